@@ -0,0 +1,36 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck
+
+// ConditionKind is a kind of custom condition which Terraform evaluates, such
+// as a resource precondition or postcondition.
+type ConditionKind string
+
+const (
+	// ConditionKindPrecondition is a precondition block in a resource, data
+	// source, or output lifecycle.
+	ConditionKindPrecondition ConditionKind = "precondition"
+
+	// ConditionKindPostcondition is a postcondition block in a resource or
+	// data source lifecycle.
+	ConditionKindPostcondition ConditionKind = "postcondition"
+)
+
+// summaries returns the diagnostic summaries Terraform reports when a
+// condition of this kind fails.
+func (k ConditionKind) summaries() []string {
+	switch k {
+	case ConditionKindPrecondition:
+		return []string{
+			"Resource precondition failed",
+			"Module output value precondition failed",
+		}
+	case ConditionKindPostcondition:
+		return []string{
+			"Resource postcondition failed",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck
+
+import (
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Diagnostic is a warning or error diagnostic reported by Terraform, parsed from the
+// machine-readable (-json) output of a Terraform command.
+type Diagnostic struct {
+	tfjson.Diagnostic
+
+	// AttributePath is the path of the configuration attribute which the diagnostic source range
+	// refers to, relative to the enclosing top-level block (e.g. resource, data, or provider).
+	// Path steps are separated by periods, and nested blocks, list elements, and tuple elements
+	// are stepped into by their 0-based index, e.g. "nested_block.0.attribute".
+	//
+	// AttributePath is empty when the diagnostic does not refer to an attribute or when the
+	// configuration cannot be inspected, such as when using JSON configuration syntax.
+	AttributePath string
+
+	// BlockAddress is the address of the top-level configuration block which the diagnostic
+	// source range refers to, such as "examplecloud_thing.test" for a resource,
+	// "data.examplecloud_thing.test" for a data source, "check.example" for a check block, or
+	// "output.example" for an output. Unlike the Address field, which Terraform only sets for
+	// some diagnostics, it is determined from the configuration, so it never includes a resource
+	// instance key.
+	//
+	// BlockAddress is empty when the diagnostic does not refer to one of those blocks or when the
+	// configuration cannot be inspected, such as when using JSON configuration syntax.
+	BlockAddress string
+}
+
+// String returns a human-readable representation of the diagnostic.
+func (d Diagnostic) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "[%s] %s", d.Severity, d.Summary)
+
+	if d.Detail != "" {
+		fmt.Fprintf(&b, ": %s", d.Detail)
+	}
+
+	var location []string
+
+	if d.Address != "" {
+		location = append(location, fmt.Sprintf("address: %s", d.Address))
+	}
+
+	if d.BlockAddress != "" && d.BlockAddress != d.Address {
+		location = append(location, fmt.Sprintf("block address: %s", d.BlockAddress))
+	}
+
+	if d.AttributePath != "" {
+		location = append(location, fmt.Sprintf("attribute path: %s", d.AttributePath))
+	}
+
+	if len(location) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(location, ", "))
+	}
+
+	return b.String()
+}
+
+// diagnosticsString returns a human-readable, newline separated representation of the diagnostics.
+func diagnosticsString(diags []Diagnostic) string {
+	if len(diags) == 0 {
+		return "no diagnostics"
+	}
+
+	lines := make([]string, 0, len(diags))
+
+	for _, diag := range diags {
+		lines = append(lines, diag.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,120 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck
+
+import (
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+// DiagnosticMatcher describes the expected fields of a single diagnostic. Fields which are not set
+// are not compared, so the zero-value DiagnosticMatcher matches any diagnostic.
+//
+// The knownvalue.Check fields are compared against string values, so use string checks such as
+// knownvalue.StringExact or knownvalue.StringRegexp.
+type DiagnosticMatcher struct {
+	// Severity is the expected diagnostic severity, such as
+	// tfjson.DiagnosticSeverityError or tfjson.DiagnosticSeverityWarning.
+	Severity tfjson.DiagnosticSeverity
+
+	// Summary checks the short description of the diagnostic.
+	Summary knownvalue.Check
+
+	// Detail checks the long description of the diagnostic.
+	Detail knownvalue.Check
+
+	// Address checks the address of the resource the diagnostic is
+	// associated with, e.g. "examplecloud_thing.test". Diagnostics not
+	// associated with a resource have an empty address.
+	Address knownvalue.Check
+
+	// AttributePath checks the configuration attribute path the diagnostic
+	// refers to, e.g. "nested_block.0.attribute". Refer to the
+	// Diagnostic type AttributePath field for the path format.
+	AttributePath knownvalue.Check
+
+	// BlockAddress checks the address of the top-level configuration block
+	// the diagnostic refers to, e.g. "examplecloud_thing.test". Refer to the
+	// Diagnostic type BlockAddress field for the address format.
+	BlockAddress knownvalue.Check
+}
+
+// Match returns an error describing the first field of the given diagnostic that does not match
+// the expectation, or nil if all fields match.
+func (m DiagnosticMatcher) Match(diag Diagnostic) error {
+	if m.Severity != "" && m.Severity != diag.Severity {
+		return fmt.Errorf("expected severity %s, got: %s", m.Severity, diag.Severity)
+	}
+
+	if m.Summary != nil {
+		if err := m.Summary.CheckValue(diag.Summary); err != nil {
+			return fmt.Errorf("summary: %s", err)
+		}
+	}
+
+	if m.Detail != nil {
+		if err := m.Detail.CheckValue(diag.Detail); err != nil {
+			return fmt.Errorf("detail: %s", err)
+		}
+	}
+
+	if m.Address != nil {
+		if err := m.Address.CheckValue(diag.Address); err != nil {
+			return fmt.Errorf("address: %s", err)
+		}
+	}
+
+	if m.AttributePath != nil {
+		if err := m.AttributePath.CheckValue(diag.AttributePath); err != nil {
+			return fmt.Errorf("attribute path: %s", err)
+		}
+	}
+
+	if m.BlockAddress != nil {
+		if err := m.BlockAddress.CheckValue(diag.BlockAddress); err != nil {
+			return fmt.Errorf("block address: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// String returns a human-readable representation of the expectation.
+func (m DiagnosticMatcher) String() string {
+	var fields []string
+
+	if m.Severity != "" {
+		fields = append(fields, fmt.Sprintf("severity: %s", m.Severity))
+	}
+
+	if m.Summary != nil {
+		fields = append(fields, fmt.Sprintf("summary: %s", m.Summary))
+	}
+
+	if m.Detail != nil {
+		fields = append(fields, fmt.Sprintf("detail: %s", m.Detail))
+	}
+
+	if m.Address != nil {
+		fields = append(fields, fmt.Sprintf("address: %s", m.Address))
+	}
+
+	if m.AttributePath != nil {
+		fields = append(fields, fmt.Sprintf("attribute path: %s", m.AttributePath))
+	}
+
+	if m.BlockAddress != nil {
+		fields = append(fields, fmt.Sprintf("block address: %s", m.BlockAddress))
+	}
+
+	if len(fields) == 0 {
+		return "any diagnostic"
+	}
+
+	return strings.Join(fields, ", ")
+}
@@ -0,0 +1,144 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestDiagnosticMatcher_Match(t *testing.T) {
+	t.Parallel()
+
+	diag := diagcheck.Diagnostic{
+		Diagnostic: tfjson.Diagnostic{
+			Severity: tfjson.DiagnosticSeverityError,
+			Summary:  "Invalid Attribute Value",
+			Detail:   "Attribute name must be at least 3 characters.",
+			Address:  "test_resource.test",
+		},
+		BlockAddress:  "test_resource.test",
+		AttributePath: "nested_block.0.name",
+	}
+
+	testCases := map[string]struct {
+		matcher       diagcheck.DiagnosticMatcher
+		expectedError error
+	}{
+		"zero-value": {
+			matcher: diagcheck.DiagnosticMatcher{},
+		},
+		"all-fields": {
+			matcher: diagcheck.DiagnosticMatcher{
+				Severity:      tfjson.DiagnosticSeverityError,
+				Summary:       knownvalue.StringExact("Invalid Attribute Value"),
+				Detail:        knownvalue.StringRegexp(regexp.MustCompile(`at least 3`)),
+				Address:       knownvalue.StringExact("test_resource.test"),
+				AttributePath: knownvalue.StringExact("nested_block.0.name"),
+				BlockAddress:  knownvalue.StringExact("test_resource.test"),
+			},
+		},
+		"severity-mismatch": {
+			matcher: diagcheck.DiagnosticMatcher{
+				Severity: tfjson.DiagnosticSeverityWarning,
+			},
+			expectedError: fmt.Errorf("expected severity warning, got: error"),
+		},
+		"summary-mismatch": {
+			matcher: diagcheck.DiagnosticMatcher{
+				Summary: knownvalue.StringExact("Missing Attribute"),
+			},
+			expectedError: fmt.Errorf("summary: expected value Missing Attribute for StringExact check, got: Invalid Attribute Value"),
+		},
+		"detail-mismatch": {
+			matcher: diagcheck.DiagnosticMatcher{
+				Detail: knownvalue.StringRegexp(regexp.MustCompile(`^must`)),
+			},
+			expectedError: fmt.Errorf("detail: expected regex match ^must for StringRegexp check, got: Attribute name must be at least 3 characters."),
+		},
+		"address-mismatch": {
+			matcher: diagcheck.DiagnosticMatcher{
+				Address: knownvalue.StringExact("test_resource.other"),
+			},
+			expectedError: fmt.Errorf("address: expected value test_resource.other for StringExact check, got: test_resource.test"),
+		},
+		"attribute-path-mismatch": {
+			matcher: diagcheck.DiagnosticMatcher{
+				AttributePath: knownvalue.StringExact("name"),
+			},
+			expectedError: fmt.Errorf("attribute path: expected value name for StringExact check, got: nested_block.0.name"),
+		},
+		"block-address-mismatch": {
+			matcher: diagcheck.DiagnosticMatcher{
+				BlockAddress: knownvalue.StringExact("test_resource.other"),
+			},
+			expectedError: fmt.Errorf("block address: expected value test_resource.other for StringExact check, got: test_resource.test"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.matcher.Match(diag)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDiagnosticMatcher_String(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		matcher  diagcheck.DiagnosticMatcher
+		expected string
+	}{
+		"zero-value": {
+			matcher:  diagcheck.DiagnosticMatcher{},
+			expected: "any diagnostic",
+		},
+		"all-fields": {
+			matcher: diagcheck.DiagnosticMatcher{
+				Severity:      tfjson.DiagnosticSeverityError,
+				Summary:       knownvalue.StringExact("Invalid Attribute Value"),
+				Detail:        knownvalue.StringRegexp(regexp.MustCompile(`at least 3`)),
+				Address:       knownvalue.StringExact("test_resource.test"),
+				AttributePath: knownvalue.StringExact("name"),
+				BlockAddress:  knownvalue.StringExact("test_resource.test"),
+			},
+			expected: "severity: error, summary: Invalid Attribute Value, detail: at least 3, address: test_resource.test, attribute path: name, block address: test_resource.test",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.matcher.String()
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+// equateErrorMessage reports errors to be equal if both are nil
+// or both have the same message.
+var equateErrorMessage = cmp.Comparer(func(x, y error) bool {
+	if x == nil || y == nil {
+		return x == nil && y == nil
+	}
+	return x.Error() == y.Error()
+})
@@ -0,0 +1,5 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package diagcheck contains the diagnostic check interface, request/response structs, and common diagnostic check implementations.
+package diagcheck
@@ -0,0 +1,77 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+var _ DiagnosticCheck = expectConditionFailure{}
+
+type expectConditionFailure struct {
+	kind    ConditionKind
+	address string
+}
+
+// CheckDiagnostics implements the diagnostic check logic.
+func (e expectConditionFailure) CheckDiagnostics(ctx context.Context, req CheckDiagnosticsRequest, resp *CheckDiagnosticsResponse) {
+	summaries := e.kind.summaries()
+
+	if summaries == nil {
+		resp.Error = fmt.Errorf("%s - unknown condition kind %q", e.address, e.kind)
+
+		return
+	}
+
+	for _, diag := range req.Diagnostics {
+		if diag.Severity != tfjson.DiagnosticSeverityError {
+			continue
+		}
+
+		if !slices.Contains(summaries, diag.Summary) {
+			continue
+		}
+
+		if e.addressMatches(diag) {
+			return
+		}
+	}
+
+	resp.Error = fmt.Errorf("expected %s failure for %s, got:\n%s", e.kind, e.address, diagnosticsString(req.Diagnostics))
+}
+
+// addressMatches returns true if the diagnostic refers to the expected
+// address, or one of its instances if the expected address does not include
+// an instance key.
+func (e expectConditionFailure) addressMatches(diag Diagnostic) bool {
+	if diag.Address == e.address || strings.HasPrefix(diag.Address, e.address+"[") {
+		return true
+	}
+
+	return diag.BlockAddress == e.address
+}
+
+// ExpectConditionFailure returns a diagnostic check that asserts that a custom
+// condition of the given kind failed for the given address, such as
+// "examplecloud_thing.test", "examplecloud_thing.test[0]", or "output.example".
+//
+// Terraform does not include an address in all condition failure diagnostics,
+// so the address is also compared with the Diagnostic type BlockAddress field,
+// which is determined from the configuration. This allows a precondition
+// failure to be distinguished from a provider error for the same resource.
+//
+// Check block assertion failures are reported as warnings rather than errors
+// and do not fail the TestStep, use the plancheck.ExpectCheckResult or
+// statecheck.ExpectCheckResult checks to verify them instead.
+func ExpectConditionFailure(kind ConditionKind, address string) DiagnosticCheck {
+	return expectConditionFailure{
+		kind:    kind,
+		address: address,
+	}
+}
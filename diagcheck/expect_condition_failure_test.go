@@ -0,0 +1,101 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+)
+
+func TestExpectConditionFailure(t *testing.T) {
+	t.Parallel()
+
+	diags := []diagcheck.Diagnostic{
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityError,
+				Summary:  "Resource precondition failed",
+				Detail:   "Length must be at least 3.",
+			},
+			BlockAddress: "test_resource.test",
+		},
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityError,
+				Summary:  "Resource postcondition failed",
+				Address:  "test_resource.other[1]",
+			},
+			BlockAddress: "test_resource.other",
+		},
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityError,
+				Summary:  "Invalid Attribute Value",
+				Address:  "test_resource.invalid",
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		diags         []diagcheck.Diagnostic
+		check         diagcheck.DiagnosticCheck
+		expectedError error
+	}{
+		"precondition-block-address": {
+			diags: diags,
+			check: diagcheck.ExpectConditionFailure(diagcheck.ConditionKindPrecondition, "test_resource.test"),
+		},
+		"postcondition-instance-address": {
+			diags: diags,
+			check: diagcheck.ExpectConditionFailure(diagcheck.ConditionKindPostcondition, "test_resource.other[1]"),
+		},
+		"postcondition-resource-address": {
+			diags: diags,
+			check: diagcheck.ExpectConditionFailure(diagcheck.ConditionKindPostcondition, "test_resource.other"),
+		},
+		"kind-mismatch": {
+			diags: diags,
+			check: diagcheck.ExpectConditionFailure(diagcheck.ConditionKindPostcondition, "test_resource.test"),
+			expectedError: fmt.Errorf("expected postcondition failure for test_resource.test, got:\n" +
+				"[error] Resource precondition failed: Length must be at least 3. (block address: test_resource.test)\n" +
+				"[error] Resource postcondition failed (address: test_resource.other[1], block address: test_resource.other)\n" +
+				"[error] Invalid Attribute Value (address: test_resource.invalid)"),
+		},
+		"not-condition-failure": {
+			diags: diags[2:],
+			check: diagcheck.ExpectConditionFailure(diagcheck.ConditionKindPrecondition, "test_resource.invalid"),
+			expectedError: fmt.Errorf("expected precondition failure for test_resource.invalid, got:\n" +
+				"[error] Invalid Attribute Value (address: test_resource.invalid)"),
+		},
+		"no-diagnostics": {
+			check:         diagcheck.ExpectConditionFailure(diagcheck.ConditionKindPrecondition, "test_resource.test"),
+			expectedError: fmt.Errorf("expected precondition failure for test_resource.test, got:\nno diagnostics"),
+		},
+		"unknown-kind": {
+			diags:         diags,
+			check:         diagcheck.ExpectConditionFailure("assertion", "test_resource.test"),
+			expectedError: fmt.Errorf(`test_resource.test - unknown condition kind "assertion"`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := diagcheck.CheckDiagnosticsResponse{}
+
+			testCase.check.CheckDiagnostics(context.Background(), diagcheck.CheckDiagnosticsRequest{Diagnostics: testCase.diags}, &resp)
+
+			if diff := cmp.Diff(resp.Error, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ DiagnosticCheck = expectDiagnostic{}
+
+type expectDiagnostic struct {
+	matcher DiagnosticMatcher
+}
+
+// CheckDiagnostics implements the diagnostic check logic.
+func (e expectDiagnostic) CheckDiagnostics(ctx context.Context, req CheckDiagnosticsRequest, resp *CheckDiagnosticsResponse) {
+	for _, diag := range req.Diagnostics {
+		if e.matcher.Match(diag) == nil {
+			return
+		}
+	}
+
+	resp.Error = fmt.Errorf("expected diagnostic matching (%s), got:\n%s", e.matcher, diagnosticsString(req.Diagnostics))
+}
+
+// ExpectDiagnostic returns a diagnostic check that asserts that at least one diagnostic matches
+// all fields set in the given DiagnosticMatcher.
+func ExpectDiagnostic(matcher DiagnosticMatcher) DiagnosticCheck {
+	return expectDiagnostic{
+		matcher: matcher,
+	}
+}
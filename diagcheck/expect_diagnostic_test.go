@@ -0,0 +1,79 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestExpectDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	diags := []diagcheck.Diagnostic{
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityWarning,
+				Summary:  "Deprecated Attribute",
+			},
+		},
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityError,
+				Summary:  "Invalid Attribute Value",
+				Address:  "test_resource.test",
+			},
+			AttributePath: "name",
+		},
+	}
+
+	testCases := map[string]struct {
+		diags         []diagcheck.Diagnostic
+		check         diagcheck.DiagnosticCheck
+		expectedError error
+	}{
+		"match": {
+			diags: diags,
+			check: diagcheck.ExpectDiagnostic(diagcheck.DiagnosticMatcher{
+				Severity:      tfjson.DiagnosticSeverityError,
+				AttributePath: knownvalue.StringExact("name"),
+			}),
+		},
+		"no-match": {
+			diags: diags,
+			check: diagcheck.ExpectDiagnostic(diagcheck.DiagnosticMatcher{
+				Severity: tfjson.DiagnosticSeverityWarning,
+				Summary:  knownvalue.StringExact("Invalid Attribute Value"),
+			}),
+			expectedError: fmt.Errorf("expected diagnostic matching (severity: warning, summary: Invalid Attribute Value), got:\n" +
+				"[warning] Deprecated Attribute\n" +
+				"[error] Invalid Attribute Value (address: test_resource.test, attribute path: name)"),
+		},
+		"no-diagnostics": {
+			check:         diagcheck.ExpectDiagnostic(diagcheck.DiagnosticMatcher{}),
+			expectedError: fmt.Errorf("expected diagnostic matching (any diagnostic), got:\nno diagnostics"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := diagcheck.CheckDiagnosticsResponse{}
+
+			testCase.check.CheckDiagnostics(context.Background(), diagcheck.CheckDiagnosticsRequest{Diagnostics: testCase.diags}, &resp)
+
+			if diff := cmp.Diff(resp.Error, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
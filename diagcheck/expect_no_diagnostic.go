@@ -0,0 +1,34 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ DiagnosticCheck = expectNoDiagnostic{}
+
+type expectNoDiagnostic struct {
+	matcher DiagnosticMatcher
+}
+
+// CheckDiagnostics implements the diagnostic check logic.
+func (e expectNoDiagnostic) CheckDiagnostics(ctx context.Context, req CheckDiagnosticsRequest, resp *CheckDiagnosticsResponse) {
+	for _, diag := range req.Diagnostics {
+		if e.matcher.Match(diag) == nil {
+			resp.Error = fmt.Errorf("expected no diagnostic matching (%s), got: %s", e.matcher, diag)
+
+			return
+		}
+	}
+}
+
+// ExpectNoDiagnostic returns a diagnostic check that asserts that no diagnostic matches all fields
+// set in the given DiagnosticMatcher.
+func ExpectNoDiagnostic(matcher DiagnosticMatcher) DiagnosticCheck {
+	return expectNoDiagnostic{
+		matcher: matcher,
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestExpectNoDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	diags := []diagcheck.Diagnostic{
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityError,
+				Summary:  "Invalid Attribute Value",
+				Detail:   "Attribute name must be at least 3 characters.",
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		diags         []diagcheck.Diagnostic
+		check         diagcheck.DiagnosticCheck
+		expectedError error
+	}{
+		"no-match": {
+			diags: diags,
+			check: diagcheck.ExpectNoDiagnostic(diagcheck.DiagnosticMatcher{
+				Severity: tfjson.DiagnosticSeverityWarning,
+			}),
+		},
+		"no-diagnostics": {
+			check: diagcheck.ExpectNoDiagnostic(diagcheck.DiagnosticMatcher{}),
+		},
+		"match": {
+			diags: diags,
+			check: diagcheck.ExpectNoDiagnostic(diagcheck.DiagnosticMatcher{
+				Summary: knownvalue.StringExact("Invalid Attribute Value"),
+			}),
+			expectedError: fmt.Errorf("expected no diagnostic matching (summary: Invalid Attribute Value), got: " +
+				"[error] Invalid Attribute Value: Attribute name must be at least 3 characters."),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := diagcheck.CheckDiagnosticsResponse{}
+
+			testCase.check.CheckDiagnostics(context.Background(), diagcheck.CheckDiagnosticsRequest{Diagnostics: testCase.diags}, &resp)
+
+			if diff := cmp.Diff(resp.Error, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
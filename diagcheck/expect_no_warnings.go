@@ -0,0 +1,57 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+var _ DiagnosticCheck = expectNoWarnings{}
+
+type expectNoWarnings struct {
+	allowed []DiagnosticMatcher
+}
+
+// CheckDiagnostics implements the diagnostic check logic.
+func (e expectNoWarnings) CheckDiagnostics(ctx context.Context, req CheckDiagnosticsRequest, resp *CheckDiagnosticsResponse) {
+	var unexpected []string
+
+	for _, diag := range req.Diagnostics {
+		if diag.Severity != tfjson.DiagnosticSeverityWarning {
+			continue
+		}
+
+		if e.isAllowed(diag) {
+			continue
+		}
+
+		unexpected = append(unexpected, diag.String())
+	}
+
+	if len(unexpected) > 0 {
+		resp.Error = fmt.Errorf("expected no warnings, got:\n%s", strings.Join(unexpected, "\n"))
+	}
+}
+
+func (e expectNoWarnings) isAllowed(diag Diagnostic) bool {
+	for _, matcher := range e.allowed {
+		if matcher.Match(diag) == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExpectNoWarnings returns a diagnostic check that asserts that there are no warning diagnostics,
+// other than those matching all fields set in any of the given allowed DiagnosticMatcher.
+func ExpectNoWarnings(allowed ...DiagnosticMatcher) DiagnosticCheck {
+	return expectNoWarnings{
+		allowed: allowed,
+	}
+}
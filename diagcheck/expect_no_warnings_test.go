@@ -0,0 +1,96 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestExpectNoWarnings(t *testing.T) {
+	t.Parallel()
+
+	diags := []diagcheck.Diagnostic{
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityError,
+				Summary:  "Invalid Attribute Value",
+			},
+		},
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityWarning,
+				Summary:  "Attribute Deprecated",
+			},
+			AttributePath: "old_name",
+		},
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityWarning,
+				Summary:  "Provider Deprecated",
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		diags         []diagcheck.Diagnostic
+		check         diagcheck.DiagnosticCheck
+		expectedError error
+	}{
+		"no-diagnostics": {
+			check: diagcheck.ExpectNoWarnings(),
+		},
+		"errors-only": {
+			diags: diags[:1],
+			check: diagcheck.ExpectNoWarnings(),
+		},
+		"warnings": {
+			diags: diags,
+			check: diagcheck.ExpectNoWarnings(),
+			expectedError: fmt.Errorf("expected no warnings, got:\n" +
+				"[warning] Attribute Deprecated (attribute path: old_name)\n" +
+				"[warning] Provider Deprecated"),
+		},
+		"warnings-partially-allowed": {
+			diags: diags,
+			check: diagcheck.ExpectNoWarnings(diagcheck.DiagnosticMatcher{
+				AttributePath: knownvalue.StringExact("old_name"),
+			}),
+			expectedError: fmt.Errorf("expected no warnings, got:\n" +
+				"[warning] Provider Deprecated"),
+		},
+		"warnings-allowed": {
+			diags: diags,
+			check: diagcheck.ExpectNoWarnings(
+				diagcheck.DiagnosticMatcher{
+					AttributePath: knownvalue.StringExact("old_name"),
+				},
+				diagcheck.DiagnosticMatcher{
+					Summary: knownvalue.StringExact("Provider Deprecated"),
+				},
+			),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := diagcheck.CheckDiagnosticsResponse{}
+
+			testCase.check.CheckDiagnostics(context.Background(), diagcheck.CheckDiagnosticsRequest{Diagnostics: testCase.diags}, &resp)
+
+			if diff := cmp.Diff(resp.Error, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck
+
+import (
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// ExpectWarning returns a diagnostic check that asserts that at least one warning diagnostic
+// matches all fields set in the given DiagnosticMatcher. The DiagnosticMatcher Severity field
+// is ignored.
+func ExpectWarning(matcher DiagnosticMatcher) DiagnosticCheck {
+	matcher.Severity = tfjson.DiagnosticSeverityWarning
+
+	return expectDiagnostic{
+		matcher: matcher,
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagcheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestExpectWarning(t *testing.T) {
+	t.Parallel()
+
+	diags := []diagcheck.Diagnostic{
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityError,
+				Summary:  "Invalid Attribute Value",
+			},
+		},
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityWarning,
+				Summary:  "Attribute Deprecated",
+			},
+			AttributePath: "old_name",
+		},
+	}
+
+	testCases := map[string]struct {
+		diags         []diagcheck.Diagnostic
+		check         diagcheck.DiagnosticCheck
+		expectedError error
+	}{
+		"match": {
+			diags: diags,
+			check: diagcheck.ExpectWarning(diagcheck.DiagnosticMatcher{
+				Summary:       knownvalue.StringExact("Attribute Deprecated"),
+				AttributePath: knownvalue.StringExact("old_name"),
+			}),
+		},
+		"severity-ignored": {
+			diags: diags,
+			check: diagcheck.ExpectWarning(diagcheck.DiagnosticMatcher{
+				Severity: tfjson.DiagnosticSeverityError,
+				Summary:  knownvalue.StringExact("Attribute Deprecated"),
+			}),
+		},
+		"error-not-matched": {
+			diags: diags,
+			check: diagcheck.ExpectWarning(diagcheck.DiagnosticMatcher{
+				Summary: knownvalue.StringExact("Invalid Attribute Value"),
+			}),
+			expectedError: fmt.Errorf("expected diagnostic matching (severity: warning, summary: Invalid Attribute Value), got:\n" +
+				"[error] Invalid Attribute Value\n" +
+				"[warning] Attribute Deprecated (attribute path: old_name)"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := diagcheck.CheckDiagnosticsResponse{}
+
+			testCase.check.CheckDiagnostics(context.Background(), diagcheck.CheckDiagnosticsRequest{Diagnostics: testCase.diags}, &resp)
+
+			if diff := cmp.Diff(resp.Error, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
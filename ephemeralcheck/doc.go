@@ -0,0 +1,6 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package ephemeralcheck contains the ephemeral check interface, request/response structs, and common ephemeral
+// resource lifecycle check implementations.
+package ephemeralcheck
@@ -0,0 +1,36 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeralcheck
+
+import (
+	"context"
+)
+
+// EphemeralCheck defines an interface for implementing test logic that checks the ephemeral resource lifecycle
+// RPCs Terraform called on the in-process provider servers during a TestStep and then returns an error if the
+// lifecycle does not match what is expected.
+type EphemeralCheck interface {
+	// CheckEphemeral should perform the ephemeral check.
+	CheckEphemeral(context.Context, CheckEphemeralRequest, *CheckEphemeralResponse)
+}
+
+// CheckEphemeralRequest is a request for an invoke of the CheckEphemeral function.
+type CheckEphemeralRequest struct {
+	// Events are the OpenEphemeralResource, RenewEphemeralResource, and CloseEphemeralResource RPCs called on the
+	// in-process provider servers during the TestStep, in the order they were called, across all Terraform commands
+	// run by the TestStep.
+	Events []Event
+
+	// PersistedData is the content of the files Terraform persisted in the working directory at the end of the
+	// TestStep, by name. The saved plan file is a zip archive, so each of its entries is included separately with
+	// a "planfile/" prefix, such as "planfile/tfplan". The state file is included as "terraform.tfstate".
+	PersistedData map[string][]byte
+}
+
+// CheckEphemeralResponse is a response to an invoke of the CheckEphemeral function.
+type CheckEphemeralResponse struct {
+	// Error is used to report the failure of an ephemeral check assertion and is combined with other
+	// EphemeralCheck errors to be reported as a test failure.
+	Error error
+}
@@ -0,0 +1,59 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeralcheck
+
+import (
+	"time"
+)
+
+// Operation is an ephemeral resource lifecycle operation.
+type Operation string
+
+const (
+	// Open is the OpenEphemeralResource RPC.
+	Open Operation = "Open"
+
+	// Renew is the RenewEphemeralResource RPC.
+	Renew Operation = "Renew"
+
+	// Close is the CloseEphemeralResource RPC.
+	Close Operation = "Close"
+)
+
+// Event is a single ephemeral resource lifecycle RPC to an in-process provider server.
+type Event struct {
+	// Provider is the name of the provider, such as "examplecloud".
+	Provider string
+
+	// TypeName is the ephemeral resource type name, such as "examplecloud_token".
+	TypeName string
+
+	// Operation is the lifecycle operation of the RPC.
+	Operation Operation
+
+	// Command is the Terraform command which called the RPC, such as "plan" or "apply", or an empty string if
+	// it is not known.
+	Command string
+
+	// Time is when the RPC was called.
+	Time time.Time
+
+	// RequestPrivate is the private data sent by Terraform with a Renew or Close operation.
+	RequestPrivate []byte
+
+	// ResponsePrivate is the private data returned by the provider for an Open or Renew operation.
+	ResponsePrivate []byte
+
+	// RenewAt is the time the provider requested a Renew operation for an Open or Renew operation, or the zero
+	// time if no renewal was requested.
+	RenewAt time.Time
+
+	// Result is the result of an Open operation, decoded with the ephemeral resource schema into the
+	// representation used by the knownvalue and tfjsonpath packages, or nil if it was not returned or could not
+	// be decoded.
+	Result any
+
+	// Error is the error returned by the RPC, or an error combining its error diagnostics, if any.
+	Error error
+}
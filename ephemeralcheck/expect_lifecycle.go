@@ -0,0 +1,67 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeralcheck
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+var _ EphemeralCheck = expectLifecycle{}
+
+type expectLifecycle struct {
+	command    string
+	typeName   string
+	operations []Operation
+}
+
+// CheckEphemeral implements the ephemeral check logic.
+func (e expectLifecycle) CheckEphemeral(ctx context.Context, req CheckEphemeralRequest, resp *CheckEphemeralResponse) {
+	operations := []Operation{}
+
+	for _, event := range req.Events {
+		if event.TypeName != e.typeName {
+			continue
+		}
+
+		if e.command != "" && event.Command != e.command {
+			continue
+		}
+
+		operations = append(operations, event.Operation)
+	}
+
+	if slices.Equal(operations, e.operations) {
+		return
+	}
+
+	if e.command != "" {
+		resp.Error = fmt.Errorf("%s - expected lifecycle %v during %s, got: %v", e.typeName, e.operations, e.command, operations)
+
+		return
+	}
+
+	resp.Error = fmt.Errorf("%s - expected lifecycle %v, got: %v", e.typeName, e.operations, operations)
+}
+
+// ExpectLifecycle returns an ephemeral check that asserts that the ephemeral resource type had exactly the given
+// lifecycle operations, in order, across all Terraform commands run by the TestStep. For example, a Config
+// TestStep which opens an ephemeral resource during both plan and apply would expect Open, Close, Open, Close.
+func ExpectLifecycle(typeName string, operations ...Operation) EphemeralCheck {
+	return expectLifecycle{
+		typeName:   typeName,
+		operations: append([]Operation{}, operations...),
+	}
+}
+
+// ExpectCommandLifecycle returns an ephemeral check that asserts that the ephemeral resource type had exactly the
+// given lifecycle operations, in order, during the given Terraform command, such as "plan" or "apply".
+func ExpectCommandLifecycle(command string, typeName string, operations ...Operation) EphemeralCheck {
+	return expectLifecycle{
+		command:    command,
+		typeName:   typeName,
+		operations: append([]Operation{}, operations...),
+	}
+}
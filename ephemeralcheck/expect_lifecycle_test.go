@@ -0,0 +1,86 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeralcheck_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/ephemeralcheck"
+)
+
+func TestExpectLifecycle(t *testing.T) {
+	t.Parallel()
+
+	events := []ephemeralcheck.Event{
+		{TypeName: "test_token", Operation: ephemeralcheck.Open, Command: "plan"},
+		{TypeName: "test_token", Operation: ephemeralcheck.Close, Command: "plan"},
+		{TypeName: "test_other", Operation: ephemeralcheck.Open, Command: "apply"},
+		{TypeName: "test_token", Operation: ephemeralcheck.Open, Command: "apply"},
+		{TypeName: "test_token", Operation: ephemeralcheck.Renew, Command: "apply"},
+		{TypeName: "test_token", Operation: ephemeralcheck.Close, Command: "apply"},
+	}
+
+	testCases := map[string]struct {
+		check       ephemeralcheck.EphemeralCheck
+		expectedErr *regexp.Regexp
+	}{
+		"match": {
+			check: ephemeralcheck.ExpectLifecycle("test_token", ephemeralcheck.Open, ephemeralcheck.Close, ephemeralcheck.Open, ephemeralcheck.Renew, ephemeralcheck.Close),
+		},
+		"match-other": {
+			check: ephemeralcheck.ExpectLifecycle("test_other", ephemeralcheck.Open),
+		},
+		"match-none": {
+			check: ephemeralcheck.ExpectLifecycle("test_missing"),
+		},
+		"mismatch": {
+			check:       ephemeralcheck.ExpectLifecycle("test_token", ephemeralcheck.Open, ephemeralcheck.Close),
+			expectedErr: regexp.MustCompile(`^test_token - expected lifecycle \[Open Close\], got: \[Open Close Open Renew Close\]$`),
+		},
+		"command-match": {
+			check: ephemeralcheck.ExpectCommandLifecycle("apply", "test_token", ephemeralcheck.Open, ephemeralcheck.Renew, ephemeralcheck.Close),
+		},
+		"command-mismatch": {
+			check:       ephemeralcheck.ExpectCommandLifecycle("plan", "test_token", ephemeralcheck.Open, ephemeralcheck.Renew, ephemeralcheck.Close),
+			expectedErr: regexp.MustCompile(`^test_token - expected lifecycle \[Open Renew Close\] during plan, got: \[Open Close\]$`),
+		},
+		"command-mismatch-none": {
+			check:       ephemeralcheck.ExpectCommandLifecycle("refresh", "test_token", ephemeralcheck.Open, ephemeralcheck.Close),
+			expectedErr: regexp.MustCompile(`^test_token - expected lifecycle \[Open Close\] during refresh, got: \[\]$`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := ephemeralcheck.CheckEphemeralResponse{}
+			testCase.check.CheckEphemeral(context.Background(), ephemeralcheck.CheckEphemeralRequest{Events: events}, &resp)
+
+			assertError(t, resp.Error, testCase.expectedErr)
+		})
+	}
+}
+
+func assertError(t *testing.T, err error, expectedErr *regexp.Regexp) {
+	t.Helper()
+
+	if expectedErr == nil {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		return
+	}
+
+	if err == nil {
+		t.Fatalf("expected error matching %q, got none", expectedErr)
+	}
+
+	if !expectedErr.MatchString(err.Error()) {
+		t.Fatalf("expected error matching %q, got: %s", expectedErr, err)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeralcheck
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+var _ EphemeralCheck = expectNotPersisted{}
+
+type expectNotPersisted struct {
+	typeName string
+}
+
+// CheckEphemeral implements the ephemeral check logic.
+func (e expectNotPersisted) CheckEphemeral(ctx context.Context, req CheckEphemeralRequest, resp *CheckEphemeralResponse) {
+	values := make(map[string]string)
+	var found bool
+
+	for _, event := range req.Events {
+		if event.TypeName != e.typeName || event.Operation != Open || event.Result == nil {
+			continue
+		}
+
+		found = true
+
+		stringValues("", event.Result, values)
+	}
+
+	if !found {
+		resp.Error = fmt.Errorf("%s - expected Open operation(s) with a result, got none", e.typeName)
+
+		return
+	}
+
+	var result []error
+
+	for _, name := range slices.Sorted(maps.Keys(req.PersistedData)) {
+		for _, value := range slices.Sorted(maps.Keys(values)) {
+			// The value itself is not included in the error, as it may be
+			// sensitive.
+			if bytes.Contains(req.PersistedData[name], []byte(value)) {
+				result = append(result, fmt.Errorf("%s - ephemeral value of %s found in %s", e.typeName, values[value], name))
+			}
+		}
+	}
+
+	resp.Error = errors.Join(result...)
+}
+
+// stringValues adds the non-empty string values of an Open result to values,
+// keyed by value with their path, such as "credentials.token".
+func stringValues(path string, value any, values map[string]string) {
+	switch value := value.(type) {
+	case string:
+		if value == "" {
+			return
+		}
+
+		if _, ok := values[value]; !ok {
+			values[value] = path
+		}
+	case []any:
+		for i, element := range value {
+			stringValues(path+"["+strconv.Itoa(i)+"]", element, values)
+		}
+	case map[string]any:
+		for _, name := range slices.Sorted(maps.Keys(value)) {
+			attributePath := name
+
+			if path != "" {
+				attributePath = path + "." + name
+			}
+
+			stringValues(attributePath, value[name], values)
+		}
+	}
+}
+
+// ExpectNotPersisted returns an ephemeral check that asserts that no string value of the results of the Open
+// operations of the ephemeral resource type appears in the saved plan file or state file at the end of the
+// TestStep. Numbers and booleans are not checked, and the TestStep configuration should use distinctive values
+// so that they are not found by coincidence, such as in other attributes or provider metadata.
+func ExpectNotPersisted(typeName string) EphemeralCheck {
+	return expectNotPersisted{
+		typeName: typeName,
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeralcheck_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/ephemeralcheck"
+)
+
+func TestExpectNotPersisted(t *testing.T) {
+	t.Parallel()
+
+	events := []ephemeralcheck.Event{
+		{
+			TypeName:  "test_token",
+			Operation: ephemeralcheck.Open,
+			Result: map[string]any{
+				"token":   "secret-token-value",
+				"scopes":  []any{"secret-scope-value"},
+				"expires": nil,
+				"empty":   "",
+			},
+		},
+		{TypeName: "test_token", Operation: ephemeralcheck.Close},
+	}
+
+	testCases := map[string]struct {
+		events        []ephemeralcheck.Event
+		persistedData map[string][]byte
+		expectedErr   *regexp.Regexp
+	}{
+		"not-persisted": {
+			events: events,
+			persistedData: map[string][]byte{
+				"planfile/tfplan":   []byte(`other`),
+				"terraform.tfstate": []byte(`{"resources":[]}`),
+			},
+		},
+		"persisted": {
+			events: events,
+			persistedData: map[string][]byte{
+				"planfile/tfplan":   []byte(`...secret-scope-value...`),
+				"terraform.tfstate": []byte(`{"value":"secret-token-value"}`),
+			},
+			expectedErr: regexp.MustCompile(`^test_token - ephemeral value of scopes\[0\] found in planfile/tfplan\ntest_token - ephemeral value of token found in terraform.tfstate$`),
+		},
+		"no-results": {
+			events:      []ephemeralcheck.Event{{TypeName: "test_token", Operation: ephemeralcheck.Open, Error: nil}},
+			expectedErr: regexp.MustCompile(`^test_token - expected Open operation\(s\) with a result, got none$`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := ephemeralcheck.CheckEphemeralResponse{}
+			ephemeralcheck.ExpectNotPersisted("test_token").CheckEphemeral(context.Background(), ephemeralcheck.CheckEphemeralRequest{Events: testCase.events, PersistedData: testCase.persistedData}, &resp)
+
+			assertError(t, resp.Error, testCase.expectedErr)
+		})
+	}
+}
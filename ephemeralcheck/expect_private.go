@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeralcheck
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+)
+
+var _ EphemeralCheck = expectPrivate{}
+
+type expectPrivate struct {
+	typeName  string
+	operation Operation
+	private   []byte
+}
+
+// CheckEphemeral implements the ephemeral check logic.
+func (e expectPrivate) CheckEphemeral(ctx context.Context, req CheckEphemeralRequest, resp *CheckEphemeralResponse) {
+	var result []error
+	var found bool
+
+	for _, event := range req.Events {
+		if event.TypeName != e.typeName || event.Operation != e.operation {
+			continue
+		}
+
+		found = true
+
+		private := event.RequestPrivate
+
+		if event.Operation == Open {
+			private = event.ResponsePrivate
+		}
+
+		if !bytes.Equal(private, e.private) {
+			result = append(result, fmt.Errorf("%s - expected %s private data %q, got: %q", e.typeName, e.operation, e.private, private))
+		}
+	}
+
+	if !found {
+		resp.Error = fmt.Errorf("%s - expected %s operation(s), got none", e.typeName, e.operation)
+
+		return
+	}
+
+	resp.Error = errors.Join(result...)
+}
+
+// ExpectPrivate returns an ephemeral check that asserts that every operation of the ephemeral resource type had
+// the given private data. For an Open operation, the private data returned by the provider is checked. For a
+// Renew or Close operation, the private data sent by Terraform is checked, which is the private data returned by
+// the preceding Open or Renew operation.
+func ExpectPrivate(typeName string, operation Operation, private []byte) EphemeralCheck {
+	return expectPrivate{
+		typeName:  typeName,
+		operation: operation,
+		private:   private,
+	}
+}
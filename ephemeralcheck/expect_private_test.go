@@ -0,0 +1,56 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeralcheck_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/ephemeralcheck"
+)
+
+func TestExpectPrivate(t *testing.T) {
+	t.Parallel()
+
+	events := []ephemeralcheck.Event{
+		{TypeName: "test_token", Operation: ephemeralcheck.Open, ResponsePrivate: []byte(`{"lease":"1"}`)},
+		{TypeName: "test_token", Operation: ephemeralcheck.Renew, RequestPrivate: []byte(`{"lease":"1"}`), ResponsePrivate: []byte(`{"lease":"2"}`)},
+		{TypeName: "test_token", Operation: ephemeralcheck.Close, RequestPrivate: []byte(`{"lease":"2"}`)},
+	}
+
+	testCases := map[string]struct {
+		check       ephemeralcheck.EphemeralCheck
+		expectedErr *regexp.Regexp
+	}{
+		"open": {
+			check: ephemeralcheck.ExpectPrivate("test_token", ephemeralcheck.Open, []byte(`{"lease":"1"}`)),
+		},
+		"renew": {
+			check: ephemeralcheck.ExpectPrivate("test_token", ephemeralcheck.Renew, []byte(`{"lease":"1"}`)),
+		},
+		"close": {
+			check: ephemeralcheck.ExpectPrivate("test_token", ephemeralcheck.Close, []byte(`{"lease":"2"}`)),
+		},
+		"mismatch": {
+			check:       ephemeralcheck.ExpectPrivate("test_token", ephemeralcheck.Close, []byte(`{"lease":"1"}`)),
+			expectedErr: regexp.MustCompile(`^test_token - expected Close private data "{\\"lease\\":\\"1\\"}", got: "{\\"lease\\":\\"2\\"}"$`),
+		},
+		"no-operations": {
+			check:       ephemeralcheck.ExpectPrivate("test_other", ephemeralcheck.Close, nil),
+			expectedErr: regexp.MustCompile(`^test_other - expected Close operation\(s\), got none$`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := ephemeralcheck.CheckEphemeralResponse{}
+			testCase.check.CheckEphemeral(context.Background(), ephemeralcheck.CheckEphemeralRequest{Events: events}, &resp)
+
+			assertError(t, resp.Error, testCase.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeralcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var _ EphemeralCheck = expectRenewAtWithin{}
+
+type expectRenewAtWithin struct {
+	typeName string
+	min      time.Duration
+	max      time.Duration
+}
+
+// CheckEphemeral implements the ephemeral check logic.
+func (e expectRenewAtWithin) CheckEphemeral(ctx context.Context, req CheckEphemeralRequest, resp *CheckEphemeralResponse) {
+	var result []error
+	var found bool
+
+	for _, event := range req.Events {
+		if event.TypeName != e.typeName || event.Error != nil {
+			continue
+		}
+
+		if event.Operation != Open && event.Operation != Renew {
+			continue
+		}
+
+		found = true
+
+		if event.RenewAt.IsZero() {
+			result = append(result, fmt.Errorf("%s - expected %s RenewAt, got none", e.typeName, event.Operation))
+
+			continue
+		}
+
+		renewAfter := event.RenewAt.Sub(event.Time)
+
+		if renewAfter < e.min || renewAfter > e.max {
+			result = append(result, fmt.Errorf("%s - expected %s RenewAt between %s and %s after the call, got: %s", e.typeName, event.Operation, e.min, e.max, renewAfter))
+		}
+	}
+
+	if !found {
+		resp.Error = fmt.Errorf("%s - expected successful Open or Renew operation(s), got none", e.typeName)
+
+		return
+	}
+
+	resp.Error = errors.Join(result...)
+}
+
+// ExpectRenewAtWithin returns an ephemeral check that asserts that every successful Open and Renew operation of
+// the ephemeral resource type returned a RenewAt time between min and max after the operation was called. For
+// example, a provider which renews a lease 30 seconds before it expires could be checked with a lease duration
+// of 5 minutes as ExpectRenewAtWithin("examplecloud_lease", 4*time.Minute, 5*time.Minute).
+func ExpectRenewAtWithin(typeName string, minimum time.Duration, maximum time.Duration) EphemeralCheck {
+	return expectRenewAtWithin{
+		typeName: typeName,
+		min:      minimum,
+		max:      maximum,
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeralcheck_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/ephemeralcheck"
+)
+
+func TestExpectRenewAtWithin(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		events      []ephemeralcheck.Event
+		expectedErr *regexp.Regexp
+	}{
+		"match": {
+			events: []ephemeralcheck.Event{
+				{TypeName: "test_lease", Operation: ephemeralcheck.Open, Time: now, RenewAt: now.Add(4 * time.Minute)},
+				{TypeName: "test_lease", Operation: ephemeralcheck.Renew, Time: now.Add(4 * time.Minute), RenewAt: now.Add(9 * time.Minute)},
+				{TypeName: "test_lease", Operation: ephemeralcheck.Close, Time: now.Add(5 * time.Minute)},
+				{TypeName: "test_other", Operation: ephemeralcheck.Open, Time: now},
+			},
+		},
+		"ignores-errors": {
+			events: []ephemeralcheck.Event{
+				{TypeName: "test_lease", Operation: ephemeralcheck.Open, Time: now, RenewAt: now.Add(4 * time.Minute)},
+				{TypeName: "test_lease", Operation: ephemeralcheck.Renew, Time: now, Error: errors.New("test")},
+			},
+		},
+		"too-early": {
+			events: []ephemeralcheck.Event{
+				{TypeName: "test_lease", Operation: ephemeralcheck.Open, Time: now, RenewAt: now.Add(time.Minute)},
+			},
+			expectedErr: regexp.MustCompile(`^test_lease - expected Open RenewAt between 3m0s and 5m0s after the call, got: 1m0s$`),
+		},
+		"too-late": {
+			events: []ephemeralcheck.Event{
+				{TypeName: "test_lease", Operation: ephemeralcheck.Open, Time: now, RenewAt: now.Add(4 * time.Minute)},
+				{TypeName: "test_lease", Operation: ephemeralcheck.Renew, Time: now, RenewAt: now.Add(time.Hour)},
+			},
+			expectedErr: regexp.MustCompile(`^test_lease - expected Renew RenewAt between 3m0s and 5m0s after the call, got: 1h0m0s$`),
+		},
+		"missing-renew-at": {
+			events: []ephemeralcheck.Event{
+				{TypeName: "test_lease", Operation: ephemeralcheck.Open, Time: now},
+			},
+			expectedErr: regexp.MustCompile(`^test_lease - expected Open RenewAt, got none$`),
+		},
+		"no-operations": {
+			events: []ephemeralcheck.Event{
+				{TypeName: "test_other", Operation: ephemeralcheck.Open, Time: now, RenewAt: now.Add(4 * time.Minute)},
+			},
+			expectedErr: regexp.MustCompile(`^test_lease - expected successful Open or Renew operation\(s\), got none$`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := ephemeralcheck.CheckEphemeralResponse{}
+			ephemeralcheck.ExpectRenewAtWithin("test_lease", 3*time.Minute, 5*time.Minute).CheckEphemeral(context.Background(), ephemeralcheck.CheckEphemeralRequest{Events: testCase.events}, &resp)
+
+			assertError(t, resp.Error, testCase.expectedErr)
+		})
+	}
+}
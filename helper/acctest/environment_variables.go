@@ -0,0 +1,18 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package acctest
+
+// Environment variables for recording and replaying HTTP interactions with
+// the Recorder.
+const (
+	// Environment variable to record or replay the HTTP interactions of
+	// each Recorder. Valid values are "record" and "replay". When unset,
+	// the Recorder transport sends requests without recording them.
+	EnvTfAccRecordMode = "TF_ACC_RECORD_MODE"
+
+	// Environment variable with the directory of the recording files used
+	// when TF_ACC_RECORD_MODE is set. Defaults to testdata/recordings, unless
+	// the RecorderConfig Dir is set.
+	EnvTfAccRecordDir = "TF_ACC_RECORD_DIR"
+)
@@ -36,7 +36,7 @@ func RandomWithPrefix(name string) string {
 
 // RandIntRange returns a random integer between minInt (inclusive) and maxInt (exclusive)
 func RandIntRange(minInt int, maxInt int) int {
-	return rand.Intn(maxInt-minInt) + minInt
+	return randIntRange(rand.Intn, minInt, maxInt)
 }
 
 // RandString generates a random alphanumeric string of the length specified
@@ -47,9 +47,17 @@ func RandString(strlen int) string {
 // RandStringFromCharSet generates a random string by selecting characters from
 // the charset provided
 func RandStringFromCharSet(strlen int, charSet string) string {
+	return randStringFromCharSet(rand.Intn, strlen, charSet)
+}
+
+func randIntRange(intn func(int) int, minInt int, maxInt int) int {
+	return intn(maxInt-minInt) + minInt
+}
+
+func randStringFromCharSet(intn func(int) int, strlen int, charSet string) string {
 	result := make([]byte, strlen)
 	for i := 0; i < strlen; i++ {
-		result[i] = charSet[RandIntRange(0, len(charSet))]
+		result[i] = charSet[randIntRange(intn, 0, len(charSet))]
 	}
 	return string(result)
 }
@@ -123,6 +131,10 @@ func RandTLSCert(orgName string) (string, string, error) {
 
 // RandIpAddress returns a random IP address in the specified CIDR block.
 func RandIpAddress(s string) (string, error) {
+	return randIpAddress(crand.Read, s)
+}
+
+func randIpAddress(read func([]byte) (int, error), s string) (string, error) {
 	prefix, err := netip.ParsePrefix(s)
 	if err != nil {
 		return "", err
@@ -146,7 +158,7 @@ func RandIpAddress(s string) (string, error) {
 
 	// the result starts life as 4 or 16 bytes of random data
 	resultBytes := make([]byte, len(inverseMaskBytes))
-	_, err = crand.Read(resultBytes)
+	_, err = read(resultBytes)
 	if err != nil {
 		return "", err
 	}
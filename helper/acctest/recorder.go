@@ -0,0 +1,562 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package acctest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mitchellh/go-testing-interface"
+)
+
+// RecorderMode determines whether a Recorder records or replays HTTP
+// interactions.
+type RecorderMode string
+
+const (
+	// RecorderModeDisabled sends requests without recording them.
+	RecorderModeDisabled RecorderMode = ""
+
+	// RecorderModeRecord sends requests and records the sanitized requests
+	// and responses.
+	RecorderModeRecord RecorderMode = "record"
+
+	// RecorderModeReplay returns the recorded response for every request
+	// with a matching recorded request, without sending it.
+	RecorderModeReplay RecorderMode = "replay"
+)
+
+// RecorderRedacted is the value which replaces redacted fields in recorded
+// requests and responses.
+const RecorderRedacted = "REDACTED"
+
+// defaultRecorderDir is the default directory of recording files, relative to
+// the test package.
+const defaultRecorderDir = "testdata/recordings"
+
+// defaultRecorderStripHeaders are always removed from recorded requests and
+// responses, as they typically contain credentials.
+var defaultRecorderStripHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+}
+
+// RecorderConfig configures a Recorder.
+type RecorderConfig struct {
+	// Dir is the directory of the recording files. Defaults to the
+	// TF_ACC_RECORD_DIR environment variable, or testdata/recordings.
+	Dir string
+
+	// StripHeaders are the names of headers to remove from recorded requests
+	// and responses, in addition to the Authorization, Cookie,
+	// Proxy-Authorization, and Set-Cookie headers.
+	StripHeaders []string
+
+	// RedactFields are the names of JSON object fields, form fields, and
+	// query parameters in recorded requests and responses whose values are
+	// replaced with RecorderRedacted. Redacted request values are also
+	// ignored when matching requests during replay.
+	RedactFields []string
+}
+
+// Recorder records the HTTP interactions between provider client code and
+// remote APIs during a test, and replays them so the test can be rerun
+// without credentials or network access. The mode is determined by the
+// TF_ACC_RECORD_MODE environment variable.
+//
+// Interactions are recorded to a file per test name, and keyed by the
+// TestStep number, which is set by the testing framework when the Recorder is
+// given in the TestCase HTTPRecorder field. Interactions outside of a TestStep,
+// such as during the post-test destroy, use the step number 0.
+//
+// Random values for resource names and other configuration must be generated
+// with the Recorder random methods, such as RandomWithPrefix, which use a
+// seed saved in the recording, so that replays reproduce the same requests.
+// The package-level random functions, such as RandomWithPrefix, are never
+// seeded, so tests must be migrated to the Recorder methods before recording.
+type Recorder struct {
+	config RecorderConfig
+	mode   RecorderMode
+	path   string
+
+	mu           sync.Mutex
+	rand         *rand.Rand
+	seed         int64
+	step         int
+	interactions []*recordedInteraction
+}
+
+// recording is the recording file contents.
+type recording struct {
+	Seed         int64                  `json:"seed"`
+	Interactions []*recordedInteraction `json:"interactions"`
+}
+
+type recordedInteraction struct {
+	Step     int              `json:"step"`
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+
+	replayed bool
+}
+
+// recordedRequest is a sanitized HTTP request. Bodies which are not valid
+// UTF-8 are base64 encoded.
+type recordedRequest struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// recordedResponse is a sanitized HTTP response. Bodies which are not valid
+// UTF-8 are base64 encoded.
+type recordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// encodeBody returns the recorded body and base64 encoded body.
+func encodeBody(b []byte) (string, string) {
+	if utf8.Valid(b) {
+		return string(b), ""
+	}
+
+	return "", base64.StdEncoding.EncodeToString(b)
+}
+
+// NewRecorder returns a Recorder for the test in the mode given by the
+// TF_ACC_RECORD_MODE environment variable. In replay mode, the recording file
+// must exist. In record mode, the recording file is written when the test
+// finishes.
+func NewRecorder(t testing.T, config RecorderConfig) *Recorder {
+	t.Helper()
+
+	dir := config.Dir
+
+	if dir == "" {
+		dir = os.Getenv(EnvTfAccRecordDir)
+	}
+
+	if dir == "" {
+		dir = defaultRecorderDir
+	}
+
+	r := &Recorder{
+		config: config,
+		mode:   RecorderMode(os.Getenv(EnvTfAccRecordMode)),
+		path:   filepath.Join(dir, strings.ReplaceAll(t.Name(), "/", "_")+".json"),
+		seed:   time.Now().UnixNano(),
+	}
+
+	switch r.mode {
+	case RecorderModeDisabled:
+	case RecorderModeRecord:
+		t.Cleanup(func() {
+			if err := r.Save(); err != nil {
+				t.Errorf("error saving HTTP recording: %s", err)
+			}
+		})
+	case RecorderModeReplay:
+		if err := r.load(); err != nil {
+			t.Fatalf("error loading HTTP recording: %s", err)
+		}
+	default:
+		t.Fatalf("unknown %s value %q, expected %q or %q", EnvTfAccRecordMode, r.mode, RecorderModeRecord, RecorderModeReplay)
+	}
+
+	r.rand = rand.New(rand.NewSource(r.seed)) //nolint:gosec // random values are not used for security
+
+	return r
+}
+
+// Mode returns the Recorder mode.
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Path returns the recording file path.
+func (r *Recorder) Path() string {
+	return r.path
+}
+
+// SetStep sets the TestStep number of subsequent HTTP interactions. The
+// testing framework calls this when the Recorder is given in the TestCase
+// HTTPRecorder field.
+func (r *Recorder) SetStep(step int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.step = step
+}
+
+// Save writes the recorded interactions to the recording file, creating any
+// parent directories. It does nothing unless in record mode. NewRecorder
+// registers Save to be called when the test finishes.
+func (r *Recorder) Save() error {
+	if r.mode != RecorderModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(recording{Seed: r.seed, Interactions: r.interactions}, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("encoding HTTP recording: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("creating HTTP recording directory: %w", err)
+	}
+
+	if err := os.WriteFile(r.path, append(b, '\n'), 0o644); err != nil { //nolint:gosec // recordings are committed alongside tests
+		return fmt.Errorf("writing HTTP recording: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Recorder) load() error {
+	b, err := os.ReadFile(r.path)
+
+	if err != nil {
+		return err
+	}
+
+	var rec recording
+
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return fmt.Errorf("parsing %s: %w", r.path, err)
+	}
+
+	r.seed = rec.Seed
+	r.interactions = rec.Interactions
+
+	return nil
+}
+
+// Transport returns an http.RoundTripper which records or replays requests
+// sent with the given http.RoundTripper, or http.DefaultTransport if nil.
+// Provider client code should use it as the HTTP client transport.
+func (r *Recorder) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if r.mode == RecorderModeDisabled {
+		return base
+	}
+
+	return &recorderTransport{
+		base:     base,
+		recorder: r,
+	}
+}
+
+// RandInt generates a random integer from the Recorder seed.
+func (r *Recorder) RandInt() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.rand.Int()
+}
+
+// RandomWithPrefix is used to generate a unique name with a prefix from the
+// Recorder seed, for randomizing names in acceptance tests.
+func (r *Recorder) RandomWithPrefix(name string) string {
+	return fmt.Sprintf("%s-%d", name, r.RandInt())
+}
+
+// RandIntRange returns a random integer from the Recorder seed between minInt
+// (inclusive) and maxInt (exclusive).
+func (r *Recorder) RandIntRange(minInt int, maxInt int) int {
+	return randIntRange(r.intn, minInt, maxInt)
+}
+
+// RandString generates a random alphanumeric string of the length specified
+// from the Recorder seed.
+func (r *Recorder) RandString(strlen int) string {
+	return r.RandStringFromCharSet(strlen, CharSetAlphaNum)
+}
+
+// RandStringFromCharSet generates a random string from the Recorder seed by
+// selecting characters from the charset provided.
+func (r *Recorder) RandStringFromCharSet(strlen int, charSet string) string {
+	return randStringFromCharSet(r.intn, strlen, charSet)
+}
+
+// RandIpAddress returns a random IP address in the specified CIDR block from
+// the Recorder seed.
+func (r *Recorder) RandIpAddress(s string) (string, error) {
+	return randIpAddress(r.read, s)
+}
+
+func (r *Recorder) intn(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.rand.Intn(n)
+}
+
+func (r *Recorder) read(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.rand.Read(b)
+}
+
+type recorderTransport struct {
+	base     http.RoundTripper
+	recorder *Recorder
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+
+		reqBody, err = io.ReadAll(req.Body)
+
+		req.Body.Close() //nolint:errcheck // request body is fully read
+
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
+
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	recordedReq := t.recorder.sanitizeRequest(req, reqBody)
+
+	if t.recorder.mode == RecorderModeReplay {
+		return t.recorder.replay(req, recordedReq)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+
+	if err != nil {
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+
+	resp.Body.Close() //nolint:errcheck // response body is fully read
+
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	t.recorder.mu.Lock()
+	defer t.recorder.mu.Unlock()
+
+	t.recorder.interactions = append(t.recorder.interactions, &recordedInteraction{
+		Step:    t.recorder.step,
+		Request: recordedReq,
+		Response: recordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     t.recorder.sanitizeHeader(resp.Header),
+		},
+	})
+
+	last := t.recorder.interactions[len(t.recorder.interactions)-1]
+	last.Response.Body, last.Response.BodyBase64 = encodeBody(t.recorder.sanitizeBody(resp.Header.Get("Content-Type"), respBody))
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recordedReq recordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var closest *recordedInteraction
+
+	for _, interaction := range r.interactions {
+		if interaction.replayed || interaction.Step != r.step || interaction.Request.Method != recordedReq.Method {
+			continue
+		}
+
+		if interaction.Request.URL == recordedReq.URL && interaction.Request.Body == recordedReq.Body && interaction.Request.BodyBase64 == recordedReq.BodyBase64 {
+			interaction.replayed = true
+
+			body := []byte(interaction.Response.Body)
+
+			if interaction.Response.BodyBase64 != "" {
+				var err error
+
+				body, err = base64.StdEncoding.DecodeString(interaction.Response.BodyBase64)
+
+				if err != nil {
+					return nil, fmt.Errorf("HTTP recording %s: decoding response body: %w", r.path, err)
+				}
+			}
+
+			return &http.Response{
+				Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+				StatusCode:    interaction.Response.StatusCode,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        interaction.Response.Header.Clone(),
+				Body:          io.NopCloser(bytes.NewReader(body)),
+				ContentLength: int64(len(body)),
+				Request:       req,
+			}, nil
+		}
+
+		if closest == nil || (closest.Request.URL != recordedReq.URL && interaction.Request.URL == recordedReq.URL) {
+			closest = interaction
+		}
+	}
+
+	if closest == nil {
+		return nil, fmt.Errorf("HTTP recording %s: no unreplayed %s requests recorded in step %d for %s", r.path, recordedReq.Method, r.step, recordedReq.URL)
+	}
+
+	return nil, fmt.Errorf("HTTP recording %s: %s %s request in step %d does not match any unreplayed recorded request, "+
+		"difference from closest recorded request (-recorded +got):\n%s",
+		r.path, recordedReq.Method, recordedReq.URL, r.step,
+		cmp.Diff(closest.Request, recordedReq, cmp.FilterPath(func(p cmp.Path) bool {
+			return p.Last().String() == ".Header"
+		}, cmp.Ignore())))
+}
+
+// sanitizeRequest returns the request to record, without the stripped headers
+// and with the redacted fields replaced.
+func (r *Recorder) sanitizeRequest(req *http.Request, body []byte) recordedRequest {
+	u := *req.URL
+
+	if query := u.Query(); len(query) > 0 {
+		r.redactValues(query)
+		u.RawQuery = query.Encode()
+	}
+
+	result := recordedRequest{
+		Method: req.Method,
+		URL:    u.String(),
+		Header: r.sanitizeHeader(req.Header),
+	}
+
+	result.Body, result.BodyBase64 = encodeBody(r.sanitizeBody(req.Header.Get("Content-Type"), body))
+
+	return result
+}
+
+func (r *Recorder) sanitizeHeader(header http.Header) http.Header {
+	result := header.Clone()
+
+	for _, name := range defaultRecorderStripHeaders {
+		result.Del(name)
+	}
+
+	for _, name := range r.config.StripHeaders {
+		result.Del(name)
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// sanitizeBody returns the body with the redacted fields replaced. JSON bodies
+// are re-encoded with sorted object keys, so equal bodies are recorded equally.
+func (r *Recorder) sanitizeBody(contentType string, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+
+		if err != nil {
+			return body
+		}
+
+		r.redactValues(values)
+
+		return []byte(values.Encode())
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return body
+	}
+
+	result, err := json.Marshal(r.redactJSON(value))
+
+	if err != nil {
+		return body
+	}
+
+	return result
+}
+
+func (r *Recorder) redactValues(values url.Values) {
+	for _, field := range r.config.RedactFields {
+		if _, ok := values[field]; ok {
+			values.Set(field, RecorderRedacted)
+		}
+	}
+}
+
+func (r *Recorder) redactJSON(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, v := range value {
+			if r.isRedacted(key) {
+				value[key] = RecorderRedacted
+
+				continue
+			}
+
+			value[key] = r.redactJSON(v)
+		}
+	case []any:
+		for i, v := range value {
+			value[i] = r.redactJSON(v)
+		}
+	}
+
+	return value
+}
+
+func (r *Recorder) isRedacted(field string) bool {
+	for _, redacted := range r.config.RedactFields {
+		if field == redacted {
+			return true
+		}
+	}
+
+	return false
+}
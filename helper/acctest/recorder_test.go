@@ -0,0 +1,199 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package acctest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func recorderTestRequest(t *testing.T, client *http.Client, url string, body string) string {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, url+"/things?token=secret", strings.NewReader(body))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return string(b)
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestRecorder_RecordReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+
+		_, _ = w.Write([]byte(`{"request":` + string(b) + `,"password":"secret"}`))
+	}))
+
+	t.Setenv(EnvTfAccRecordMode, "record")
+
+	recorder := NewRecorder(t, RecorderConfig{
+		Dir:          dir,
+		RedactFields: []string{"password", "token"},
+	})
+	recordName := recorder.RandomWithPrefix("tf-acc-test")
+	recordIP, err := recorder.RandIpAddress("10.0.0.0/8")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	recorder.SetStep(1)
+
+	client := &http.Client{Transport: recorder.Transport(nil)}
+	got := recorderTestRequest(t, client, server.URL, `{"name":"`+recordName+`","password":"secret"}`)
+
+	if !strings.Contains(got, `"password":"secret"`) {
+		t.Errorf("expected unsanitized response while recording, got: %s", got)
+	}
+
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	server.Close()
+
+	b, err := os.ReadFile(recorder.Path())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, secret := range []string{"Bearer secret", "session=secret", `"secret"`, "token=secret"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("expected recording to not contain %q, got: %s", secret, b)
+		}
+	}
+
+	t.Setenv(EnvTfAccRecordMode, "replay")
+
+	player := NewRecorder(t, RecorderConfig{
+		Dir:          dir,
+		RedactFields: []string{"password", "token"},
+	})
+	replayName := player.RandomWithPrefix("tf-acc-test")
+	player.SetStep(1)
+
+	if replayName != recordName {
+		t.Errorf("expected replayed random name %q, got %q", recordName, replayName)
+	}
+
+	if replayIP, err := player.RandIpAddress("10.0.0.0/8"); err != nil || replayIP != recordIP {
+		t.Errorf("expected replayed random IP address %q, got %q (error: %v)", recordIP, replayIP, err)
+	}
+
+	client = &http.Client{Transport: player.Transport(nil)}
+	got = recorderTestRequest(t, client, server.URL, `{"password":"other","name":"`+replayName+`"}`)
+
+	var resp struct {
+		Password string `json:"password"`
+		Request  struct {
+			Name string `json:"name"`
+		} `json:"request"`
+	}
+
+	if err := json.Unmarshal([]byte(got), &resp); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resp.Request.Name != recordName || resp.Password != RecorderRedacted {
+		t.Errorf("unexpected replayed response: %s", got)
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestRecorder_ReplayMismatch(t *testing.T) {
+	dir := t.TempDir()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	t.Setenv(EnvTfAccRecordMode, "record")
+
+	recorder := NewRecorder(t, RecorderConfig{Dir: dir})
+	recorder.SetStep(1)
+	recorderTestRequest(t, &http.Client{Transport: recorder.Transport(nil)}, server.URL, `{"name":"one"}`)
+
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	t.Setenv(EnvTfAccRecordMode, "replay")
+
+	player := NewRecorder(t, RecorderConfig{Dir: dir})
+	client := &http.Client{Transport: player.Transport(nil)}
+
+	testCases := map[string]struct {
+		step        int
+		body        string
+		expectedErr *regexp.Regexp
+	}{
+		"different-step": {
+			step:        2,
+			body:        `{"name":"one"}`,
+			expectedErr: regexp.MustCompile(`no unreplayed POST requests recorded in step 2`),
+		},
+		"different-body": {
+			step:        1,
+			body:        `{"name":"two"}`,
+			expectedErr: regexp.MustCompile(`does not match any unreplayed recorded request, difference from closest recorded request \(-recorded \+got\):\n(.|\n)*-.*one(.|\n)*\+.*two`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			player.SetStep(testCase.step)
+
+			req, err := http.NewRequest(http.MethodPost, server.URL+"/things?token=secret", strings.NewReader(testCase.body))
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			resp, err := client.Do(req)
+
+			if err == nil {
+				resp.Body.Close()
+
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.expectedErr.MatchString(err.Error()) {
+				t.Errorf("expected error matching %q, got: %s", testCase.expectedErr, err)
+			}
+		})
+	}
+}
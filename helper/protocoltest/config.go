@@ -0,0 +1,307 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package protocoltest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// decodeConfig parses the body of a configuration block in HCL native syntax
+// and decodes it against the schema block. Expressions are evaluated without
+// variables or functions, so only literal values are supported.
+func decodeConfig(src string, block *tfprotov6.SchemaBlock) (tftypes.Value, error) {
+	file, diags := hclsyntax.ParseConfig([]byte(src), "config.tf", hcl.InitialPos)
+
+	if diags.HasErrors() {
+		return tftypes.Value{}, diags
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+
+	if !ok {
+		return tftypes.Value{}, fmt.Errorf("unexpected configuration body type: %T", file.Body)
+	}
+
+	return decodeBlock(body, block, "")
+}
+
+func decodeBlock(body *hclsyntax.Body, block *tfprotov6.SchemaBlock, path string) (tftypes.Value, error) {
+	if block == nil {
+		block = &tfprotov6.SchemaBlock{}
+	}
+
+	valueType := block.ValueType()
+	values := make(map[string]tftypes.Value, len(block.Attributes)+len(block.BlockTypes))
+
+	for name := range body.Attributes {
+		if !slices.ContainsFunc(block.Attributes, func(attribute *tfprotov6.SchemaAttribute) bool { return attribute.Name == name }) {
+			return tftypes.Value{}, fmt.Errorf("%s: unsupported argument %q", configPath(path, name), name)
+		}
+	}
+
+	for _, nested := range body.Blocks {
+		if !slices.ContainsFunc(block.BlockTypes, func(blockType *tfprotov6.SchemaNestedBlock) bool { return blockType.TypeName == nested.Type }) {
+			return tftypes.Value{}, fmt.Errorf("%s: unsupported block type %q", configPath(path, nested.Type), nested.Type)
+		}
+	}
+
+	for _, attribute := range block.Attributes {
+		attributePath := configPath(path, attribute.Name)
+		attributeType := attribute.ValueType()
+		expr, ok := body.Attributes[attribute.Name]
+
+		if !ok {
+			if attribute.Required {
+				return tftypes.Value{}, fmt.Errorf("%s: the argument %q is required, but no definition was found", attributePath, attribute.Name)
+			}
+
+			values[attribute.Name] = tftypes.NewValue(attributeType, nil)
+
+			continue
+		}
+
+		if attribute.Computed && !attribute.Optional && !attribute.Required {
+			return tftypes.Value{}, fmt.Errorf("%s: the argument %q cannot be set, as it is computed", attributePath, attribute.Name)
+		}
+
+		ctyValue, diags := expr.Expr.Value(nil)
+
+		if diags.HasErrors() {
+			return tftypes.Value{}, fmt.Errorf("%s: %w", attributePath, diags)
+		}
+
+		value, err := ctyToValue(attributeType, ctyValue, attributePath)
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		values[attribute.Name] = value
+	}
+
+	for _, blockType := range block.BlockTypes {
+		value, err := decodeNestedBlocks(body, blockType, configPath(path, blockType.TypeName))
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		values[blockType.TypeName] = value
+	}
+
+	return tftypes.NewValue(valueType, values), nil
+}
+
+func decodeNestedBlocks(body *hclsyntax.Body, blockType *tfprotov6.SchemaNestedBlock, path string) (tftypes.Value, error) {
+	var blocks []*hclsyntax.Block
+
+	for _, nested := range body.Blocks {
+		if nested.Type == blockType.TypeName {
+			blocks = append(blocks, nested)
+		}
+	}
+
+	valueType := blockType.ValueType()
+
+	switch blockType.Nesting {
+	case tfprotov6.SchemaNestedBlockNestingModeSingle, tfprotov6.SchemaNestedBlockNestingModeGroup:
+		if len(blocks) > 1 {
+			return tftypes.Value{}, fmt.Errorf("%s: no more than 1 %q block is allowed", path, blockType.TypeName)
+		}
+
+		if len(blocks) == 0 {
+			if blockType.Nesting == tfprotov6.SchemaNestedBlockNestingModeGroup {
+				return decodeBlock(&hclsyntax.Body{}, blockType.Block, path)
+			}
+
+			return tftypes.NewValue(valueType, nil), nil
+		}
+
+		return decodeBlock(blocks[0].Body, blockType.Block, path)
+	case tfprotov6.SchemaNestedBlockNestingModeList, tfprotov6.SchemaNestedBlockNestingModeSet:
+		if blockType.MinItems > 0 && int64(len(blocks)) < blockType.MinItems {
+			return tftypes.Value{}, fmt.Errorf("%s: at least %d %q blocks are required", path, blockType.MinItems, blockType.TypeName)
+		}
+
+		if blockType.MaxItems > 0 && int64(len(blocks)) > blockType.MaxItems {
+			return tftypes.Value{}, fmt.Errorf("%s: no more than %d %q blocks are allowed", path, blockType.MaxItems, blockType.TypeName)
+		}
+
+		elements := make([]tftypes.Value, 0, len(blocks))
+
+		for i, nested := range blocks {
+			element, err := decodeBlock(nested.Body, blockType.Block, fmt.Sprintf("%s[%d]", path, i))
+
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+
+			elements = append(elements, element)
+		}
+
+		return tftypes.NewValue(valueType, elements), nil
+	case tfprotov6.SchemaNestedBlockNestingModeMap:
+		elements := make(map[string]tftypes.Value, len(blocks))
+
+		for _, nested := range blocks {
+			if len(nested.Labels) != 1 {
+				return tftypes.Value{}, fmt.Errorf("%s: %q blocks require a single label", path, blockType.TypeName)
+			}
+
+			element, err := decodeBlock(nested.Body, blockType.Block, fmt.Sprintf("%s[%q]", path, nested.Labels[0]))
+
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+
+			elements[nested.Labels[0]] = element
+		}
+
+		return tftypes.NewValue(valueType, elements), nil
+	}
+
+	return tftypes.Value{}, fmt.Errorf("%s: unsupported block nesting mode %s", path, blockType.Nesting)
+}
+
+// ctyToValue converts a configuration value to a value of the given type.
+func ctyToValue(valueType tftypes.Type, value cty.Value, path string) (tftypes.Value, error) {
+	if value.IsNull() {
+		return tftypes.NewValue(valueType, nil), nil
+	}
+
+	if !value.IsWhollyKnown() {
+		return tftypes.Value{}, fmt.Errorf("%s: unknown values are not supported", path)
+	}
+
+	switch {
+	case valueType.Is(tftypes.String):
+		converted, err := convert.Convert(value, cty.String)
+
+		if err != nil {
+			return tftypes.Value{}, fmt.Errorf("%s: %w", path, err)
+		}
+
+		return tftypes.NewValue(tftypes.String, converted.AsString()), nil
+	case valueType.Is(tftypes.Number):
+		converted, err := convert.Convert(value, cty.Number)
+
+		if err != nil {
+			return tftypes.Value{}, fmt.Errorf("%s: %w", path, err)
+		}
+
+		return tftypes.NewValue(tftypes.Number, converted.AsBigFloat()), nil
+	case valueType.Is(tftypes.Bool):
+		converted, err := convert.Convert(value, cty.Bool)
+
+		if err != nil {
+			return tftypes.Value{}, fmt.Errorf("%s: %w", path, err)
+		}
+
+		return tftypes.NewValue(tftypes.Bool, converted.True()), nil
+	case valueType.Is(tftypes.List{}), valueType.Is(tftypes.Set{}):
+		if !value.Type().IsListType() && !value.Type().IsSetType() && !value.Type().IsTupleType() {
+			return tftypes.Value{}, fmt.Errorf("%s: a list or set is required, got %s", path, value.Type().FriendlyName())
+		}
+
+		var elementType tftypes.Type
+
+		if listType, ok := valueType.(tftypes.List); ok {
+			elementType = listType.ElementType
+		} else {
+			elementType = valueType.(tftypes.Set).ElementType //nolint:forcetypeassert // checked by Is
+		}
+
+		var elements []tftypes.Value
+
+		for it := value.ElementIterator(); it.Next(); {
+			_, element := it.Element()
+
+			converted, err := ctyToValue(elementType, element, fmt.Sprintf("%s[%d]", path, len(elements)))
+
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+
+			elements = append(elements, converted)
+		}
+
+		return tftypes.NewValue(valueType, elements), nil
+	case valueType.Is(tftypes.Map{}):
+		if !value.Type().IsMapType() && !value.Type().IsObjectType() {
+			return tftypes.Value{}, fmt.Errorf("%s: a map is required, got %s", path, value.Type().FriendlyName())
+		}
+
+		elementType := valueType.(tftypes.Map).ElementType //nolint:forcetypeassert // checked by Is
+		elements := make(map[string]tftypes.Value)
+
+		for it := value.ElementIterator(); it.Next(); {
+			key, element := it.Element()
+
+			converted, err := ctyToValue(elementType, element, fmt.Sprintf("%s[%q]", path, key.AsString()))
+
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+
+			elements[key.AsString()] = converted
+		}
+
+		return tftypes.NewValue(valueType, elements), nil
+	case valueType.Is(tftypes.Object{}):
+		if !value.Type().IsMapType() && !value.Type().IsObjectType() {
+			return tftypes.Value{}, fmt.Errorf("%s: an object is required, got %s", path, value.Type().FriendlyName())
+		}
+
+		objectType := valueType.(tftypes.Object) //nolint:forcetypeassert // checked by Is
+		elements := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+
+		for it := value.ElementIterator(); it.Next(); {
+			key, _ := it.Element()
+
+			if _, ok := objectType.AttributeTypes[key.AsString()]; !ok {
+				return tftypes.Value{}, fmt.Errorf("%s: unsupported attribute %q", path, key.AsString())
+			}
+		}
+
+		for name, attributeType := range objectType.AttributeTypes {
+			var element cty.Value
+
+			switch {
+			case value.Type().IsObjectType() && value.Type().HasAttribute(name):
+				element = value.GetAttr(name)
+			case value.Type().IsMapType() && value.HasIndex(cty.StringVal(name)).True():
+				element = value.Index(cty.StringVal(name))
+			default:
+				elements[name] = tftypes.NewValue(attributeType, nil)
+
+				continue
+			}
+
+			converted, err := ctyToValue(attributeType, element, configPath(path, name))
+
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+
+			elements[name] = converted
+		}
+
+		return tftypes.NewValue(valueType, elements), nil
+	}
+
+	return tftypes.Value{}, fmt.Errorf("%s: values of type %s are not supported in Config, use ConfigValue instead", path, valueType)
+}
+
+func configPath(path string, name string) string {
+	return strings.TrimPrefix(path+"."+name, ".")
+}
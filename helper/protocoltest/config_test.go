@@ -0,0 +1,193 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package protocoltest
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDecodeConfig(t *testing.T) {
+	t.Parallel()
+
+	block := &tfprotov6.SchemaBlock{
+		Attributes: []*tfprotov6.SchemaAttribute{
+			{
+				Name:     "id",
+				Type:     tftypes.String,
+				Computed: true,
+			},
+			{
+				Name:     "name",
+				Type:     tftypes.String,
+				Required: true,
+			},
+			{
+				Name:     "count",
+				Type:     tftypes.Number,
+				Optional: true,
+			},
+			{
+				Name:     "tags",
+				Type:     tftypes.Map{ElementType: tftypes.String},
+				Optional: true,
+			},
+			{
+				Name: "endpoint",
+				NestedType: &tfprotov6.SchemaObject{
+					Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "url",
+							Type:     tftypes.String,
+							Required: true,
+						},
+						{
+							Name:     "insecure",
+							Type:     tftypes.Bool,
+							Optional: true,
+						},
+					},
+				},
+				Optional: true,
+			},
+		},
+		BlockTypes: []*tfprotov6.SchemaNestedBlock{
+			{
+				TypeName: "rule",
+				Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "ports",
+							Type:     tftypes.Set{ElementType: tftypes.Number},
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	endpointType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"url":      tftypes.String,
+			"insecure": tftypes.Bool,
+		},
+	}
+	ruleType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"ports": tftypes.Set{ElementType: tftypes.Number},
+		},
+	}
+
+	testCases := map[string]struct {
+		config      string
+		expected    tftypes.Value
+		expectedErr *regexp.Regexp
+	}{
+		"required-only": {
+			config: `name = "test"`,
+			expected: tftypes.NewValue(block.ValueType(), map[string]tftypes.Value{
+				"id":       tftypes.NewValue(tftypes.String, nil),
+				"name":     tftypes.NewValue(tftypes.String, "test"),
+				"count":    tftypes.NewValue(tftypes.Number, nil),
+				"tags":     tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"endpoint": tftypes.NewValue(endpointType, nil),
+				"rule":     tftypes.NewValue(tftypes.List{ElementType: ruleType}, []tftypes.Value{}),
+			}),
+		},
+		"all": {
+			config: `
+name     = "test"
+count    = 2
+tags     = { env = "test" }
+endpoint = { url = "https://example.com" }
+
+rule {
+  ports = [80, 443]
+}
+`,
+			expected: tftypes.NewValue(block.ValueType(), map[string]tftypes.Value{
+				"id":    tftypes.NewValue(tftypes.String, nil),
+				"name":  tftypes.NewValue(tftypes.String, "test"),
+				"count": tftypes.NewValue(tftypes.Number, 2),
+				"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"env": tftypes.NewValue(tftypes.String, "test"),
+				}),
+				"endpoint": tftypes.NewValue(endpointType, map[string]tftypes.Value{
+					"url":      tftypes.NewValue(tftypes.String, "https://example.com"),
+					"insecure": tftypes.NewValue(tftypes.Bool, nil),
+				}),
+				"rule": tftypes.NewValue(tftypes.List{ElementType: ruleType}, []tftypes.Value{
+					tftypes.NewValue(ruleType, map[string]tftypes.Value{
+						"ports": tftypes.NewValue(tftypes.Set{ElementType: tftypes.Number}, []tftypes.Value{
+							tftypes.NewValue(tftypes.Number, 80),
+							tftypes.NewValue(tftypes.Number, 443),
+						}),
+					}),
+				}),
+			}),
+		},
+		"missing-required": {
+			config:      `count = 1`,
+			expectedErr: regexp.MustCompile(`name: the argument "name" is required, but no definition was found`),
+		},
+		"computed": {
+			config:      `name = "test"` + "\n" + `id = "test"`,
+			expectedErr: regexp.MustCompile(`id: the argument "id" cannot be set, as it is computed`),
+		},
+		"unsupported-block": {
+			config:      `name = "test"` + "\n" + `other {}`,
+			expectedErr: regexp.MustCompile(`other: unsupported block type "other"`),
+		},
+		"invalid-type": {
+			config:      `name = "test"` + "\n" + `count = "two"`,
+			expectedErr: regexp.MustCompile(`count: a number is required`),
+		},
+		"unsupported-nested-attribute": {
+			config:      `name = "test"` + "\n" + `endpoint = { url = "https://example.com", other = true }`,
+			expectedErr: regexp.MustCompile(`endpoint: unsupported attribute "other"`),
+		},
+		"nested-block-attribute": {
+			config:      `name = "test"` + "\n" + `rule { ports = "80" }`,
+			expectedErr: regexp.MustCompile(`rule\[0\].ports: a list or set is required, got string`),
+		},
+		"syntax": {
+			config:      `name = `,
+			expectedErr: regexp.MustCompile(`config.tf:1,8-8: Missing expression`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := decodeConfig(testCase.config, block)
+
+			if err != nil {
+				if testCase.expectedErr == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !testCase.expectedErr.MatchString(err.Error()) {
+					t.Fatalf("expected error matching %q, got: %s", testCase.expectedErr, err)
+				}
+
+				return
+			}
+
+			if testCase.expectedErr != nil {
+				t.Fatalf("expected error matching %q, got none", testCase.expectedErr)
+			}
+
+			if !got.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
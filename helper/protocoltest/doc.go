@@ -0,0 +1,17 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package protocoltest contains a lightweight test runner which drives a
+// single managed resource type of a protocol version 6 provider server
+// directly through the validate, plan, apply, read, import, and destroy RPCs,
+// keeping the resource state in memory. It does not require the Terraform
+// CLI, so it is suited to fast unit testing of resource schema and CRUD logic.
+//
+// The plans and states are passed to the plancheck and statecheck packages
+// in the form of the Terraform JSON output, where the resource address is the
+// resource type followed by ".test", such as "examplecloud_thing.test".
+//
+// Terraform core behaviors, such as configuration references, functions,
+// and provider-defined plan modifications of other resources, are not
+// available. Use the helper/resource package for acceptance testing.
+package protocoltest
@@ -0,0 +1,835 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package protocoltest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+// runner drives a single resource type of the provider server and holds its
+// state in memory.
+type runner struct {
+	resourceType string
+	server       tfprotov6.ProviderServer
+	schema       *tfprotov6.Schema
+
+	state   tftypes.Value
+	private []byte
+}
+
+// resourcePlan is the planned change of the resource.
+type resourcePlan struct {
+	actions tfjson.Actions
+	config  tftypes.Value
+	prior   tftypes.Value
+	planned tftypes.Value
+	private []byte
+}
+
+// newRunner creates the provider server, retrieves the resource schema, and
+// configures the provider.
+func newRunner(ctx context.Context, c TestCase) (*runner, error) {
+	server, err := c.ProviderFactory()
+
+	if err != nil {
+		return nil, fmt.Errorf("creating provider server: %w", err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+	if err != nil {
+		return nil, fmt.Errorf("retrieving provider schema: %w", err)
+	}
+
+	if err := diagnosticsError(schemaResp.Diagnostics); err != nil {
+		return nil, fmt.Errorf("retrieving provider schema: %w", err)
+	}
+
+	schema := schemaResp.ResourceSchemas[c.ResourceType]
+
+	if schema == nil {
+		return nil, fmt.Errorf("resource type %q not found in provider schema", c.ResourceType)
+	}
+
+	var providerBlock *tfprotov6.SchemaBlock
+
+	if schemaResp.Provider != nil {
+		providerBlock = schemaResp.Provider.Block
+	}
+
+	providerConfig, err := decodeConfig(c.ProviderConfig, providerBlock)
+
+	if err != nil {
+		return nil, fmt.Errorf("decoding ProviderConfig: %w", err)
+	}
+
+	config, err := tfprotov6.NewDynamicValue(providerConfig.Type(), providerConfig)
+
+	if err != nil {
+		return nil, fmt.Errorf("encoding ProviderConfig: %w", err)
+	}
+
+	logging.HelperResourceTrace(ctx, "Calling provider ValidateProviderConfig")
+
+	validateResp, err := server.ValidateProviderConfig(ctx, &tfprotov6.ValidateProviderConfigRequest{Config: &config})
+
+	if err != nil {
+		return nil, fmt.Errorf("validating provider configuration: %w", err)
+	}
+
+	if err := diagnosticsError(validateResp.Diagnostics); err != nil {
+		return nil, fmt.Errorf("validating provider configuration: %w", err)
+	}
+
+	logging.HelperResourceTrace(ctx, "Calling provider ConfigureProvider")
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &config})
+
+	if err != nil {
+		return nil, fmt.Errorf("configuring provider: %w", err)
+	}
+
+	if err := diagnosticsError(configureResp.Diagnostics); err != nil {
+		return nil, fmt.Errorf("configuring provider: %w", err)
+	}
+
+	return &runner{
+		resourceType: c.ResourceType,
+		server:       server,
+		schema:       schema,
+		state:        tftypes.NewValue(schema.ValueType(), nil),
+	}, nil
+}
+
+// runStep runs a single TestStep.
+func (r *runner) runStep(ctx context.Context, step TestStep) error {
+	switch {
+	case step.ImportState:
+		return r.importState(ctx, step)
+	case step.Destroy:
+		return r.destroyStep(ctx, step)
+	default:
+		return r.applyStep(ctx, step)
+	}
+}
+
+// applyStep validates, plans, and applies the TestStep configuration, then
+// verifies the plan after apply is empty.
+func (r *runner) applyStep(ctx context.Context, step TestStep) error {
+	config, err := r.config(step)
+
+	if err != nil {
+		return err
+	}
+
+	if err := r.validate(ctx, config); err != nil {
+		return err
+	}
+
+	if err := r.refresh(ctx); err != nil {
+		return err
+	}
+
+	plan, err := r.plan(ctx, config)
+
+	if err != nil {
+		return err
+	}
+
+	if err := runPlanChecks(ctx, r.resourceType, plan, step.PlanChecks); err != nil {
+		return fmt.Errorf("Pre-apply plan check(s) failed:\n%w", err)
+	}
+
+	if err := r.apply(ctx, plan); err != nil {
+		return err
+	}
+
+	if err := runStateChecks(ctx, r.resourceType, r.state, step.StateChecks); err != nil {
+		return fmt.Errorf("State check(s) failed:\n%w", err)
+	}
+
+	if err := r.refresh(ctx); err != nil {
+		return fmt.Errorf("refreshing after apply: %w", err)
+	}
+
+	plan, err = r.plan(ctx, config)
+
+	if err != nil {
+		return fmt.Errorf("planning after apply: %w", err)
+	}
+
+	if !plan.actions.NoOp() && !step.ExpectNonEmptyPlan {
+		return fmt.Errorf("After applying this test step, the plan was not empty, with %q actions:\n\n%s", plan.actions, planDifferences(plan))
+	}
+
+	return nil
+}
+
+// destroyStep plans and applies the destruction of the resource.
+func (r *runner) destroyStep(ctx context.Context, step TestStep) error {
+	if err := r.refresh(ctx); err != nil {
+		return err
+	}
+
+	plan, err := r.plan(ctx, tftypes.NewValue(r.schema.ValueType(), nil))
+
+	if err != nil {
+		return err
+	}
+
+	if err := runPlanChecks(ctx, r.resourceType, plan, step.PlanChecks); err != nil {
+		return fmt.Errorf("Pre-apply plan check(s) failed:\n%w", err)
+	}
+
+	if err := r.apply(ctx, plan); err != nil {
+		return err
+	}
+
+	if err := runStateChecks(ctx, r.resourceType, r.state, step.StateChecks); err != nil {
+		return fmt.Errorf("State check(s) failed:\n%w", err)
+	}
+
+	return nil
+}
+
+// destroy destroys the resource, if it exists in state.
+func (r *runner) destroy(ctx context.Context) error {
+	if r.state.IsNull() {
+		return nil
+	}
+
+	logging.HelperResourceDebug(ctx, "Destroying resource after protocol TestCase")
+
+	plan, err := r.plan(ctx, tftypes.NewValue(r.schema.ValueType(), nil))
+
+	if err != nil {
+		return err
+	}
+
+	return r.apply(ctx, plan)
+}
+
+// importState imports and reads the resource, then verifies the imported
+// state against the current state with ImportStateVerify.
+func (r *runner) importState(ctx context.Context, step TestStep) error {
+	id := step.ImportStateId
+
+	if id == "" {
+		var err error
+
+		id, err = r.stateID()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	logging.HelperResourceTrace(ctx, "Calling provider ImportResourceState")
+
+	importResp, err := r.server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: r.resourceType,
+		ID:       id,
+	})
+
+	if err != nil {
+		return fmt.Errorf("importing resource: %w", err)
+	}
+
+	if err := diagnosticsError(importResp.Diagnostics); err != nil {
+		return fmt.Errorf("importing resource: %w", err)
+	}
+
+	index := slices.IndexFunc(importResp.ImportedResources, func(imported *tfprotov6.ImportedResource) bool {
+		return imported != nil && imported.TypeName == r.resourceType
+	})
+
+	if index < 0 {
+		return fmt.Errorf("importing resource: provider returned no %s resource for import ID %q", r.resourceType, id)
+	}
+
+	imported := importResp.ImportedResources[index]
+
+	importedState, err := r.decode("imported state", imported.State)
+
+	if err != nil {
+		return err
+	}
+
+	importedState, _, err = r.read(ctx, importedState, imported.Private)
+
+	if err != nil {
+		return fmt.Errorf("reading imported resource: %w", err)
+	}
+
+	if importedState.IsNull() {
+		return fmt.Errorf("importing resource: cannot import non-existent remote object with import ID %q", id)
+	}
+
+	if err := runStateChecks(ctx, r.resourceType, importedState, step.StateChecks); err != nil {
+		return fmt.Errorf("State check(s) failed:\n%w", err)
+	}
+
+	if !step.ImportStateVerify {
+		return nil
+	}
+
+	return importStateVerify(r.state, importedState, step.ImportStateVerifyIgnore)
+}
+
+// config returns the TestStep resource configuration.
+func (r *runner) config(step TestStep) (tftypes.Value, error) {
+	if step.ConfigValue.Type() != nil {
+		if !step.ConfigValue.Type().Equal(r.schema.ValueType()) {
+			return tftypes.Value{}, fmt.Errorf("ConfigValue type %s does not match the resource schema type %s", step.ConfigValue.Type(), r.schema.ValueType())
+		}
+
+		return step.ConfigValue, nil
+	}
+
+	config, err := decodeConfig(step.Config, r.schema.Block)
+
+	if err != nil {
+		return tftypes.Value{}, fmt.Errorf("decoding Config: %w", err)
+	}
+
+	return config, nil
+}
+
+// validate calls ValidateResourceConfig with the configuration.
+func (r *runner) validate(ctx context.Context, config tftypes.Value) error {
+	dv, err := r.encode("configuration", config)
+
+	if err != nil {
+		return err
+	}
+
+	logging.HelperResourceTrace(ctx, "Calling provider ValidateResourceConfig")
+
+	resp, err := r.server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: r.resourceType,
+		Config:   dv,
+	})
+
+	if err != nil {
+		return fmt.Errorf("validating resource configuration: %w", err)
+	}
+
+	if err := diagnosticsError(resp.Diagnostics); err != nil {
+		return fmt.Errorf("validating resource configuration: %w", err)
+	}
+
+	return nil
+}
+
+// refresh reads the resource, if it exists in state, and updates the state.
+func (r *runner) refresh(ctx context.Context) error {
+	if r.state.IsNull() {
+		return nil
+	}
+
+	state, private, err := r.read(ctx, r.state, r.private)
+
+	if err != nil {
+		return fmt.Errorf("reading resource: %w", err)
+	}
+
+	r.state, r.private = state, private
+
+	return nil
+}
+
+// read calls ReadResource with the state and returns the new state.
+func (r *runner) read(ctx context.Context, state tftypes.Value, private []byte) (tftypes.Value, []byte, error) {
+	dv, err := r.encode("state", state)
+
+	if err != nil {
+		return tftypes.Value{}, nil, err
+	}
+
+	logging.HelperResourceTrace(ctx, "Calling provider ReadResource")
+
+	resp, err := r.server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     r.resourceType,
+		CurrentState: dv,
+		Private:      private,
+	})
+
+	if err != nil {
+		return tftypes.Value{}, nil, err
+	}
+
+	if err := diagnosticsError(resp.Diagnostics); err != nil {
+		return tftypes.Value{}, nil, err
+	}
+
+	newState, err := r.decode("new state", resp.NewState)
+
+	return newState, resp.Private, err
+}
+
+// plan calls PlanResourceChange with the configuration, or a null
+// configuration to destroy the resource, and returns the planned change. If
+// the provider requires replacement, the resource creation is planned.
+func (r *runner) plan(ctx context.Context, config tftypes.Value) (resourcePlan, error) {
+	plan := resourcePlan{
+		config: config,
+		prior:  r.state,
+	}
+
+	if config.IsNull() && r.state.IsNull() {
+		plan.actions = tfjson.Actions{tfjson.ActionNoop}
+		plan.planned = r.state
+
+		return plan, nil
+	}
+
+	planned, private, requiresReplace, err := r.planResourceChange(ctx, r.state, r.private, config)
+
+	if err != nil {
+		return plan, err
+	}
+
+	plan.planned, plan.private = planned, private
+
+	switch {
+	case r.state.IsNull():
+		plan.actions = tfjson.Actions{tfjson.ActionCreate}
+	case config.IsNull():
+		plan.actions = tfjson.Actions{tfjson.ActionDelete}
+	case len(requiresReplace) > 0:
+		plan.actions = tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}
+
+		// Terraform plans the creation of the replacement resource as if
+		// the resource does not exist.
+		plan.planned, plan.private, _, err = r.planResourceChange(ctx, tftypes.NewValue(r.schema.ValueType(), nil), nil, config)
+
+		if err != nil {
+			return plan, err
+		}
+	case planned.Equal(r.state):
+		plan.actions = tfjson.Actions{tfjson.ActionNoop}
+	default:
+		plan.actions = tfjson.Actions{tfjson.ActionUpdate}
+	}
+
+	return plan, nil
+}
+
+func (r *runner) planResourceChange(ctx context.Context, prior tftypes.Value, priorPrivate []byte, config tftypes.Value) (tftypes.Value, []byte, []*tftypes.AttributePath, error) {
+	priorState, err := r.encode("prior state", prior)
+
+	if err != nil {
+		return tftypes.Value{}, nil, nil, err
+	}
+
+	proposedNewState, err := r.encode("proposed new state", proposedNew(r.schema.Block, prior, config))
+
+	if err != nil {
+		return tftypes.Value{}, nil, nil, err
+	}
+
+	configDV, err := r.encode("configuration", config)
+
+	if err != nil {
+		return tftypes.Value{}, nil, nil, err
+	}
+
+	logging.HelperResourceTrace(ctx, "Calling provider PlanResourceChange")
+
+	resp, err := r.server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         r.resourceType,
+		PriorState:       priorState,
+		ProposedNewState: proposedNewState,
+		Config:           configDV,
+		PriorPrivate:     priorPrivate,
+	})
+
+	if err != nil {
+		return tftypes.Value{}, nil, nil, fmt.Errorf("planning resource change: %w", err)
+	}
+
+	if err := diagnosticsError(resp.Diagnostics); err != nil {
+		return tftypes.Value{}, nil, nil, fmt.Errorf("planning resource change: %w", err)
+	}
+
+	planned, err := r.decode("planned state", resp.PlannedState)
+
+	return planned, resp.PlannedPrivate, resp.RequiresReplace, err
+}
+
+// apply calls ApplyResourceChange for the planned change and updates the
+// state. Replacement destroys the resource before creating it.
+func (r *runner) apply(ctx context.Context, plan resourcePlan) error {
+	if plan.actions.NoOp() {
+		return nil
+	}
+
+	if plan.actions.DestroyBeforeCreate() {
+		nullState := tftypes.NewValue(r.schema.ValueType(), nil)
+
+		if err := r.applyResourceChange(ctx, r.state, nullState, nullState, r.private); err != nil {
+			return err
+		}
+	}
+
+	return r.applyResourceChange(ctx, r.state, plan.planned, plan.config, plan.private)
+}
+
+func (r *runner) applyResourceChange(ctx context.Context, prior tftypes.Value, planned tftypes.Value, config tftypes.Value, plannedPrivate []byte) error {
+	priorState, err := r.encode("prior state", prior)
+
+	if err != nil {
+		return err
+	}
+
+	plannedState, err := r.encode("planned state", planned)
+
+	if err != nil {
+		return err
+	}
+
+	configDV, err := r.encode("configuration", config)
+
+	if err != nil {
+		return err
+	}
+
+	logging.HelperResourceTrace(ctx, "Calling provider ApplyResourceChange")
+
+	resp, err := r.server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       r.resourceType,
+		PriorState:     priorState,
+		PlannedState:   plannedState,
+		Config:         configDV,
+		PlannedPrivate: plannedPrivate,
+	})
+
+	if err != nil {
+		return fmt.Errorf("applying resource change: %w", err)
+	}
+
+	newState, decodeErr := r.decode("new state", resp.NewState)
+
+	// As with Terraform, any new state returned alongside error diagnostics
+	// is kept, such as for partially created resources.
+	if decodeErr == nil && (resp.NewState != nil || !hasErrorDiagnostic(resp.Diagnostics)) {
+		r.state, r.private = newState, resp.Private
+	}
+
+	if err := diagnosticsError(resp.Diagnostics); err != nil {
+		return fmt.Errorf("applying resource change: %w", err)
+	}
+
+	return decodeErr
+}
+
+// stateID returns the "id" attribute of the resource in state.
+func (r *runner) stateID() (string, error) {
+	var attributes map[string]tftypes.Value
+
+	if r.state.IsNull() || r.state.As(&attributes) != nil {
+		return "", errors.New("ImportStateId is required when the resource does not exist in state")
+	}
+
+	var id string
+
+	if value, ok := attributes["id"]; !ok || !value.IsKnown() || value.As(&id) != nil || id == "" {
+		return "", errors.New(`ImportStateId is required when the resource has no "id" string attribute in state`)
+	}
+
+	return id, nil
+}
+
+func (r *runner) encode(name string, value tftypes.Value) (*tfprotov6.DynamicValue, error) {
+	dv, err := tfprotov6.NewDynamicValue(r.schema.ValueType(), value)
+
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s: %w", name, err)
+	}
+
+	return &dv, nil
+}
+
+func (r *runner) decode(name string, dv *tfprotov6.DynamicValue) (tftypes.Value, error) {
+	if dv == nil {
+		return tftypes.NewValue(r.schema.ValueType(), nil), nil
+	}
+
+	value, err := dv.Unmarshal(r.schema.ValueType())
+
+	if err != nil {
+		return tftypes.Value{}, fmt.Errorf("decoding %s: %w", name, err)
+	}
+
+	return value, nil
+}
+
+// proposedNew returns the proposed new state, which is the configuration with
+// the prior state values of computed attributes which are not configured, as
+// Terraform sends in PlanResourceChange requests.
+func proposedNew(block *tfprotov6.SchemaBlock, prior tftypes.Value, config tftypes.Value) tftypes.Value {
+	if block == nil || config.IsNull() || !config.IsKnown() {
+		return config
+	}
+
+	var priorAttributes, configAttributes map[string]tftypes.Value
+
+	if config.As(&configAttributes) != nil {
+		return config
+	}
+
+	if prior.IsNull() || !prior.IsKnown() || prior.As(&priorAttributes) != nil {
+		priorAttributes = nil
+	}
+
+	result := make(map[string]tftypes.Value, len(configAttributes))
+
+	for name, value := range configAttributes {
+		result[name] = value
+	}
+
+	for _, attribute := range block.Attributes {
+		configValue, ok := configAttributes[attribute.Name]
+
+		if !ok {
+			continue
+		}
+
+		switch {
+		case attribute.WriteOnly:
+			result[attribute.Name] = tftypes.NewValue(configValue.Type(), nil)
+		case attribute.Computed && configValue.IsNull():
+			if priorValue, ok := priorAttributes[attribute.Name]; ok {
+				result[attribute.Name] = priorValue
+			}
+		}
+	}
+
+	for _, blockType := range block.BlockTypes {
+		configValue, ok := configAttributes[blockType.TypeName]
+		priorValue, priorOk := priorAttributes[blockType.TypeName]
+
+		if !ok || !priorOk || priorValue.IsNull() || !priorValue.IsKnown() {
+			continue
+		}
+
+		switch blockType.Nesting {
+		case tfprotov6.SchemaNestedBlockNestingModeSingle, tfprotov6.SchemaNestedBlockNestingModeGroup:
+			result[blockType.TypeName] = proposedNew(blockType.Block, priorValue, configValue)
+		case tfprotov6.SchemaNestedBlockNestingModeList:
+			var priorElements, configElements []tftypes.Value
+
+			if configValue.IsNull() || priorValue.As(&priorElements) != nil || configValue.As(&configElements) != nil {
+				continue
+			}
+
+			elements := make([]tftypes.Value, 0, len(configElements))
+
+			for i, configElement := range configElements {
+				if i < len(priorElements) {
+					configElement = proposedNew(blockType.Block, priorElements[i], configElement)
+				}
+
+				elements = append(elements, configElement)
+			}
+
+			result[blockType.TypeName] = tftypes.NewValue(configValue.Type(), elements)
+		case tfprotov6.SchemaNestedBlockNestingModeMap:
+			var priorElements, configElements map[string]tftypes.Value
+
+			if configValue.IsNull() || priorValue.As(&priorElements) != nil || configValue.As(&configElements) != nil {
+				continue
+			}
+
+			elements := make(map[string]tftypes.Value, len(configElements))
+
+			for key, configElement := range configElements {
+				if priorElement, ok := priorElements[key]; ok {
+					configElement = proposedNew(blockType.Block, priorElement, configElement)
+				}
+
+				elements[key] = configElement
+			}
+
+			result[blockType.TypeName] = tftypes.NewValue(configValue.Type(), elements)
+		}
+	}
+
+	return tftypes.NewValue(config.Type(), result)
+}
+
+// importStateVerify returns an error describing each top-level attribute of
+// the imported state which does not match the state.
+func importStateVerify(state tftypes.Value, imported tftypes.Value, ignore []string) error {
+	var stateAttributes, importedAttributes map[string]tftypes.Value
+
+	if state.IsNull() || state.As(&stateAttributes) != nil {
+		return errors.New("ImportStateVerify requires the resource to exist in state")
+	}
+
+	if err := imported.As(&importedAttributes); err != nil {
+		return fmt.Errorf("decoding imported state: %w", err)
+	}
+
+	var differences []string
+
+	for _, name := range slices.Sorted(maps.Keys(stateAttributes)) {
+		if slices.Contains(ignore, name) {
+			continue
+		}
+
+		if !stateAttributes[name].Equal(importedAttributes[name]) {
+			differences = append(differences, fmt.Sprintf("  %s: imported %s, state %s", name, importedAttributes[name], stateAttributes[name]))
+		}
+	}
+
+	if len(differences) > 0 {
+		return fmt.Errorf("ImportStateVerify attributes not equivalent. Difference is shown below.\n\n%s", strings.Join(differences, "\n"))
+	}
+
+	return nil
+}
+
+// planDifferences returns a description of each value which differs between
+// the prior and planned state.
+func planDifferences(plan resourcePlan) string {
+	diffs, err := plan.prior.Diff(plan.planned)
+
+	if err != nil {
+		return err.Error()
+	}
+
+	var result []string
+
+	for _, diff := range diffs {
+		// Differences within collections and objects are described by the
+		// differences of their elements.
+		if isCollection(diff.Value1) && isCollection(diff.Value2) {
+			continue
+		}
+
+		result = append(result, fmt.Sprintf("  %s: %s => %s", diff.Path, diffValue(diff.Value1), diffValue(diff.Value2)))
+	}
+
+	slices.Sort(result)
+
+	return strings.Join(result, "\n")
+}
+
+func diffValue(value *tftypes.Value) string {
+	if value == nil {
+		return "(none)"
+	}
+
+	return value.String()
+}
+
+// isCollection returns true if the value is a known, non-null collection,
+// object, or tuple.
+func isCollection(value *tftypes.Value) bool {
+	if value == nil || !value.IsKnown() || value.IsNull() {
+		return false
+	}
+
+	return !value.Type().Is(tftypes.String) && !value.Type().Is(tftypes.Number) && !value.Type().Is(tftypes.Bool)
+}
+
+func runPlanChecks(ctx context.Context, resourceType string, plan resourcePlan, planChecks []plancheck.PlanCheck) error {
+	if len(planChecks) == 0 {
+		return nil
+	}
+
+	planJSON, err := planJSON(resourceType, plan.actions, plan.prior, plan.planned)
+
+	if err != nil {
+		return err
+	}
+
+	var result []error
+
+	for _, planCheck := range planChecks {
+		resp := plancheck.CheckPlanResponse{}
+		planCheck.CheckPlan(ctx, plancheck.CheckPlanRequest{Plan: planJSON}, &resp)
+
+		result = append(result, resp.Error)
+	}
+
+	return errors.Join(result...)
+}
+
+func runStateChecks(ctx context.Context, resourceType string, state tftypes.Value, stateChecks []statecheck.StateCheck) error {
+	if len(stateChecks) == 0 {
+		return nil
+	}
+
+	stateJSON, err := stateJSON(resourceType, state)
+
+	if err != nil {
+		return err
+	}
+
+	var result []error
+
+	for _, stateCheck := range stateChecks {
+		resp := statecheck.CheckStateResponse{}
+		stateCheck.CheckState(ctx, statecheck.CheckStateRequest{State: stateJSON}, &resp)
+
+		result = append(result, resp.Error)
+	}
+
+	return errors.Join(result...)
+}
+
+// diagnosticsError returns an error containing the error diagnostics, or nil
+// if there are none.
+func diagnosticsError(diagnostics []*tfprotov6.Diagnostic) error {
+	var result []string
+
+	for _, diagnostic := range diagnostics {
+		if diagnostic == nil || diagnostic.Severity != tfprotov6.DiagnosticSeverityError {
+			continue
+		}
+
+		var b strings.Builder
+
+		b.WriteString("Error: ")
+		b.WriteString(diagnostic.Summary)
+
+		if diagnostic.Attribute != nil && len(diagnostic.Attribute.Steps()) > 0 {
+			fmt.Fprintf(&b, "\n\n  with %s", diagnostic.Attribute)
+		}
+
+		if diagnostic.Detail != "" {
+			b.WriteString("\n\n")
+			b.WriteString(diagnostic.Detail)
+		}
+
+		result = append(result, b.String())
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(result, "\n\n"))
+}
+
+func hasErrorDiagnostic(diagnostics []*tfprotov6.Diagnostic) bool {
+	return slices.ContainsFunc(diagnostics, func(diagnostic *tfprotov6.Diagnostic) bool {
+		return diagnostic != nil && diagnostic.Severity == tfprotov6.DiagnosticSeverityError
+	})
+}
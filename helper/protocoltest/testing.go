@@ -0,0 +1,222 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package protocoltest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+// TestCase is a single protocol-level test case of a managed resource type,
+// run against an in-process provider server without the Terraform CLI.
+//
+// After the last TestStep, the resource is destroyed if it exists in state.
+type TestCase struct {
+	// ProviderFactory returns the provider server under test. It is called
+	// once per TestCase. Required.
+	ProviderFactory func() (tfprotov6.ProviderServer, error)
+
+	// ProviderConfig is the provider configuration, written as the body of a
+	// provider block in HCL native syntax, such as `region = "us-east-1"`.
+	// Only literal values are supported. If empty, the provider is
+	// configured with all attributes null.
+	ProviderConfig string
+
+	// ResourceType is the managed resource type under test, such as
+	// "examplecloud_thing". Required.
+	ResourceType string
+
+	// Steps are the test steps, which are run in order.
+	Steps []TestStep
+}
+
+// TestStep is a single step within a TestCase.
+//
+// A TestStep without Destroy or ImportState validates the configuration, reads
+// the resource if it exists in state, plans the change, runs the PlanChecks,
+// applies the change, and runs the StateChecks. It then reads the resource
+// and plans again, expecting no changes unless ExpectNonEmptyPlan is set.
+type TestStep struct {
+	// Config is the resource configuration, written as the body of a
+	// resource block in HCL native syntax, such as `name = "example"`. It is
+	// decoded against the resource schema. Only literal values are
+	// supported, as there are no variables, references, or functions. An
+	// empty Config is a resource block with no arguments.
+	Config string
+
+	// ConfigValue is the resource configuration as a value of the resource
+	// schema type, as an alternative to Config. For example, this can be
+	// used for dynamic attributes, which Config does not support.
+	ConfigValue tftypes.Value
+
+	// Destroy, if true, plans and applies the destruction of the resource.
+	Destroy bool
+
+	// ImportState, if true, imports the resource with ImportStateId and
+	// reads it. The imported state is passed to the StateChecks, but does not
+	// replace the state of the TestCase.
+	ImportState bool
+
+	// ImportStateId is the import identifier. If empty, the "id" attribute
+	// of the resource in state is used.
+	ImportStateId string
+
+	// ImportStateVerify, if true, verifies that the imported state matches
+	// the state of the TestCase, except for the ImportStateVerifyIgnore
+	// attributes.
+	ImportStateVerify bool
+
+	// ImportStateVerifyIgnore is a list of top-level attribute names which
+	// are not verified by ImportStateVerify, such as write-only or
+	// configuration-only attributes.
+	ImportStateVerifyIgnore []string
+
+	// ExpectError allows the TestStep to pass if an RPC returns an error
+	// diagnostic, or the configuration is invalid, with an error matching
+	// the regular expression. The state is unchanged by the failed TestStep.
+	ExpectError *regexp.Regexp
+
+	// ExpectNonEmptyPlan, if true, allows the plan after apply to have
+	// changes.
+	ExpectNonEmptyPlan bool
+
+	// PlanChecks are run against the plan before it is applied.
+	PlanChecks []plancheck.PlanCheck
+
+	// StateChecks are run against the state after it is applied, or
+	// against the imported state with ImportState.
+	StateChecks []statecheck.StateCheck
+}
+
+// Test runs the TestCase. Unlike resource.Test, it does not require the
+// TF_ACC environment variable or the Terraform CLI.
+func Test(t testing.T, c TestCase) {
+	t.Helper()
+
+	ctx := context.Background()
+	ctx = logging.InitTestContext(ctx, t)
+
+	if err := c.validate(); err != nil {
+		logging.HelperResourceError(ctx,
+			"Test validation error",
+			map[string]interface{}{logging.KeyError: err},
+		)
+		t.Fatalf("Test validation error: %s", err)
+	}
+
+	logging.HelperResourceDebug(ctx, "Starting protocol TestCase")
+
+	r, err := newRunner(ctx, c)
+
+	if err != nil {
+		logging.HelperResourceError(ctx,
+			"Error starting provider server",
+			map[string]interface{}{logging.KeyError: err},
+		)
+		t.Fatalf("Error starting provider server: %s", err)
+	}
+
+	defer func() {
+		t.Helper()
+
+		if err := r.destroy(ctx); err != nil {
+			logging.HelperResourceError(ctx,
+				"Error running post-test destroy, there may be dangling resources",
+				map[string]interface{}{logging.KeyError: err},
+			)
+			t.Fatalf("Error running post-test destroy, there may be dangling resources: %s", err)
+		}
+	}()
+
+	for i, step := range c.Steps {
+		stepNumber := i + 1
+
+		ctx := logging.TestStepNumberContext(ctx, stepNumber)
+
+		logging.HelperResourceDebug(ctx, "Starting protocol TestStep")
+
+		err := r.runStep(ctx, step)
+
+		if step.ExpectError != nil {
+			if err == nil {
+				logging.HelperResourceError(ctx, "Expected an error but got none")
+				t.Fatalf("Step %d/%d, expected an error but got none", stepNumber, len(c.Steps))
+			}
+
+			if !step.ExpectError.MatchString(err.Error()) {
+				logging.HelperResourceError(ctx,
+					fmt.Sprintf("Expected an error with pattern (%s)", step.ExpectError.String()),
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Step %d/%d, expected an error with pattern, no match on: %s", stepNumber, len(c.Steps), err)
+			}
+		} else if err != nil {
+			logging.HelperResourceError(ctx,
+				"Unexpected error",
+				map[string]interface{}{logging.KeyError: err},
+			)
+			t.Fatalf("Step %d/%d error: %s", stepNumber, len(c.Steps), err)
+		}
+
+		logging.HelperResourceDebug(ctx, "Finished protocol TestStep")
+	}
+
+	logging.HelperResourceDebug(ctx, "Finished protocol TestCase")
+}
+
+// validate ensures the TestCase is valid based on the following criteria:
+//
+//   - ProviderFactory and ResourceType are set.
+//   - Has at least one TestStep.
+//   - Each TestStep has at most one of Config, ConfigValue, Destroy, or
+//     ImportState.
+func (c TestCase) validate() error {
+	if c.ProviderFactory == nil {
+		return errors.New("TestCase missing ProviderFactory")
+	}
+
+	if c.ResourceType == "" {
+		return errors.New("TestCase missing ResourceType")
+	}
+
+	if len(c.Steps) == 0 {
+		return errors.New("TestCase missing Steps")
+	}
+
+	for i, step := range c.Steps {
+		var modes int
+
+		if step.Config != "" {
+			modes++
+		}
+
+		if step.ConfigValue.Type() != nil {
+			modes++
+		}
+
+		if step.Destroy {
+			modes++
+		}
+
+		if step.ImportState {
+			modes++
+		}
+
+		if modes > 1 {
+			return fmt.Errorf("TestStep %d/%d must have at most one of Config, ConfigValue, Destroy, or ImportState", i+1, len(c.Steps))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package protocoltest
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var testResourceSchema = &tfprotov6.Schema{
+	Block: &tfprotov6.SchemaBlock{
+		Attributes: []*tfprotov6.SchemaAttribute{
+			{
+				Name:     "id",
+				Type:     tftypes.String,
+				Computed: true,
+			},
+			{
+				Name:     "name",
+				Type:     tftypes.String,
+				Required: true,
+			},
+		},
+	},
+}
+
+func testResourceValue(id any, name string) tftypes.Value {
+	return tftypes.NewValue(testResourceSchema.ValueType(), map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, id),
+		"name": tftypes.NewValue(tftypes.String, name),
+	})
+}
+
+func testProviderFactory(r testprovider.Resource) func() (tfprotov6.ProviderServer, error) {
+	r.SchemaResponse = &resource.SchemaResponse{
+		Schema: testResourceSchema,
+	}
+
+	if r.CreateFunc == nil {
+		r.CreateFunc = func(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+			var attributes map[string]tftypes.Value
+
+			_ = req.Config.As(&attributes)
+
+			resp.NewState = tftypes.NewValue(testResourceSchema.ValueType(), map[string]tftypes.Value{
+				"id":   tftypes.NewValue(tftypes.String, "test-id"),
+				"name": attributes["name"],
+			})
+		}
+	}
+
+	// Similar to the UseStateForUnknown plan modifier, the prior id is kept.
+	if r.PlanChangeFunc == nil {
+		r.PlanChangeFunc = func(ctx context.Context, req resource.PlanChangeRequest, resp *resource.PlanChangeResponse) {
+			var prior, planned map[string]tftypes.Value
+
+			if req.PriorState.IsNull() || req.ProposedNewState.IsNull() || req.PriorState.As(&prior) != nil || resp.PlannedState.As(&planned) != nil {
+				return
+			}
+
+			planned["id"] = prior["id"]
+			resp.PlannedState = tftypes.NewValue(testResourceSchema.ValueType(), planned)
+		}
+	}
+
+	return providerserver.NewProviderServer(testprovider.Provider{
+		Resources: map[string]testprovider.Resource{
+			"test_resource": r,
+		},
+	})
+}
+
+func TestTest(t *testing.T) {
+	t.Parallel()
+
+	var deleted int
+
+	Test(t, TestCase{
+		ProviderFactory: testProviderFactory(testprovider.Resource{
+			DeleteFunc: func(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+				deleted++
+			},
+			ImportStateResponse: &resource.ImportStateResponse{
+				State: testResourceValue("test-id", "two"),
+			},
+		}),
+		ResourceType: "test_resource",
+		Steps: []TestStep{
+			{
+				Config: `name = "one"`,
+				PlanChecks: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction("test_resource.test", plancheck.ResourceActionCreate),
+					plancheck.ExpectUnknownValue("test_resource.test", tfjsonpath.New("id")),
+				},
+				StateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("id"), knownvalue.StringExact("test-id")),
+					statecheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("name"), knownvalue.StringExact("one")),
+				},
+			},
+			{
+				ConfigValue: testResourceValue(nil, "two"),
+				PlanChecks: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction("test_resource.test", plancheck.ResourceActionUpdate),
+					plancheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("id"), knownvalue.StringExact("test-id")),
+				},
+				StateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("name"), knownvalue.StringExact("two")),
+				},
+			},
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Destroy: true,
+				PlanChecks: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction("test_resource.test", plancheck.ResourceActionDestroy),
+				},
+			},
+		},
+	})
+
+	if deleted != 1 {
+		t.Errorf("expected 1 delete, got %d", deleted)
+	}
+}
+
+func TestTest_RequiresReplace(t *testing.T) {
+	t.Parallel()
+
+	var deleted int
+
+	Test(t, TestCase{
+		ProviderFactory: testProviderFactory(testprovider.Resource{
+			DeleteFunc: func(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+				deleted++
+			},
+			PlanChangeFunc: func(ctx context.Context, req resource.PlanChangeRequest, resp *resource.PlanChangeResponse) {
+				if req.PriorState.IsNull() || req.ProposedNewState.IsNull() || req.ProposedNewState.Equal(req.PriorState) {
+					return
+				}
+
+				resp.RequiresReplace = []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("name")}
+			},
+		}),
+		ResourceType: "test_resource",
+		Steps: []TestStep{
+			{
+				Config: `name = "one"`,
+			},
+			{
+				Config: `name = "two"`,
+				PlanChecks: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction("test_resource.test", plancheck.ResourceActionDestroyBeforeCreate),
+				},
+			},
+		},
+	})
+
+	// The replacement and the post-test destroy.
+	if deleted != 2 {
+		t.Errorf("expected 2 deletes, got %d", deleted)
+	}
+}
+
+func TestTest_ExpectError(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ProviderFactory: testProviderFactory(testprovider.Resource{
+			ValidateConfigResponse: &resource.ValidateConfigResponse{
+				Diagnostics: []*tfprotov6.Diagnostic{
+					{
+						Severity: tfprotov6.DiagnosticSeverityError,
+						Summary:  "Invalid name",
+						Detail:   "The name is invalid.",
+					},
+				},
+			},
+		}),
+		ResourceType: "test_resource",
+		Steps: []TestStep{
+			{
+				Config:      `name = "one"`,
+				ExpectError: regexp.MustCompile(`Error: Invalid name\n\nThe name is invalid.`),
+			},
+			{
+				Config:      `name = "one"` + "\n" + `other = "two"`,
+				ExpectError: regexp.MustCompile(`other: unsupported argument "other"`),
+			},
+			{
+				Config:      ``,
+				ExpectError: regexp.MustCompile(`name: the argument "name" is required, but no definition was found`),
+			},
+		},
+	})
+}
+
+func TestTest_NonEmptyPlan(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ProviderFactory: testProviderFactory(testprovider.Resource{
+			CreateFunc: func(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+				resp.NewState = testResourceValue("test-id", "other")
+			},
+		}),
+		ResourceType: "test_resource",
+		Steps: []TestStep{
+			{
+				Config:      `name = "one"`,
+				ExpectError: regexp.MustCompile(`After applying this test step, the plan was not empty, with \["update"\] actions:\n\n  AttributeName\("name"\): tftypes.String<"other"> => tftypes.String<"one">`),
+			},
+		},
+	})
+}
+
+func TestTestCaseValidate(t *testing.T) {
+	t.Parallel()
+
+	providerFactory := testProviderFactory(testprovider.Resource{})
+
+	testCases := map[string]struct {
+		testCase    TestCase
+		expectedErr *regexp.Regexp
+	}{
+		"valid": {
+			testCase: TestCase{
+				ProviderFactory: providerFactory,
+				ResourceType:    "test_resource",
+				Steps:           []TestStep{{Config: `name = "one"`}},
+			},
+		},
+		"missing-provider-factory": {
+			testCase: TestCase{
+				ResourceType: "test_resource",
+				Steps:        []TestStep{{Config: `name = "one"`}},
+			},
+			expectedErr: regexp.MustCompile(`TestCase missing ProviderFactory`),
+		},
+		"missing-resource-type": {
+			testCase: TestCase{
+				ProviderFactory: providerFactory,
+				Steps:           []TestStep{{Config: `name = "one"`}},
+			},
+			expectedErr: regexp.MustCompile(`TestCase missing ResourceType`),
+		},
+		"missing-steps": {
+			testCase: TestCase{
+				ProviderFactory: providerFactory,
+				ResourceType:    "test_resource",
+			},
+			expectedErr: regexp.MustCompile(`TestCase missing Steps`),
+		},
+		"config-and-destroy": {
+			testCase: TestCase{
+				ProviderFactory: providerFactory,
+				ResourceType:    "test_resource",
+				Steps:           []TestStep{{Config: `name = "one"`, Destroy: true}},
+			},
+			expectedErr: regexp.MustCompile(`TestStep 1/1 must have at most one of Config, ConfigValue, Destroy, or ImportState`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.testCase.validate()
+
+			if err == nil && testCase.expectedErr != nil {
+				t.Fatalf("expected error matching %q, got none", testCase.expectedErr)
+			}
+
+			if err != nil && testCase.expectedErr == nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err != nil && !testCase.expectedErr.MatchString(err.Error()) {
+				t.Errorf("expected error matching %q, got: %s", testCase.expectedErr, err)
+			}
+		})
+	}
+}
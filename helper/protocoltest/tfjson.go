@@ -0,0 +1,272 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package protocoltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	// formatVersion is the Terraform JSON format version of the plans and
+	// states passed to plan and state checks.
+	formatVersion = "1.2"
+
+	// providerName is the provider address of the resource in the plans and
+	// states passed to plan and state checks.
+	providerName = "registry.terraform.io/hashicorp/test"
+
+	// resourceName is the name of the resource under test.
+	resourceName = "test"
+)
+
+// planJSON returns the plan of the resource change in the form of the
+// Terraform JSON plan output.
+func planJSON(resourceType string, actions tfjson.Actions, prior tftypes.Value, planned tftypes.Value) (*tfjson.Plan, error) {
+	before, err := jsonValue(prior)
+
+	if err != nil {
+		return nil, fmt.Errorf("converting prior state: %w", err)
+	}
+
+	after, err := jsonValue(planned)
+
+	if err != nil {
+		return nil, fmt.Errorf("converting planned state: %w", err)
+	}
+
+	afterUnknown, err := jsonUnknown(planned)
+
+	if err != nil {
+		return nil, fmt.Errorf("converting planned state: %w", err)
+	}
+
+	if afterUnknown == nil {
+		afterUnknown = map[string]any{}
+	}
+
+	plan := &tfjson.Plan{
+		FormatVersion: formatVersion,
+		PlannedValues: &tfjson.StateValues{
+			RootModule: &tfjson.StateModule{},
+		},
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address:      resourceType + "." + resourceName,
+				Mode:         tfjson.ManagedResourceMode,
+				Type:         resourceType,
+				Name:         resourceName,
+				ProviderName: providerName,
+				Change: &tfjson.Change{
+					Actions:         actions,
+					Before:          before,
+					After:           after,
+					AfterUnknown:    afterUnknown,
+					BeforeSensitive: false,
+					AfterSensitive:  false,
+				},
+			},
+		},
+	}
+
+	if after != nil {
+		plan.PlannedValues.RootModule.Resources = []*tfjson.StateResource{
+			stateResource(resourceType, after),
+		}
+	}
+
+	return plan, nil
+}
+
+// stateJSON returns the state of the resource in the form of the Terraform
+// JSON state output.
+func stateJSON(resourceType string, state tftypes.Value) (*tfjson.State, error) {
+	values, err := jsonValue(state)
+
+	if err != nil {
+		return nil, fmt.Errorf("converting state: %w", err)
+	}
+
+	result := &tfjson.State{
+		FormatVersion: formatVersion,
+		Values: &tfjson.StateValues{
+			RootModule: &tfjson.StateModule{},
+		},
+	}
+
+	if values != nil {
+		result.Values.RootModule.Resources = []*tfjson.StateResource{
+			stateResource(resourceType, values),
+		}
+	}
+
+	return result, nil
+}
+
+func stateResource(resourceType string, values any) *tfjson.StateResource {
+	attributeValues, _ := values.(map[string]any)
+
+	return &tfjson.StateResource{
+		Address:         resourceType + "." + resourceName,
+		Mode:            tfjson.ManagedResourceMode,
+		Type:            resourceType,
+		Name:            resourceName,
+		ProviderName:    providerName,
+		AttributeValues: attributeValues,
+		SensitiveValues: json.RawMessage("{}"),
+	}
+}
+
+// jsonValue returns the representation of a value as decoded from Terraform
+// JSON output, such as string, json.Number, bool, []any, and map[string]any,
+// where unknown values are null.
+func jsonValue(value tftypes.Value) (any, error) {
+	if value.Type() == nil || !value.IsKnown() || value.IsNull() {
+		return nil, nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var result string
+
+		err := value.As(&result)
+
+		return result, err
+	case value.Type().Is(tftypes.Number):
+		var result big.Float
+
+		if err := value.As(&result); err != nil {
+			return nil, err
+		}
+
+		return json.Number(result.Text('f', -1)), nil
+	case value.Type().Is(tftypes.Bool):
+		var result bool
+
+		err := value.As(&result)
+
+		return result, err
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Set{}), value.Type().Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+
+		result := make([]any, 0, len(elements))
+
+		for _, element := range elements {
+			v, err := jsonValue(element)
+
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, v)
+		}
+
+		return result, nil
+	case value.Type().Is(tftypes.Map{}), value.Type().Is(tftypes.Object{}):
+		var attributes map[string]tftypes.Value
+
+		if err := value.As(&attributes); err != nil {
+			return nil, err
+		}
+
+		result := make(map[string]any, len(attributes))
+
+		for name, attribute := range attributes {
+			v, err := jsonValue(attribute)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			result[name] = v
+		}
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value type %s", value.Type())
+}
+
+// jsonUnknown returns the representation of the unknown values within a value
+// as in the after_unknown field of Terraform JSON plan output, which is true
+// for unknown values, or nil if the value is wholly known.
+func jsonUnknown(value tftypes.Value) (any, error) {
+	if value.Type() == nil || value.IsNull() {
+		return nil, nil
+	}
+
+	if !value.IsKnown() {
+		return true, nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Set{}), value.Type().Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+
+		result := make([]any, 0, len(elements))
+		var hasUnknown bool
+
+		for _, element := range elements {
+			v, err := jsonUnknown(element)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if v == nil {
+				v = false
+			} else {
+				hasUnknown = true
+			}
+
+			result = append(result, v)
+		}
+
+		if !hasUnknown {
+			return nil, nil
+		}
+
+		return result, nil
+	case value.Type().Is(tftypes.Map{}), value.Type().Is(tftypes.Object{}):
+		var attributes map[string]tftypes.Value
+
+		if err := value.As(&attributes); err != nil {
+			return nil, err
+		}
+
+		result := make(map[string]any)
+
+		for name, attribute := range attributes {
+			v, err := jsonUnknown(attribute)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			if v != nil {
+				result[name] = v
+			}
+		}
+
+		if len(result) == 0 {
+			return nil, nil
+		}
+
+		return result, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,111 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+func runDiagnosticChecks(ctx context.Context, t testing.T, diags []diagcheck.Diagnostic, diagChecks []diagcheck.DiagnosticCheck) error {
+	t.Helper()
+
+	var result []error
+
+	for _, diagCheck := range diagChecks {
+		resp := diagcheck.CheckDiagnosticsResponse{}
+		diagCheck.CheckDiagnostics(ctx, diagcheck.CheckDiagnosticsRequest{Diagnostics: diags}, &resp)
+
+		result = append(result, resp.Error)
+	}
+
+	return errors.Join(result...)
+}
+
+// checksDiagnostics returns true if the TestStep makes assertions against
+// diagnostics, which requires Terraform commands to be run with
+// machine-readable output.
+func (s TestStep) checksDiagnostics() bool {
+	return len(s.ExpectDiagnostics) > 0 || len(s.ExpectWarnings) > 0 || s.ExpectNoWarnings
+}
+
+// capturesDiagnostics returns true if the TestStep checks diagnostics or its
+// Retry Retryable function receives them in a TestStepError. Unlike
+// diagnostic assertions, Retry with older Terraform CLI versions is not an
+// error.
+func (s TestStep) capturesDiagnostics(helper *plugintest.Helper) bool {
+	return s.checksDiagnostics() || (s.Retry != nil && diagnosticsPreconditions(helper) == nil)
+}
+
+// capturesDiagnostics returns true if the TestCase ErrorCheck opted in to
+// receiving the diagnostics in a TestStepError, which requires Terraform
+// commands to be run with machine-readable output. Unlike TestStep diagnostic
+// assertions, older Terraform CLI versions are not an error.
+func (c TestCase) capturesDiagnostics(helper *plugintest.Helper) bool {
+	return c.ErrorCheck != nil && c.ErrorCheckDiagnostics && diagnosticsPreconditions(helper) == nil
+}
+
+// diagnosticsPreconditions returns an error if the Terraform CLI version
+// under test does not support machine-readable output, which is required
+// to capture diagnostics.
+func diagnosticsPreconditions(helper *plugintest.Helper) error {
+	if helper.TerraformVersion().LessThan(plugintest.MinTerraformVersionDiagnostics) {
+		return fmt.Errorf(
+			`TestStep ExpectDiagnostics, ExpectWarnings, and ExpectNoWarnings require Terraform 0.15.3 or later. Either ` +
+				`upgrade the Terraform version running the test or add a ` + "`TerraformVersionChecks`" + ` to ` +
+				`the test case to skip this test.` + "\n\n" +
+				`https://developer.hashicorp.com/terraform/plugin/testing/acceptance-tests/tfversion-checks#skip-version-checks`)
+	}
+
+	return nil
+}
+
+// testStepExpectDiagnostics verifies that the TestStep failed and that the
+// diagnostics captured while running it satisfy the TestStep
+// ExpectDiagnostics checks.
+func testStepExpectDiagnostics(ctx context.Context, t testing.T, wd *plugintest.WorkingDir, step TestStep, stepErr error) error {
+	t.Helper()
+
+	if stepErr == nil {
+		return fmt.Errorf("expected an error but got none")
+	}
+
+	err := runDiagnosticChecks(ctx, t, wd.Diagnostics(), step.ExpectDiagnostics)
+
+	if err != nil {
+		return fmt.Errorf("diagnostic checks failed on error: %s\n\n%w", stepErr, err)
+	}
+
+	return nil
+}
+
+// testStepExpectWarnings verifies that the warning diagnostics captured while
+// running the TestStep satisfy the TestStep ExpectWarnings and
+// ExpectNoWarnings assertions.
+func testStepExpectWarnings(ctx context.Context, t testing.T, wd *plugintest.WorkingDir, step TestStep) error {
+	t.Helper()
+
+	var diagChecks []diagcheck.DiagnosticCheck
+
+	for _, matcher := range step.ExpectWarnings {
+		diagChecks = append(diagChecks, diagcheck.ExpectWarning(matcher))
+	}
+
+	if step.ExpectNoWarnings {
+		var allowed []diagcheck.DiagnosticMatcher
+
+		allowed = append(allowed, step.ExpectWarnings...)
+		allowed = append(allowed, step.AllowedWarnings...)
+
+		diagChecks = append(diagChecks, diagcheck.ExpectNoWarnings(allowed...))
+	}
+
+	return runDiagnosticChecks(ctx, t, wd.Diagnostics(), diagChecks)
+}
@@ -0,0 +1,447 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"regexp"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestTest_TestStep_ExpectDiagnostics_NewConfig(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": diagnosticsTestResource(invalidValueDiagnostic),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {
+					id = "invalid-value"
+				}`,
+				ExpectDiagnostics: []diagcheck.DiagnosticCheck{
+					diagcheck.ExpectDiagnostic(diagcheck.DiagnosticMatcher{
+						Severity:      tfjson.DiagnosticSeverityError,
+						Summary:       knownvalue.StringExact("Invalid Attribute Value"),
+						Detail:        knownvalue.StringRegexp(regexp.MustCompile(`must not be invalid-value`)),
+						AttributePath: knownvalue.StringExact("id"),
+					}),
+					diagcheck.ExpectNoDiagnostic(diagcheck.DiagnosticMatcher{
+						Severity: tfjson.DiagnosticSeverityWarning,
+					}),
+				},
+			},
+		},
+	})
+}
+
+// ErrorCheck alone must not switch Terraform commands to machine-readable
+// output, which would change the error messages ExpectError matches.
+func TestTest_TestStep_ExpectError_ErrorCheck_HumanReadable(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": diagnosticsTestResource(invalidValueDiagnostic),
+				},
+			}),
+		},
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {
+					id = "invalid-value"
+				}`,
+				ExpectError: regexp.MustCompile(`with test_resource\.test,[\s\S]*on terraform_plugin_test\.tf line 2, in resource "test_resource" "test":[\s\S]*id = "invalid-value"`),
+			},
+		},
+	})
+}
+
+func TestTest_TestStep_ExpectDiagnostics_NewConfig_NoMatch(t *testing.T) {
+	t.Parallel()
+
+	plugintest.TestExpectTFatal(t, func() {
+		UnitTest(&mockT{}, TestCase{
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+			},
+			ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+				"test": providerserver.NewProviderServer(testprovider.Provider{
+					Resources: map[string]testprovider.Resource{
+						"test_resource": diagnosticsTestResource(invalidValueDiagnostic),
+					},
+				}),
+			},
+			Steps: []TestStep{
+				{
+					Config: `resource "test_resource" "test" {
+						id = "invalid-value"
+					}`,
+					ExpectDiagnostics: []diagcheck.DiagnosticCheck{
+						diagcheck.ExpectDiagnostic(diagcheck.DiagnosticMatcher{
+							Summary: knownvalue.StringExact("Missing Attribute"),
+						}),
+					},
+				},
+			},
+		})
+	})
+}
+
+func TestTest_TestStep_ExpectDiagnostics_NewConfig_NoError(t *testing.T) {
+	t.Parallel()
+
+	plugintest.TestExpectTFatal(t, func() {
+		UnitTest(&mockT{}, TestCase{
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+			},
+			ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+				"test": providerserver.NewProviderServer(testprovider.Provider{
+					Resources: map[string]testprovider.Resource{
+						"test_resource": diagnosticsTestResource(),
+					},
+				}),
+			},
+			Steps: []TestStep{
+				{
+					Config: `resource "test_resource" "test" {
+						id = "valid-value"
+					}`,
+					ExpectDiagnostics: []diagcheck.DiagnosticCheck{
+						diagcheck.ExpectDiagnostic(diagcheck.DiagnosticMatcher{}),
+					},
+				},
+			},
+		})
+	})
+}
+
+func TestTest_TestStep_ExpectDiagnostics_ImportBlock(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0), // ImportBlockWithID
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": {
+						CreateResponse: &resource.CreateResponse{
+							NewState: tftypes.NewValue(
+								tftypes.Object{
+									AttributeTypes: map[string]tftypes.Type{
+										"id": tftypes.String,
+									},
+								},
+								map[string]tftypes.Value{
+									"id": tftypes.NewValue(tftypes.String, "test"),
+								},
+							),
+						},
+						ImportStateResponse: &resource.ImportStateResponse{
+							Diagnostics: []*tfprotov6.Diagnostic{
+								{
+									Severity: tfprotov6.DiagnosticSeverityError,
+									Summary:  "Resource Not Found",
+									Detail:   "The resource could not be imported.",
+								},
+							},
+						},
+						SchemaResponse: &resource.SchemaResponse{
+							Schema: &tfprotov6.Schema{
+								Block: &tfprotov6.SchemaBlock{
+									Attributes: []*tfprotov6.SchemaAttribute{
+										{
+											Name:     "id",
+											Type:     tftypes.String,
+											Required: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {
+					id = "test"
+				}`,
+			},
+			{
+				ResourceName:    "test_resource.test",
+				ImportState:     true,
+				ImportStateKind: ImportBlockWithID,
+				ExpectDiagnostics: []diagcheck.DiagnosticCheck{
+					diagcheck.ExpectDiagnostic(diagcheck.DiagnosticMatcher{
+						Severity: tfjson.DiagnosticSeverityError,
+						Summary:  knownvalue.StringExact("Resource Not Found"),
+					}),
+				},
+			},
+		},
+	})
+}
+
+func TestTest_TestStep_ExpectWarnings(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": diagnosticsTestResource(deprecatedAttributeDiagnostic),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {
+					id = "valid-value"
+				}`,
+				ExpectWarnings: []diagcheck.DiagnosticMatcher{
+					{
+						Summary:       knownvalue.StringExact("Attribute Deprecated"),
+						AttributePath: knownvalue.StringExact("id"),
+					},
+				},
+				ExpectNoWarnings: true,
+			},
+		},
+	})
+}
+
+func TestTest_TestStep_ExpectWarnings_ImportCommand(t *testing.T) {
+	t.Parallel()
+
+	state := tftypes.NewValue(
+		tftypes.Object{
+			AttributeTypes: map[string]tftypes.Type{
+				"id": tftypes.String,
+			},
+		},
+		map[string]tftypes.Value{
+			"id": tftypes.NewValue(tftypes.String, "valid-value"),
+		},
+	)
+
+	testResource := diagnosticsTestResource()
+	testResource.ImportStateResponse = &resource.ImportStateResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			{
+				Severity: tfprotov6.DiagnosticSeverityWarning,
+				Summary:  "Import Deprecated",
+				Detail:   "Importing by ID is deprecated.",
+			},
+		},
+		State: state,
+	}
+	testResource.ReadResponse = &resource.ReadResponse{
+		NewState: state,
+	}
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": testResource,
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {
+					id = "valid-value"
+				}`,
+			},
+			{
+				ResourceName:  "test_resource.test",
+				ImportState:   true,
+				ImportStateId: "valid-value",
+				ExpectWarnings: []diagcheck.DiagnosticMatcher{
+					{
+						Summary: knownvalue.StringExact("Import Deprecated"),
+						Detail:  knownvalue.StringExact("Importing by ID is deprecated."),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestTest_TestStep_ExpectWarnings_NoMatch(t *testing.T) {
+	t.Parallel()
+
+	plugintest.TestExpectTFatal(t, func() {
+		UnitTest(&mockT{}, TestCase{
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+			},
+			ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+				"test": providerserver.NewProviderServer(testprovider.Provider{
+					Resources: map[string]testprovider.Resource{
+						"test_resource": diagnosticsTestResource(),
+					},
+				}),
+			},
+			Steps: []TestStep{
+				{
+					Config: `resource "test_resource" "test" {
+						id = "valid-value"
+					}`,
+					ExpectWarnings: []diagcheck.DiagnosticMatcher{
+						{
+							Summary: knownvalue.StringExact("Attribute Deprecated"),
+						},
+					},
+				},
+			},
+		})
+	})
+}
+
+func TestTest_TestStep_ExpectNoWarnings_Unexpected(t *testing.T) {
+	t.Parallel()
+
+	plugintest.TestExpectTFatal(t, func() {
+		UnitTest(&mockT{}, TestCase{
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+			},
+			ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+				"test": providerserver.NewProviderServer(testprovider.Provider{
+					Resources: map[string]testprovider.Resource{
+						"test_resource": diagnosticsTestResource(deprecatedAttributeDiagnostic),
+					},
+				}),
+			},
+			Steps: []TestStep{
+				{
+					Config: `resource "test_resource" "test" {
+						id = "valid-value"
+					}`,
+					ExpectNoWarnings: true,
+				},
+			},
+		})
+	})
+}
+
+func TestTest_TestStep_ExpectNoWarnings_AllowedWarnings(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": diagnosticsTestResource(deprecatedAttributeDiagnostic),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {
+					id = "valid-value"
+				}`,
+				ExpectNoWarnings: true,
+				AllowedWarnings: []diagcheck.DiagnosticMatcher{
+					{
+						Summary: knownvalue.StringExact("Attribute Deprecated"),
+					},
+				},
+			},
+		},
+	})
+}
+
+// deprecatedAttributeDiagnostic is a warning diagnostic for the id attribute.
+var deprecatedAttributeDiagnostic = &tfprotov6.Diagnostic{
+	Severity:  tfprotov6.DiagnosticSeverityWarning,
+	Summary:   "Attribute Deprecated",
+	Detail:    "Attribute id is deprecated.",
+	Attribute: tftypes.NewAttributePath().WithAttributeName("id"),
+}
+
+// invalidValueDiagnostic is an error diagnostic for the id attribute.
+var invalidValueDiagnostic = &tfprotov6.Diagnostic{
+	Severity:  tfprotov6.DiagnosticSeverityError,
+	Summary:   "Invalid Attribute Value",
+	Detail:    "Attribute id must not be invalid-value.",
+	Attribute: tftypes.NewAttributePath().WithAttributeName("id"),
+}
+
+// diagnosticsTestResource returns a resource which reports the given
+// diagnostics during configuration validation.
+func diagnosticsTestResource(validateDiags ...*tfprotov6.Diagnostic) testprovider.Resource {
+	return testprovider.Resource{
+		CreateResponse: &resource.CreateResponse{
+			NewState: tftypes.NewValue(
+				tftypes.Object{
+					AttributeTypes: map[string]tftypes.Type{
+						"id": tftypes.String,
+					},
+				},
+				map[string]tftypes.Value{
+					"id": tftypes.NewValue(tftypes.String, "valid-value"),
+				},
+			),
+		},
+		SchemaResponse: &resource.SchemaResponse{
+			Schema: &tfprotov6.Schema{
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "id",
+							Type:     tftypes.String,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+		ValidateConfigResponse: &resource.ValidateConfigResponse{
+			Diagnostics: validateDiags,
+		},
+	}
+}
@@ -3,6 +3,10 @@
 
 package resource
 
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+)
+
 // Environment variables for acceptance testing. Additional environment
 // variable constants can be found in the internal/plugintest package.
 const (
@@ -42,4 +46,41 @@ const (
 	// When comparing two states, the testing framework is not aware of
 	// semantic equality or set equality.
 	EnvTfAccRefreshAfterApply = "TF_ACC_REFRESH_AFTER_APPLY"
+
+	// Environment variable to record or replay the RPCs between Terraform
+	// and the in-process providers of each TestCase. Valid values are
+	// "record" and "replay".
+	//
+	// In record mode, every RPC request and response is written to a
+	// cassette file per TestCase. In replay mode, the recorded responses
+	// are returned without calling the providers, so tests can be rerun
+	// without credentials for the remote system. A request which does not
+	// match a recorded request fails the test with the difference from the
+	// closest recorded request, and recorded requests which were not
+	// replayed by the end of the TestCase fail the test.
+	//
+	// Providers in ExternalProviders are not recorded, and resource
+	// configurations must be deterministic, such as by using a fixed
+	// acctest random seed, for requests to match on replay.
+	EnvTfAccProviderCassetteMode = "TF_ACC_PROVIDER_CASSETTE_MODE"
+
+	// Environment variable with the directory of the cassette files used
+	// when TF_ACC_PROVIDER_CASSETTE_MODE is set. Defaults to
+	// testdata/cassettes. Each TestCase uses a file named after the test,
+	// with any slashes replaced by underscores.
+	EnvTfAccProviderCassetteDir = "TF_ACC_PROVIDER_CASSETTE_DIR"
+
+	// Environment variable with the directory to write a leaked resource
+	// report to when the post-test destroy or TestCase CheckDestroy fails.
+	// Each TestCase writes a JSON report, decoded as LeakedResourceReport,
+	// and a copy of its raw state file, if any, to files named after the
+	// test, with any slashes replaced by underscores, so sweepers or
+	// humans can clean up the resources which were not destroyed.
+	EnvTfAccLeakedResourcesDir = "TF_ACC_LEAKED_RESOURCES_DIR"
+
+	// Environment variable to record or replay the HTTP interactions of
+	// provider client code using an acctest.Recorder transport. Valid
+	// values are "record" and "replay". See the acctest.Recorder
+	// documentation for details.
+	EnvTfAccRecordMode = acctest.EnvTfAccRecordMode
 )
@@ -0,0 +1,309 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/ephemeralcheck"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+	"github.com/hashicorp/terraform-plugin-testing/internal/providerwrap"
+)
+
+// hasEphemeralChecks returns true if any TestStep has EphemeralChecks.
+func (c TestCase) hasEphemeralChecks() bool {
+	for _, step := range c.Steps {
+		if len(step.EphemeralChecks) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func runEphemeralChecks(ctx context.Context, t testing.T, wd *plugintest.WorkingDir, events []ephemeralcheck.Event, ephemeralChecks []ephemeralcheck.EphemeralCheck) error {
+	t.Helper()
+
+	persistedData, err := ephemeralPersistedData(wd)
+
+	if err != nil {
+		return fmt.Errorf("reading persisted data: %w", err)
+	}
+
+	var result []error
+
+	for _, ephemeralCheck := range ephemeralChecks {
+		resp := ephemeralcheck.CheckEphemeralResponse{}
+		ephemeralCheck.CheckEphemeral(ctx, ephemeralcheck.CheckEphemeralRequest{Events: events, PersistedData: persistedData}, &resp)
+
+		result = append(result, resp.Error)
+	}
+
+	return errors.Join(result...)
+}
+
+// ephemeralPersistedData returns the content of the saved plan file entries
+// and the state file of the working directory, if they exist.
+func ephemeralPersistedData(wd *plugintest.WorkingDir) (map[string][]byte, error) {
+	result := make(map[string][]byte)
+
+	if wd.HasSavedPlan() {
+		planFile, err := zip.OpenReader(filepath.Join(wd.BaseDir(), plugintest.PlanFileName))
+
+		if err != nil {
+			return nil, fmt.Errorf("opening saved plan file: %w", err)
+		}
+
+		defer planFile.Close()
+
+		for _, file := range planFile.File {
+			if file.FileInfo().IsDir() {
+				continue
+			}
+
+			data, err := readZipFile(file)
+
+			if err != nil {
+				return nil, fmt.Errorf("reading saved plan file %s: %w", file.Name, err)
+			}
+
+			result["planfile/"+file.Name] = data
+		}
+	}
+
+	state, err := os.ReadFile(wd.StateFilePath())
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("reading state file: %w", err)
+	}
+
+	if err == nil {
+		result[filepath.Base(wd.StateFilePath())] = state
+	}
+
+	return result, nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	r, err := file.Open()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+// ephemeralEventRecorder records the ephemeral resource lifecycle RPCs to the
+// in-process provider servers during a TestStep with EphemeralChecks.
+type ephemeralEventRecorder struct {
+	mu        sync.Mutex
+	recording bool
+	events    []ephemeralcheck.Event
+	schemas   map[string]any
+}
+
+// Start discards any recorded events and starts or stops recording events.
+func (r *ephemeralEventRecorder) Start(recording bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recording = recording
+	r.events = nil
+	r.schemas = make(map[string]any)
+}
+
+// Events returns the recorded events.
+func (r *ephemeralEventRecorder) Events() []ephemeralcheck.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]ephemeralcheck.Event(nil), r.events...)
+}
+
+// Interceptor returns a providerwrap.Interceptor which records the ephemeral
+// resource lifecycle RPCs to the provider server with the given name.
+func (r *ephemeralEventRecorder) Interceptor(provider string) providerwrap.Interceptor {
+	return func(ctx context.Context, call providerwrap.Call, next providerwrap.Handler) (any, error) {
+		var operation ephemeralcheck.Operation
+
+		switch call.RPC {
+		case "OpenEphemeralResource":
+			operation = ephemeralcheck.Open
+		case "RenewEphemeralResource":
+			operation = ephemeralcheck.Renew
+		case "CloseEphemeralResource":
+			operation = ephemeralcheck.Close
+		default:
+			return next(ctx, call)
+		}
+
+		r.mu.Lock()
+		recording := r.recording
+		r.mu.Unlock()
+
+		if !recording {
+			return next(ctx, call)
+		}
+
+		event := ephemeralcheck.Event{
+			Provider:  provider,
+			TypeName:  call.TypeName(),
+			Operation: operation,
+			Command:   rpcCommand(ctx),
+			Time:      time.Now(),
+		}
+
+		resp, err := next(ctx, call)
+
+		event.Error = err
+
+		switch req := call.Request.(type) {
+		case *tfprotov5.RenewEphemeralResourceRequest:
+			event.RequestPrivate = req.Private
+		case *tfprotov5.CloseEphemeralResourceRequest:
+			event.RequestPrivate = req.Private
+		case *tfprotov6.RenewEphemeralResourceRequest:
+			event.RequestPrivate = req.Private
+		case *tfprotov6.CloseEphemeralResourceRequest:
+			event.RequestPrivate = req.Private
+		}
+
+		switch resp := resp.(type) {
+		case *tfprotov5.OpenEphemeralResourceResponse:
+			event.ResponsePrivate = resp.Private
+			event.RenewAt = resp.RenewAt
+			event.Result = r.openResult(ctx, provider, call, resp.Result)
+
+			if event.Error == nil {
+				event.Error = protoV5DiagnosticsError(resp.Diagnostics)
+			}
+		case *tfprotov5.RenewEphemeralResourceResponse:
+			event.ResponsePrivate = resp.Private
+			event.RenewAt = resp.RenewAt
+
+			if event.Error == nil {
+				event.Error = protoV5DiagnosticsError(resp.Diagnostics)
+			}
+		case *tfprotov5.CloseEphemeralResourceResponse:
+			if event.Error == nil {
+				event.Error = protoV5DiagnosticsError(resp.Diagnostics)
+			}
+		case *tfprotov6.OpenEphemeralResourceResponse:
+			event.ResponsePrivate = resp.Private
+			event.RenewAt = resp.RenewAt
+			event.Result = r.openResult(ctx, provider, call, resp.Result)
+
+			if event.Error == nil {
+				event.Error = protoV6DiagnosticsError(resp.Diagnostics)
+			}
+		case *tfprotov6.RenewEphemeralResourceResponse:
+			event.ResponsePrivate = resp.Private
+			event.RenewAt = resp.RenewAt
+
+			if event.Error == nil {
+				event.Error = protoV6DiagnosticsError(resp.Diagnostics)
+			}
+		case *tfprotov6.CloseEphemeralResourceResponse:
+			if event.Error == nil {
+				event.Error = protoV6DiagnosticsError(resp.Diagnostics)
+			}
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.events = append(r.events, event)
+
+		return resp, err
+	}
+}
+
+// openResult returns the result of an OpenEphemeralResource response, decoded
+// with the ephemeral resource schema, or nil if it cannot be decoded.
+func (r *ephemeralEventRecorder) openResult(ctx context.Context, provider string, call providerwrap.Call, result any) any {
+	var valueType tftypes.Type
+
+	switch schema := r.providerSchema(ctx, provider, call).(type) {
+	case *tfprotov5.GetProviderSchemaResponse:
+		if s, ok := schema.EphemeralResourceSchemas[call.TypeName()]; ok && s != nil {
+			valueType = s.ValueType()
+		}
+	case *tfprotov6.GetProviderSchemaResponse:
+		if s, ok := schema.EphemeralResourceSchemas[call.TypeName()]; ok && s != nil {
+			valueType = s.ValueType()
+		}
+	}
+
+	if valueType == nil {
+		return nil
+	}
+
+	var value tftypes.Value
+	var err error
+
+	switch result := result.(type) {
+	case *tfprotov5.DynamicValue:
+		if result == nil {
+			return nil
+		}
+
+		value, err = result.Unmarshal(valueType)
+	case *tfprotov6.DynamicValue:
+		if result == nil {
+			return nil
+		}
+
+		value, err = result.Unmarshal(valueType)
+	default:
+		return nil
+	}
+
+	if err != nil || !value.IsFullyKnown() {
+		return nil
+	}
+
+	v, err := tftypesValueJSON(value)
+
+	if err != nil {
+		return nil
+	}
+
+	return v
+}
+
+// providerSchema returns the provider schema, retrieving it from the provider
+// server on first use in the TestStep.
+func (r *ephemeralEventRecorder) providerSchema(ctx context.Context, provider string, call providerwrap.Call) any {
+	r.mu.Lock()
+	schema, ok := r.schemas[provider]
+	r.mu.Unlock()
+
+	if ok {
+		return schema
+	}
+
+	schema = getProviderSchema(ctx, call.Server)
+
+	r.mu.Lock()
+	r.schemas[provider] = schema
+	r.mu.Unlock()
+
+	return schema
+}
@@ -0,0 +1,154 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/ephemeralcheck"
+	"github.com/hashicorp/terraform-plugin-testing/internal/providerwrap"
+)
+
+// ephemeralSchemaProviderServer is a provider server which only implements
+// GetProviderSchema with the "test_token" ephemeral resource.
+type ephemeralSchemaProviderServer struct {
+	tfprotov6.ProviderServer
+}
+
+func (s ephemeralSchemaProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	return &tfprotov6.GetProviderSchemaResponse{
+		EphemeralResourceSchemas: map[string]*tfprotov6.Schema{
+			"test_token": {
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "token",
+							Type:     tftypes.String,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func Test_EphemeralEventRecorder(t *testing.T) {
+	t.Parallel()
+
+	server := ephemeralSchemaProviderServer{}
+	renewAt := time.Now().Add(time.Hour)
+	command := "plan"
+
+	result, err := tfprotov6.NewDynamicValue(
+		tftypes.Object{AttributeTypes: map[string]tftypes.Type{"token": tftypes.String}},
+		tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"token": tftypes.String}}, map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "secret"),
+		}),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	responses := map[string]any{
+		"OpenEphemeralResource": &tfprotov6.OpenEphemeralResourceResponse{
+			Result:  &result,
+			Private: []byte("one"),
+			RenewAt: renewAt,
+		},
+		"RenewEphemeralResource": &tfprotov6.RenewEphemeralResourceResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "Renew failed",
+					Detail:   "The lease expired.",
+				},
+			},
+		},
+		"CloseEphemeralResource": &tfprotov6.CloseEphemeralResourceResponse{},
+		"ReadResource":           &tfprotov6.ReadResourceResponse{},
+	}
+
+	next := func(ctx context.Context, call providerwrap.Call) (any, error) {
+		return responses[call.RPC], nil
+	}
+
+	recorder := &ephemeralEventRecorder{}
+	interceptor := recorder.Interceptor("test")
+
+	calls := []providerwrap.Call{
+		{ProtocolVersion: 6, RPC: "OpenEphemeralResource", Request: &tfprotov6.OpenEphemeralResourceRequest{TypeName: "test_token"}, Server: server},
+		{ProtocolVersion: 6, RPC: "ReadResource", Request: &tfprotov6.ReadResourceRequest{TypeName: "test_resource"}, Server: server},
+		{ProtocolVersion: 6, RPC: "RenewEphemeralResource", Request: &tfprotov6.RenewEphemeralResourceRequest{TypeName: "test_token", Private: []byte("one")}, Server: server},
+		{ProtocolVersion: 6, RPC: "CloseEphemeralResource", Request: &tfprotov6.CloseEphemeralResourceRequest{TypeName: "test_token", Private: []byte("one")}, Server: server},
+	}
+
+	// Not recorded before Start.
+	if _, err := interceptor(context.Background(), calls[0], next); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	recorder.Start(true)
+
+	for i, call := range calls {
+		if i == 2 {
+			command = "apply"
+		}
+
+		ctx := context.WithValue(context.Background(), rpcCommandContextKey{}, command)
+
+		if _, err := interceptor(ctx, call, next); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	events := recorder.Events()
+
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d: %v", len(events), events)
+	}
+
+	for _, event := range events {
+		if event.Provider != "test" || event.TypeName != "test_token" || event.Time.IsZero() {
+			t.Errorf("unexpected event: %+v", event)
+		}
+	}
+
+	if events[0].Operation != ephemeralcheck.Open || events[0].Command != "plan" || string(events[0].ResponsePrivate) != "one" || !events[0].RenewAt.Equal(renewAt) || events[0].Error != nil {
+		t.Errorf("unexpected Open event: %+v", events[0])
+	}
+
+	if expected := map[string]any{"token": "secret"}; !reflect.DeepEqual(events[0].Result, expected) {
+		t.Errorf("expected Open result %v, got: %v", expected, events[0].Result)
+	}
+
+	if events[1].Operation != ephemeralcheck.Renew || events[1].Command != "apply" || string(events[1].RequestPrivate) != "one" {
+		t.Errorf("unexpected Renew event: %+v", events[1])
+	}
+
+	if events[1].Error == nil || events[1].Error.Error() != "Renew failed: The lease expired." {
+		t.Errorf("unexpected Renew error: %v", events[1].Error)
+	}
+
+	if events[2].Operation != ephemeralcheck.Close || string(events[2].RequestPrivate) != "one" || events[2].Error != nil {
+		t.Errorf("unexpected Close event: %+v", events[2])
+	}
+
+	recorder.Start(false)
+
+	if _, err := interceptor(context.Background(), calls[0], next); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if events := recorder.Events(); len(events) != 0 {
+		t.Errorf("expected no events after stopping, got: %v", events)
+	}
+}
@@ -6,6 +6,10 @@ package resource
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func unmarshalJSON(data []byte, v interface{}) error {
@@ -13,3 +17,77 @@ func unmarshalJSON(data []byte, v interface{}) error {
 	dec.UseNumber()
 	return dec.Decode(v)
 }
+
+// tftypesValueJSON returns the representation of a known value as decoded
+// from JSON, such as string, json.Number, bool, []any, and map[string]any,
+// which is expected by the knownvalue and tfjsonpath packages.
+func tftypesValueJSON(value tftypes.Value) (any, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var result string
+
+		err := value.As(&result)
+
+		return result, err
+	case value.Type().Is(tftypes.Number):
+		var result big.Float
+
+		if err := value.As(&result); err != nil {
+			return nil, err
+		}
+
+		return json.Number(result.Text('f', -1)), nil
+	case value.Type().Is(tftypes.Bool):
+		var result bool
+
+		err := value.As(&result)
+
+		return result, err
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Set{}), value.Type().Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+
+		result := make([]any, 0, len(elements))
+
+		for _, element := range elements {
+			v, err := tftypesValueJSON(element)
+
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, v)
+		}
+
+		return result, nil
+	case value.Type().Is(tftypes.Map{}), value.Type().Is(tftypes.Object{}):
+		var attributes map[string]tftypes.Value
+
+		if err := value.As(&attributes); err != nil {
+			return nil, err
+		}
+
+		result := make(map[string]any, len(attributes))
+
+		for name, attribute := range attributes {
+			v, err := tftypesValueJSON(attribute)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			result[name] = v
+		}
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value type %s", value.Type())
+}
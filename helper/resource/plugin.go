@@ -22,6 +22,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
 	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+	"github.com/hashicorp/terraform-plugin-testing/internal/providerwrap"
 )
 
 // protov5ProviderFactory is a function which is called to start a protocol
@@ -112,6 +113,84 @@ type providerFactories struct {
 	legacy  sdkProviderFactories
 	protov5 protov5ProviderFactories
 	protov6 protov6ProviderFactories
+
+	// interceptors return the interceptors called for every RPC to the
+	// provider servers, by provider name, such as to record and replay RPCs.
+	// The first interceptor is the outermost.
+	interceptors []func(providerName string) providerwrap.Interceptor
+
+	// stepInterceptors return the interceptors called, outside of the
+	// interceptors, for the RPCs which belong to the TestStep assertions,
+	// such as to record RPCs for RPCChecks or inject ProviderFaults. They are
+	// not called for the released providers of the TestCase UpgradeFrom.
+	stepInterceptors []func(providerName string) providerwrap.Interceptor
+}
+
+// interceptor returns the combined interceptors for the given provider, or
+// nil if there are none. If wd is not nil, the interceptors are called with
+// the name of the Terraform command running in the working directory, which
+// is returned by rpcCommand.
+func (f *providerFactories) interceptor(providerName string, wd *plugintest.WorkingDir) providerwrap.Interceptor {
+	if len(f.interceptors) == 0 && len(f.stepInterceptors) == 0 {
+		return nil
+	}
+
+	interceptors := make([]providerwrap.Interceptor, 0, len(f.stepInterceptors)+len(f.interceptors)+1)
+
+	if wd != nil {
+		interceptors = append(interceptors, func(ctx context.Context, call providerwrap.Call, next providerwrap.Handler) (any, error) {
+			return next(context.WithValue(ctx, rpcCommandContextKey{}, wd.Command()), call)
+		})
+	}
+
+	for _, interceptor := range f.stepInterceptors {
+		interceptors = append(interceptors, interceptor(providerName))
+	}
+
+	for _, interceptor := range f.interceptors {
+		interceptors = append(interceptors, interceptor(providerName))
+	}
+
+	return providerwrap.Chain(interceptors...)
+}
+
+// rpcCommandContextKey is the context key of the name of the Terraform
+// command which called a provider server RPC.
+type rpcCommandContextKey struct{}
+
+// rpcCommand returns the name of the Terraform command which called the
+// provider server RPC, such as "plan" or "apply", or an empty string if it is
+// not known.
+func rpcCommand(ctx context.Context) string {
+	command, _ := ctx.Value(rpcCommandContextKey{}).(string)
+
+	return command
+}
+
+// wrapProtoV5 returns the provider server wrapped with any interceptors. The
+// working directory, if not nil, is the one running the Terraform commands
+// which call the provider server.
+func (f *providerFactories) wrapProtoV5(providerName string, wd *plugintest.WorkingDir, server tfprotov5.ProviderServer) tfprotov5.ProviderServer {
+	interceptor := f.interceptor(providerName, wd)
+
+	if interceptor == nil {
+		return server
+	}
+
+	return providerwrap.ProtoV5ProviderServer(server, interceptor)
+}
+
+// wrapProtoV6 returns the provider server wrapped with any interceptors. The
+// working directory, if not nil, is the one running the Terraform commands
+// which call the provider server.
+func (f *providerFactories) wrapProtoV6(providerName string, wd *plugintest.WorkingDir, server tfprotov6.ProviderServer) tfprotov6.ProviderServer {
+	interceptor := f.interceptor(providerName, wd)
+
+	if interceptor == nil {
+		return server
+	}
+
+	return providerwrap.ProtoV6ProviderServer(server, interceptor)
 }
 
 func runProviderCommandApplyRefreshOnly(ctx context.Context, t testing.T, wd *plugintest.WorkingDir, factories *providerFactories) error {
@@ -213,6 +292,11 @@ func runProviderCommand(ctx context.Context, t testing.T, wd *plugintest.Working
 	// RPC and end those goroutines.
 	legacyProviderServers := make([]*schema.GRPCProviderServer, 0, len(factories.legacy))
 
+	// Terraform also does not call the StopProvider RPC when a Terraform
+	// command is interrupted in reattach mode, so save a function for every
+	// provider server to call that RPC if the command context is cancelled.
+	stopProviders := make([]func(context.Context), 0, len(factories.legacy)+len(factories.protov5)+len(factories.protov6))
+
 	// Spin up gRPC servers for every provider factory, start a
 	// WaitGroup to listen for all of the close channels.
 	var wg sync.WaitGroup
@@ -238,17 +322,22 @@ func runProviderCommand(ctx context.Context, t testing.T, wd *plugintest.Working
 
 		grpcProviderServer := schema.NewGRPCProviderServer(provider)
 		legacyProviderServers = append(legacyProviderServers, grpcProviderServer)
+		stopProviders = append(stopProviders, func(ctx context.Context) {
+			grpcProviderServer.StopProvider(ctx, nil) //nolint:errcheck // does not return errors
+		})
 
 		// Ensure StopProvider is always called when returning early.
 		defer grpcProviderServer.StopProvider(ctx, nil) //nolint:errcheck // does not return errors
 
+		servedProviderServer := factories.wrapProtoV5(providerName, wd, grpcProviderServer)
+
 		// configure the settings our plugin will be served with
 		// the GRPCProviderFunc wraps a non-gRPC provider server
 		// into a gRPC interface, and the logger just discards logs
 		// from go-plugin.
 		opts := &plugin.ServeOpts{
 			GRPCProviderFunc: func() tfprotov5.ProviderServer {
-				return grpcProviderServer
+				return servedProviderServer
 			},
 			Logger: hclog.New(&hclog.LoggerOptions{
 				Name:   "plugintest",
@@ -326,6 +415,13 @@ func runProviderCommand(ctx context.Context, t testing.T, wd *plugintest.Working
 
 		logging.HelperResourceTrace(ctx, "Created tfprotov5 provider instance", map[string]interface{}{logging.KeyProviderAddress: providerAddress})
 
+		unwrappedProvider := provider
+		stopProviders = append(stopProviders, func(ctx context.Context) {
+			unwrappedProvider.StopProvider(ctx, &tfprotov5.StopProviderRequest{}) //nolint:errcheck // best effort
+		})
+
+		provider = factories.wrapProtoV5(providerName, wd, provider)
+
 		// keep track of the running factory, so we can make sure it's
 		// shut down.
 		wg.Add(1)
@@ -415,6 +511,13 @@ func runProviderCommand(ctx context.Context, t testing.T, wd *plugintest.Working
 
 		logging.HelperResourceTrace(ctx, "Created tfprotov6 provider instance", map[string]interface{}{logging.KeyProviderAddress: providerAddress})
 
+		unwrappedProvider := provider
+		stopProviders = append(stopProviders, func(ctx context.Context) {
+			unwrappedProvider.StopProvider(ctx, &tfprotov6.StopProviderRequest{}) //nolint:errcheck // best effort
+		})
+
+		provider = factories.wrapProtoV6(providerName, wd, provider)
+
 		// keep track of the running factory, so we can make sure it's
 		// shut down.
 		wg.Add(1)
@@ -477,10 +580,20 @@ func runProviderCommand(ctx context.Context, t testing.T, wd *plugintest.Working
 
 	logging.HelperResourceTrace(ctx, "Calling wrapped Terraform CLI command")
 
+	stopOnCancel := context.AfterFunc(wd.CommandContext(), func() {
+		logging.HelperResourceWarn(ctx, "Terraform CLI command cancelled, calling StopProvider RPC")
+
+		for _, stopProvider := range stopProviders {
+			stopProvider(context.Background())
+		}
+	})
+
 	// ok, let's call whatever Terraform command the test was trying to
 	// call, now that we know it'll attach back to those servers we just
 	// started.
 	err := f()
+
+	stopOnCancel()
 	if err != nil {
 		logging.HelperResourceWarn(ctx, "Error running Terraform CLI command", map[string]interface{}{logging.KeyError: err})
 	}
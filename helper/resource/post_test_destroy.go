@@ -0,0 +1,256 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+// DestroyRetry is a policy for retrying the post-test destroy of a TestCase
+// after a failure, such as when a remote API is eventually consistent about
+// dependent resources. Retries are logged and each one is reported in the
+// test output.
+type DestroyRetry struct {
+	// MaxAttempts is the maximum number of times the destroy is run,
+	// including the first attempt. It must be at least 1.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry, which is doubled before
+	// each further retry.
+	Backoff time.Duration
+
+	// MaxBackoff, if set, is the maximum delay between attempts.
+	MaxBackoff time.Duration
+
+	// Retryable, if set, returns true if the destroy should be retried after
+	// the error. Defaults to retrying every error.
+	Retryable func(error) bool
+}
+
+// LeakedResourceReport is the JSON report written to the directory in the
+// TF_ACC_LEAKED_RESOURCES_DIR environment variable when the post-test
+// destroy or TestCase CheckDestroy fails.
+type LeakedResourceReport struct {
+	// TestName is the name of the test.
+	TestName string `json:"test_name"`
+
+	// Error is the error of the post-test destroy or CheckDestroy.
+	Error string `json:"error"`
+
+	// StateFile is the path of the copy of the raw state file written next
+	// to the report, or empty if the working directory had no local state
+	// file, such as when using a state store.
+	StateFile string `json:"state_file,omitempty"`
+
+	// Resources are the managed resource instances still in state.
+	Resources []LeakedResource `json:"resources"`
+}
+
+// LeakedResource is a managed resource instance still in state after the
+// post-test destroy or TestCase CheckDestroy failed.
+type LeakedResource struct {
+	// Address is the absolute resource instance address, such as
+	// module.example.examplecloud_thing.test[0].
+	Address string `json:"address"`
+
+	// Type is the resource type, such as examplecloud_thing.
+	Type string `json:"type"`
+
+	// ProviderName is the provider source address, such as
+	// registry.terraform.io/hashicorp/examplecloud.
+	ProviderName string `json:"provider_name"`
+
+	// ID is the string value of the id attribute, if any.
+	ID string `json:"id,omitempty"`
+
+	// Identity is the resource identity, if the provider supports resource
+	// identity.
+	Identity map[string]any `json:"identity,omitempty"`
+
+	// Tainted is true if the resource instance is tainted.
+	Tainted bool `json:"tainted,omitempty"`
+}
+
+// runPostTestDestroyCommand runs terraform destroy, retrying after errors
+// according to the TestCase DestroyRetry, and returns the error of the last
+// attempt.
+func runPostTestDestroyCommand(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, providers *providerFactories) error {
+	t.Helper()
+
+	for attempt := 1; ; attempt++ {
+		err := runProviderCommand(ctx, t, wd, providers, func() error {
+			return wd.Destroy(ctx)
+		})
+
+		if err == nil {
+			if attempt > 1 {
+				logging.HelperResourceWarn(ctx, "Post-test destroy passed after retrying", map[string]interface{}{logging.KeyTestDestroyAttempt: attempt})
+				t.Logf("Post-test destroy passed after %d attempts", attempt)
+			}
+
+			return nil
+		}
+
+		retry := c.DestroyRetry
+
+		if retry == nil || attempt >= retry.MaxAttempts {
+			return err
+		}
+
+		if retry.Retryable != nil && !retry.Retryable(err) {
+			logging.HelperResourceDebug(ctx, "Post-test destroy error is not retryable", map[string]interface{}{logging.KeyTestDestroyAttempt: attempt})
+
+			return err
+		}
+
+		delay := retryDelay(retry.Backoff, retry.MaxBackoff, attempt)
+
+		logging.HelperResourceWarn(ctx,
+			"Retrying post-test destroy after error",
+			map[string]interface{}{
+				logging.KeyError:                 err,
+				logging.KeyTestDestroyAttempt:    attempt,
+				logging.KeyTestDestroyRetryDelay: delay.String(),
+			},
+		)
+		t.Logf("Post-test destroy attempt %d/%d failed, retrying in %s: %s", attempt, retry.MaxAttempts, delay, err)
+
+		// Stop retrying when the time reserved for the destroy runs out.
+		select {
+		case <-time.After(delay):
+		case <-wd.CommandContext().Done():
+			return err
+		}
+	}
+}
+
+// leakedResources returns the managed resource instances in the state.
+func leakedResources(state *tfjson.State) []LeakedResource {
+	result := []LeakedResource{}
+
+	if state == nil || state.Values == nil {
+		return result
+	}
+
+	var walk func(module *tfjson.StateModule)
+
+	walk = func(module *tfjson.StateModule) {
+		if module == nil {
+			return
+		}
+
+		for _, resource := range module.Resources {
+			if resource.Mode != tfjson.ManagedResourceMode {
+				continue
+			}
+
+			leaked := LeakedResource{
+				Address:      resource.Address,
+				Type:         resource.Type,
+				ProviderName: resource.ProviderName,
+				Identity:     resource.IdentityValues,
+				Tainted:      resource.Tainted,
+			}
+
+			if id, ok := resource.AttributeValues["id"].(string); ok {
+				leaked.ID = id
+			}
+
+			result = append(result, leaked)
+		}
+
+		for _, child := range module.ChildModules {
+			walk(child)
+		}
+	}
+
+	walk(state.Values.RootModule)
+
+	return result
+}
+
+// writeLeakedResourceReport writes a LeakedResourceReport and a copy of the
+// raw state file of the working directory to the directory in the
+// TF_ACC_LEAKED_RESOURCES_DIR environment variable, if set, after the
+// post-test destroy or TestCase CheckDestroy failed with destroyErr.
+func writeLeakedResourceReport(ctx context.Context, t testing.T, wd *plugintest.WorkingDir, providers *providerFactories, destroyErr error) error {
+	t.Helper()
+
+	dir := os.Getenv(EnvTfAccLeakedResourcesDir)
+
+	if dir == "" {
+		return nil
+	}
+
+	// The state is still read if the destroy ran out of time, as terraform
+	// show is quick compared to the destroy.
+	if wd.CommandContext().Err() != nil {
+		wd.SetCommandContext(ctx)
+	}
+
+	var state *tfjson.State
+
+	err := runProviderCommand(ctx, t, wd, providers, func() error {
+		var err error
+
+		state, _, err = getState(ctx, t, wd)
+
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("retrieving state: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	name := strings.ReplaceAll(t.Name(), "/", "_")
+
+	report := LeakedResourceReport{
+		TestName:  t.Name(),
+		Error:     destroyErr.Error(),
+		Resources: leakedResources(state),
+	}
+
+	if stateFile, err := os.ReadFile(wd.StateFilePath()); err == nil {
+		report.StateFile = filepath.Join(dir, name+".tfstate")
+
+		if err := os.WriteFile(report.StateFile, stateFile, 0600); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("reading state file: %w", err)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	reportPath := filepath.Join(dir, name+".json")
+
+	if err := os.WriteFile(reportPath, data, 0600); err != nil {
+		return err
+	}
+
+	t.Logf("Leaked resource report has been written to: %s", reportPath)
+
+	return nil
+}
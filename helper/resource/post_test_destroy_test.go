@@ -0,0 +1,102 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+func TestLeakedResources(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		state    *tfjson.State
+		expected []LeakedResource
+	}{
+		"nil": {
+			expected: []LeakedResource{},
+		},
+		"no-values": {
+			state:    &tfjson.State{},
+			expected: []LeakedResource{},
+		},
+		"resources": {
+			state: &tfjson.State{
+				Values: &tfjson.StateValues{
+					RootModule: &tfjson.StateModule{
+						Resources: []*tfjson.StateResource{
+							{
+								Address:      "examplecloud_thing.test",
+								Mode:         tfjson.ManagedResourceMode,
+								Type:         "examplecloud_thing",
+								ProviderName: "registry.terraform.io/hashicorp/examplecloud",
+								AttributeValues: map[string]any{
+									"id": "thing-1",
+								},
+								IdentityValues: map[string]any{
+									"name": "thing-1",
+								},
+							},
+							{
+								Address:      "data.examplecloud_thing.test",
+								Mode:         tfjson.DataResourceMode,
+								Type:         "examplecloud_thing",
+								ProviderName: "registry.terraform.io/hashicorp/examplecloud",
+							},
+						},
+						ChildModules: []*tfjson.StateModule{
+							{
+								Address: "module.child",
+								Resources: []*tfjson.StateResource{
+									{
+										Address:      "module.child.examplecloud_thing.test[0]",
+										Mode:         tfjson.ManagedResourceMode,
+										Type:         "examplecloud_thing",
+										ProviderName: "registry.terraform.io/hashicorp/examplecloud",
+										AttributeValues: map[string]any{
+											"id": float64(2),
+										},
+										Tainted: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []LeakedResource{
+				{
+					Address:      "examplecloud_thing.test",
+					Type:         "examplecloud_thing",
+					ProviderName: "registry.terraform.io/hashicorp/examplecloud",
+					ID:           "thing-1",
+					Identity: map[string]any{
+						"name": "thing-1",
+					},
+				},
+				{
+					Address:      "module.child.examplecloud_thing.test[0]",
+					Type:         "examplecloud_thing",
+					ProviderName: "registry.terraform.io/hashicorp/examplecloud",
+					Tainted:      true,
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := leakedResources(testCase.state)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,884 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/providerwrap"
+)
+
+// protocolConformanceChecker validates the responses of the in-process
+// provider servers against the plugin protocol invariants Terraform relies
+// on, when TestCase ProtocolConformance is enabled.
+type protocolConformanceChecker struct {
+	mu      sync.Mutex
+	schemas map[string]any
+}
+
+// Interceptor returns a providerwrap.Interceptor which validates the resource
+// RPC responses of the provider server with the given name. Any violations
+// are returned to Terraform as an error diagnostic.
+func (p *protocolConformanceChecker) Interceptor(provider string) providerwrap.Interceptor {
+	return func(ctx context.Context, call providerwrap.Call, next providerwrap.Handler) (any, error) {
+		resp, err := next(ctx, call)
+
+		if err != nil || resp == nil {
+			return resp, err
+		}
+
+		switch call.RPC {
+		case "PlanResourceChange", "ApplyResourceChange", "ReadResource", "UpgradeResourceState":
+		default:
+			return resp, err
+		}
+
+		violations := checkProtocolConformance(p.providerSchema(ctx, provider, call), call.Request, resp)
+
+		if len(violations) == 0 {
+			return resp, err
+		}
+
+		logging.HelperResourceError(ctx, "Provider protocol conformance error", map[string]interface{}{"tf_provider": provider, "tf_rpc": call.RPC, "tf_type_name": call.TypeName(), "tf_violations": violations})
+
+		appendProtocolConformanceDiagnostic(call.ProtocolVersion, resp, fmt.Sprintf(
+			"The %s provider returned a %s response for %s which does not conform to the plugin protocol:\n\n- %s\n\n"+
+				"This is a bug in the provider, which was detected because TestCase ProtocolConformance is enabled.",
+			provider, call.RPC, call.TypeName(), strings.Join(violations, "\n- "),
+		))
+
+		return resp, err
+	}
+}
+
+// providerSchema returns the provider schema, retrieving it from the provider
+// server on first use in the TestCase.
+func (p *protocolConformanceChecker) providerSchema(ctx context.Context, provider string, call providerwrap.Call) any {
+	p.mu.Lock()
+	schema, ok := p.schemas[provider]
+	p.mu.Unlock()
+
+	if ok {
+		return schema
+	}
+
+	schema = getProviderSchema(ctx, call.Server)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.schemas == nil {
+		p.schemas = make(map[string]any)
+	}
+
+	p.schemas[provider] = schema
+
+	return schema
+}
+
+// appendProtocolConformanceDiagnostic appends an error diagnostic to the
+// Diagnostics field of the RPC response.
+func appendProtocolConformanceDiagnostic(protocolVersion int, resp any, detail string) {
+	diagnostics := reflect.ValueOf(resp).Elem().FieldByName("Diagnostics")
+
+	if !diagnostics.IsValid() {
+		return
+	}
+
+	var diagnostic any
+
+	switch protocolVersion {
+	case 5:
+		diagnostic = &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Provider Protocol Conformance Error",
+			Detail:   detail,
+		}
+	default:
+		diagnostic = &tfprotov6.Diagnostic{
+			Severity: tfprotov6.DiagnosticSeverityError,
+			Summary:  "Provider Protocol Conformance Error",
+			Detail:   detail,
+		}
+	}
+
+	diagnostics.Set(reflect.Append(diagnostics, reflect.ValueOf(diagnostic)))
+}
+
+// conformanceSchema is the part of a protocol version 5 or 6 resource schema
+// which determines the protocol invariants of its values.
+type conformanceSchema struct {
+	attributes map[string]conformanceAttribute
+}
+
+// conformanceAttribute is an attribute, nested attribute, or nested block of
+// a conformanceSchema.
+type conformanceAttribute struct {
+	computed  bool
+	writeOnly bool
+	nested    *conformanceSchema
+}
+
+func conformanceSchemaV5(block *tfprotov5.SchemaBlock) *conformanceSchema {
+	result := &conformanceSchema{attributes: make(map[string]conformanceAttribute)}
+
+	if block == nil {
+		return result
+	}
+
+	for _, attribute := range block.Attributes {
+		if attribute == nil {
+			continue
+		}
+
+		result.attributes[attribute.Name] = conformanceAttribute{
+			computed:  attribute.Computed,
+			writeOnly: attribute.WriteOnly,
+		}
+	}
+
+	for _, blockType := range block.BlockTypes {
+		if blockType == nil {
+			continue
+		}
+
+		result.attributes[blockType.TypeName] = conformanceAttribute{nested: conformanceSchemaV5(blockType.Block)}
+	}
+
+	return result
+}
+
+func conformanceSchemaV6(block *tfprotov6.SchemaBlock) *conformanceSchema {
+	result := &conformanceSchema{attributes: make(map[string]conformanceAttribute)}
+
+	if block == nil {
+		return result
+	}
+
+	for _, attribute := range block.Attributes {
+		if attribute == nil {
+			continue
+		}
+
+		result.attributes[attribute.Name] = conformanceAttribute{
+			computed:  attribute.Computed,
+			writeOnly: attribute.WriteOnly,
+			nested:    conformanceSchemaV6Object(attribute.NestedType),
+		}
+	}
+
+	for _, blockType := range block.BlockTypes {
+		if blockType == nil {
+			continue
+		}
+
+		result.attributes[blockType.TypeName] = conformanceAttribute{nested: conformanceSchemaV6(blockType.Block)}
+	}
+
+	return result
+}
+
+func conformanceSchemaV6Object(object *tfprotov6.SchemaObject) *conformanceSchema {
+	if object == nil {
+		return nil
+	}
+
+	result := &conformanceSchema{attributes: make(map[string]conformanceAttribute)}
+
+	for _, attribute := range object.Attributes {
+		if attribute == nil {
+			continue
+		}
+
+		result.attributes[attribute.Name] = conformanceAttribute{
+			computed:  attribute.Computed,
+			writeOnly: attribute.WriteOnly,
+			nested:    conformanceSchemaV6Object(attribute.NestedType),
+		}
+	}
+
+	return result
+}
+
+// dynamicValue is implemented by tfprotov5.DynamicValue and
+// tfprotov6.DynamicValue.
+type dynamicValue interface {
+	Unmarshal(tftypes.Type) (tftypes.Value, error)
+}
+
+// conformanceRPC holds the values of a resource RPC request and response in
+// a protocol version independent form. Nil values are treated as null.
+type conformanceRPC struct {
+	rpc       string
+	schema    *conformanceSchema
+	valueType tftypes.Type
+
+	// config and planned are set for PlanResourceChange.
+	config  dynamicValue
+	planned dynamicValue
+
+	// newState is set for ApplyResourceChange, alongside planned, and for
+	// ReadResource and UpgradeResourceState.
+	newState dynamicValue
+
+	// legacyTypeSystem is set for PlanResourceChange and ApplyResourceChange
+	// responses with UnsafeToUseLegacyTypeSystem, for which Terraform only
+	// logs warnings when the planned or new state is inconsistent.
+	legacyTypeSystem bool
+}
+
+// decode returns the value of the dynamicValue, or a violation if it cannot
+// be decoded with the resource schema.
+func (r conformanceRPC) decode(name string, dv dynamicValue) (tftypes.Value, string) {
+	if dv == nil {
+		return tftypes.NewValue(r.valueType, nil), ""
+	}
+
+	value, err := dv.Unmarshal(r.valueType)
+
+	if err != nil {
+		return value, fmt.Sprintf("unable to decode %s: %s", name, err)
+	}
+
+	return value, ""
+}
+
+// checkProtocolConformance returns a description of each protocol invariant
+// the resource RPC response violates. Responses with error diagnostics, or
+// for resource types missing from the provider schema, are not validated. As
+// in Terraform, the planned and new state of providers using the legacy type
+// system are not validated against the configuration and planned state, but
+// must still be wholly known after apply and have null write-only attributes.
+func checkProtocolConformance(providerSchema any, req any, resp any) []string {
+	rpc, ok := newConformanceRPC(providerSchema, req, resp)
+
+	if !ok {
+		return nil
+	}
+
+	switch rpc.rpc {
+	case "PlanResourceChange":
+		config, violation := rpc.decode("configuration", rpc.config)
+
+		if violation != "" {
+			return []string{violation}
+		}
+
+		planned, violation := rpc.decode("planned state", rpc.planned)
+
+		if violation != "" {
+			return []string{violation}
+		}
+
+		result := checkWriteOnlyNull("in planned state", tftypes.NewAttributePath(), rpc.schema, planned)
+
+		// Destroy plans have no configuration.
+		if config.IsNull() || rpc.legacyTypeSystem {
+			return result
+		}
+
+		return append(checkPlannedObject(tftypes.NewAttributePath(), rpc.schema, config, planned), result...)
+	case "ApplyResourceChange":
+		planned, violation := rpc.decode("planned state", rpc.planned)
+
+		if violation != "" {
+			return []string{violation}
+		}
+
+		newState, violation := rpc.decode("new state", rpc.newState)
+
+		if violation != "" {
+			return []string{violation}
+		}
+
+		result := append(checkWhollyKnown("after apply", newState), checkWriteOnlyNull("after apply", tftypes.NewAttributePath(), rpc.schema, newState)...)
+
+		if rpc.legacyTypeSystem {
+			return result
+		}
+
+		return append(checkAppliedValue(tftypes.NewAttributePath(), planned, newState), result...)
+	default:
+		newState, violation := rpc.decode("new state", rpc.newState)
+
+		if violation != "" {
+			return []string{violation}
+		}
+
+		when := "in " + rpc.rpc + " response"
+
+		return append(checkWhollyKnown(when, newState), checkWriteOnlyNull(when, tftypes.NewAttributePath(), rpc.schema, newState)...)
+	}
+}
+
+// newConformanceRPC returns the conformanceRPC for a resource RPC request and
+// response, or false if the RPC should not be validated.
+func newConformanceRPC(providerSchema any, req any, resp any) (conformanceRPC, bool) {
+	var result conformanceRPC
+	var typeName string
+	var hasErrors bool
+
+	switch req := req.(type) {
+	case *tfprotov5.PlanResourceChangeRequest:
+		resp, ok := resp.(*tfprotov5.PlanResourceChangeResponse)
+
+		if !ok {
+			return result, false
+		}
+
+		result.rpc, typeName, hasErrors = "PlanResourceChange", req.TypeName, hasErrorDiagnosticV5(resp.Diagnostics)
+		result.config, result.planned = dynamicValueV5(req.Config), dynamicValueV5(resp.PlannedState)
+		result.legacyTypeSystem = resp.UnsafeToUseLegacyTypeSystem
+	case *tfprotov5.ApplyResourceChangeRequest:
+		resp, ok := resp.(*tfprotov5.ApplyResourceChangeResponse)
+
+		if !ok {
+			return result, false
+		}
+
+		result.rpc, typeName, hasErrors = "ApplyResourceChange", req.TypeName, hasErrorDiagnosticV5(resp.Diagnostics)
+		result.planned, result.newState = dynamicValueV5(req.PlannedState), dynamicValueV5(resp.NewState)
+		result.legacyTypeSystem = resp.UnsafeToUseLegacyTypeSystem
+	case *tfprotov5.ReadResourceRequest:
+		resp, ok := resp.(*tfprotov5.ReadResourceResponse)
+
+		if !ok {
+			return result, false
+		}
+
+		result.rpc, typeName, hasErrors = "ReadResource", req.TypeName, hasErrorDiagnosticV5(resp.Diagnostics)
+		result.newState = dynamicValueV5(resp.NewState)
+	case *tfprotov5.UpgradeResourceStateRequest:
+		resp, ok := resp.(*tfprotov5.UpgradeResourceStateResponse)
+
+		if !ok {
+			return result, false
+		}
+
+		result.rpc, typeName, hasErrors = "UpgradeResourceState", req.TypeName, hasErrorDiagnosticV5(resp.Diagnostics)
+		result.newState = dynamicValueV5(resp.UpgradedState)
+	case *tfprotov6.PlanResourceChangeRequest:
+		resp, ok := resp.(*tfprotov6.PlanResourceChangeResponse)
+
+		if !ok {
+			return result, false
+		}
+
+		result.rpc, typeName, hasErrors = "PlanResourceChange", req.TypeName, hasErrorDiagnosticV6(resp.Diagnostics)
+		result.config, result.planned = dynamicValueV6(req.Config), dynamicValueV6(resp.PlannedState)
+		result.legacyTypeSystem = resp.UnsafeToUseLegacyTypeSystem
+	case *tfprotov6.ApplyResourceChangeRequest:
+		resp, ok := resp.(*tfprotov6.ApplyResourceChangeResponse)
+
+		if !ok {
+			return result, false
+		}
+
+		result.rpc, typeName, hasErrors = "ApplyResourceChange", req.TypeName, hasErrorDiagnosticV6(resp.Diagnostics)
+		result.planned, result.newState = dynamicValueV6(req.PlannedState), dynamicValueV6(resp.NewState)
+		result.legacyTypeSystem = resp.UnsafeToUseLegacyTypeSystem
+	case *tfprotov6.ReadResourceRequest:
+		resp, ok := resp.(*tfprotov6.ReadResourceResponse)
+
+		if !ok {
+			return result, false
+		}
+
+		result.rpc, typeName, hasErrors = "ReadResource", req.TypeName, hasErrorDiagnosticV6(resp.Diagnostics)
+		result.newState = dynamicValueV6(resp.NewState)
+	case *tfprotov6.UpgradeResourceStateRequest:
+		resp, ok := resp.(*tfprotov6.UpgradeResourceStateResponse)
+
+		if !ok {
+			return result, false
+		}
+
+		result.rpc, typeName, hasErrors = "UpgradeResourceState", req.TypeName, hasErrorDiagnosticV6(resp.Diagnostics)
+		result.newState = dynamicValueV6(resp.UpgradedState)
+	default:
+		return result, false
+	}
+
+	if hasErrors {
+		return result, false
+	}
+
+	switch providerSchema := providerSchema.(type) {
+	case *tfprotov5.GetProviderSchemaResponse:
+		schema := providerSchema.ResourceSchemas[typeName]
+
+		if schema == nil {
+			return result, false
+		}
+
+		result.schema, result.valueType = conformanceSchemaV5(schema.Block), schema.ValueType()
+	case *tfprotov6.GetProviderSchemaResponse:
+		schema := providerSchema.ResourceSchemas[typeName]
+
+		if schema == nil {
+			return result, false
+		}
+
+		result.schema, result.valueType = conformanceSchemaV6(schema.Block), schema.ValueType()
+	default:
+		return result, false
+	}
+
+	return result, true
+}
+
+func dynamicValueV5(dv *tfprotov5.DynamicValue) dynamicValue {
+	if dv == nil {
+		return nil
+	}
+
+	return dv
+}
+
+func dynamicValueV6(dv *tfprotov6.DynamicValue) dynamicValue {
+	if dv == nil {
+		return nil
+	}
+
+	return dv
+}
+
+func hasErrorDiagnosticV5(diagnostics []*tfprotov5.Diagnostic) bool {
+	return slices.ContainsFunc(diagnostics, func(diagnostic *tfprotov5.Diagnostic) bool {
+		return diagnostic != nil && diagnostic.Severity == tfprotov5.DiagnosticSeverityError
+	})
+}
+
+func hasErrorDiagnosticV6(diagnostics []*tfprotov6.Diagnostic) bool {
+	return slices.ContainsFunc(diagnostics, func(diagnostic *tfprotov6.Diagnostic) bool {
+		return diagnostic != nil && diagnostic.Severity == tfprotov6.DiagnosticSeverityError
+	})
+}
+
+// checkPlannedObject validates that the planned object matches the
+// configuration object for every attribute which is not computed, or which
+// is computed and has a configuration value. Write-only attributes are
+// validated by checkWriteOnlyNull instead, as they are planned as null.
+func checkPlannedObject(path *tftypes.AttributePath, schema *conformanceSchema, config tftypes.Value, planned tftypes.Value) []string {
+	if !planned.IsKnown() || planned.IsNull() {
+		return []string{fmt.Sprintf("%s: planned value %s does not match configuration value %s", conformancePath(path), conformanceValueString(planned), conformanceValueString(config))}
+	}
+
+	var configAttributes, plannedAttributes map[string]tftypes.Value
+
+	if err := config.As(&configAttributes); err != nil {
+		return []string{fmt.Sprintf("%s: unable to decode configuration value: %s", conformancePath(path), err)}
+	}
+
+	if err := planned.As(&plannedAttributes); err != nil {
+		return []string{fmt.Sprintf("%s: unable to decode planned value: %s", conformancePath(path), err)}
+	}
+
+	var result []string
+
+	for _, name := range slices.Sorted(maps.Keys(schema.attributes)) {
+		attribute := schema.attributes[name]
+		attributePath := path.WithAttributeName(name)
+		configValue, plannedValue := configAttributes[name], plannedAttributes[name]
+
+		if configValue.Type() == nil || plannedValue.Type() == nil {
+			continue
+		}
+
+		if attribute.writeOnly || (configValue.IsNull() && attribute.computed) {
+			continue
+		}
+
+		if attribute.nested == nil || !configValue.IsKnown() || configValue.IsNull() {
+			if !configValue.Equal(plannedValue) {
+				result = append(result, fmt.Sprintf("%s: planned value %s does not match configuration value %s", conformancePath(attributePath), conformanceValueString(plannedValue), conformanceValueString(configValue)))
+			}
+
+			continue
+		}
+
+		result = append(result, checkPlannedNested(attributePath, attribute.nested, configValue, plannedValue)...)
+	}
+
+	return result
+}
+
+// checkPlannedNested validates the planned value of a known nested attribute
+// or nested block, which is an object or a collection of objects.
+func checkPlannedNested(path *tftypes.AttributePath, schema *conformanceSchema, config tftypes.Value, planned tftypes.Value) []string {
+	mismatch := []string{fmt.Sprintf("%s: planned value %s does not match configuration value %s", conformancePath(path), conformanceValueString(planned), conformanceValueString(config))}
+
+	if !planned.IsKnown() || planned.IsNull() {
+		return mismatch
+	}
+
+	switch {
+	case config.Type().Is(tftypes.Object{}):
+		return checkPlannedObject(path, schema, config, planned)
+	case config.Type().Is(tftypes.List{}):
+		var configElements, plannedElements []tftypes.Value
+
+		if config.As(&configElements) != nil || planned.As(&plannedElements) != nil {
+			return mismatch
+		}
+
+		if len(configElements) != len(plannedElements) {
+			return []string{fmt.Sprintf("%s: planned %d elements, configuration has %d elements", conformancePath(path), len(plannedElements), len(configElements))}
+		}
+
+		var result []string
+
+		for i := range configElements {
+			result = append(result, checkPlannedNested(path.WithElementKeyInt(i), schema, configElements[i], plannedElements[i])...)
+		}
+
+		return result
+	case config.Type().Is(tftypes.Map{}):
+		var configElements, plannedElements map[string]tftypes.Value
+
+		if config.As(&configElements) != nil || planned.As(&plannedElements) != nil {
+			return mismatch
+		}
+
+		var result []string
+
+		for _, key := range slices.Sorted(maps.Keys(configElements)) {
+			plannedElement, ok := plannedElements[key]
+
+			if !ok {
+				result = append(result, fmt.Sprintf("%s: planned value is missing configuration element %s", conformancePath(path.WithElementKeyString(key)), conformanceValueString(configElements[key])))
+
+				continue
+			}
+
+			result = append(result, checkPlannedNested(path.WithElementKeyString(key), schema, configElements[key], plannedElement)...)
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(plannedElements)) {
+			if _, ok := configElements[key]; !ok {
+				result = append(result, fmt.Sprintf("%s: planned element %s is not in the configuration", conformancePath(path.WithElementKeyString(key)), conformanceValueString(plannedElements[key])))
+			}
+		}
+
+		return result
+	case config.Type().Is(tftypes.Set{}):
+		// Set elements cannot be correlated once computed attributes are
+		// planned, so only the number of elements is validated.
+		var configElements, plannedElements []tftypes.Value
+
+		if config.As(&configElements) != nil || planned.As(&plannedElements) != nil {
+			return mismatch
+		}
+
+		if config.IsFullyKnown() && len(configElements) != len(plannedElements) {
+			return []string{fmt.Sprintf("%s: planned %d set elements, configuration has %d set elements", conformancePath(path), len(plannedElements), len(configElements))}
+		}
+	}
+
+	return nil
+}
+
+// checkAppliedValue validates that the new state after apply matches every
+// known value in the planned state.
+func checkAppliedValue(path *tftypes.AttributePath, planned tftypes.Value, newState tftypes.Value) []string {
+	if !planned.IsKnown() {
+		return nil
+	}
+
+	mismatch := []string{fmt.Sprintf("%s: planned value %s, but applied value is %s", conformancePath(path), conformanceValueString(planned), conformanceValueString(newState))}
+
+	if planned.IsNull() || !newState.IsKnown() || newState.IsNull() {
+		if !planned.Equal(newState) {
+			return mismatch
+		}
+
+		return nil
+	}
+
+	switch {
+	case planned.Type().Is(tftypes.Object{}), planned.Type().Is(tftypes.Map{}):
+		var plannedElements, newElements map[string]tftypes.Value
+
+		if planned.As(&plannedElements) != nil || newState.As(&newElements) != nil {
+			return mismatch
+		}
+
+		var result []string
+
+		for _, key := range slices.Sorted(maps.Keys(plannedElements)) {
+			elementPath := path.WithAttributeName(key)
+
+			if planned.Type().Is(tftypes.Map{}) {
+				elementPath = path.WithElementKeyString(key)
+			}
+
+			newElement, ok := newElements[key]
+
+			if !ok {
+				result = append(result, fmt.Sprintf("%s: planned value %s, but applied value is missing", conformancePath(elementPath), conformanceValueString(plannedElements[key])))
+
+				continue
+			}
+
+			result = append(result, checkAppliedValue(elementPath, plannedElements[key], newElement)...)
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(newElements)) {
+			if _, ok := plannedElements[key]; !ok {
+				result = append(result, fmt.Sprintf("%s: applied value %s was not planned", conformancePath(path.WithElementKeyString(key)), conformanceValueString(newElements[key])))
+			}
+		}
+
+		return result
+	case planned.Type().Is(tftypes.List{}), planned.Type().Is(tftypes.Tuple{}):
+		var plannedElements, newElements []tftypes.Value
+
+		if planned.As(&plannedElements) != nil || newState.As(&newElements) != nil {
+			return mismatch
+		}
+
+		if len(plannedElements) != len(newElements) {
+			return []string{fmt.Sprintf("%s: planned %d elements, but applied value has %d elements", conformancePath(path), len(plannedElements), len(newElements))}
+		}
+
+		var result []string
+
+		for i := range plannedElements {
+			result = append(result, checkAppliedValue(path.WithElementKeyInt(i), plannedElements[i], newElements[i])...)
+		}
+
+		return result
+	case planned.Type().Is(tftypes.Set{}):
+		var plannedElements, newElements []tftypes.Value
+
+		if planned.As(&plannedElements) != nil || newState.As(&newElements) != nil {
+			return mismatch
+		}
+
+		if len(plannedElements) != len(newElements) {
+			return []string{fmt.Sprintf("%s: planned %d set elements, but applied value has %d set elements", conformancePath(path), len(plannedElements), len(newElements))}
+		}
+
+		// Set elements with unknown values cannot be correlated with their
+		// applied elements.
+		if !planned.IsFullyKnown() {
+			return nil
+		}
+
+		var result []string
+
+		for _, plannedElement := range plannedElements {
+			if !slices.ContainsFunc(newElements, plannedElement.Equal) {
+				result = append(result, fmt.Sprintf("%s: planned set element %s is missing from applied value %s", conformancePath(path), conformanceValueString(plannedElement), conformanceValueString(newState)))
+			}
+		}
+
+		return result
+	}
+
+	if !planned.Equal(newState) {
+		return mismatch
+	}
+
+	return nil
+}
+
+// checkWriteOnlyNull validates that every write-only attribute of the object,
+// including in nested attributes and blocks, is null, as Terraform never
+// stores write-only values in the plan or state.
+func checkWriteOnlyNull(when string, path *tftypes.AttributePath, schema *conformanceSchema, value tftypes.Value) []string {
+	var attributes map[string]tftypes.Value
+
+	if !value.IsKnown() || value.IsNull() || value.As(&attributes) != nil {
+		return nil
+	}
+
+	var result []string
+
+	for _, name := range slices.Sorted(maps.Keys(schema.attributes)) {
+		attribute := schema.attributes[name]
+		attributeValue := attributes[name]
+
+		if attributeValue.Type() == nil {
+			continue
+		}
+
+		if attribute.writeOnly && !attributeValue.IsNull() {
+			result = append(result, fmt.Sprintf("%s: non-null write-only value %s", conformancePath(path.WithAttributeName(name)), when))
+
+			continue
+		}
+
+		if attribute.nested != nil {
+			result = append(result, checkWriteOnlyNullNested(when, path.WithAttributeName(name), attribute.nested, attributeValue)...)
+		}
+	}
+
+	return result
+}
+
+// checkWriteOnlyNullNested validates the write-only attributes of a nested
+// attribute or nested block, which is an object or a collection of objects.
+func checkWriteOnlyNullNested(when string, path *tftypes.AttributePath, schema *conformanceSchema, value tftypes.Value) []string {
+	if !value.IsKnown() || value.IsNull() {
+		return nil
+	}
+
+	var result []string
+
+	switch {
+	case value.Type().Is(tftypes.Object{}):
+		return checkWriteOnlyNull(when, path, schema, value)
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Set{}):
+		var elements []tftypes.Value
+
+		if value.As(&elements) != nil {
+			return nil
+		}
+
+		for i, element := range elements {
+			elementPath := path.WithElementKeyInt(i)
+
+			if value.Type().Is(tftypes.Set{}) {
+				elementPath = path.WithElementKeyValue(element)
+			}
+
+			result = append(result, checkWriteOnlyNull(when, elementPath, schema, element)...)
+		}
+	case value.Type().Is(tftypes.Map{}):
+		var elements map[string]tftypes.Value
+
+		if value.As(&elements) != nil {
+			return nil
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(elements)) {
+			result = append(result, checkWriteOnlyNull(when, path.WithElementKeyString(key), schema, elements[key])...)
+		}
+	}
+
+	return result
+}
+
+// checkWhollyKnown validates that the value contains no unknown values.
+func checkWhollyKnown(when string, value tftypes.Value) []string {
+	var result []string
+
+	_ = tftypes.Walk(value, func(path *tftypes.AttributePath, value tftypes.Value) (bool, error) {
+		if value.IsKnown() {
+			return true, nil
+		}
+
+		result = append(result, fmt.Sprintf("%s: unknown value %s", conformancePath(path), when))
+
+		return false, nil
+	})
+
+	return result
+}
+
+// conformancePath returns a human-readable attribute path, such as
+// "tags[\"env\"]" or "rule[0].port".
+func conformancePath(path *tftypes.AttributePath) string {
+	var b strings.Builder
+
+	for _, step := range path.Steps() {
+		switch step := step.(type) {
+		case tftypes.AttributeName:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+
+			b.WriteString(string(step))
+		case tftypes.ElementKeyInt:
+			fmt.Fprintf(&b, "[%d]", step)
+		case tftypes.ElementKeyString:
+			fmt.Fprintf(&b, "[%q]", string(step))
+		case tftypes.ElementKeyValue:
+			fmt.Fprintf(&b, "[%s]", conformanceValueString(tftypes.Value(step)))
+		}
+	}
+
+	if b.Len() == 0 {
+		return "(resource)"
+	}
+
+	return b.String()
+}
+
+// conformanceValueString returns a human-readable representation of the
+// value, similar to Terraform configuration syntax.
+func conformanceValueString(value tftypes.Value) string {
+	if value.Type() == nil {
+		return "null"
+	}
+
+	if !value.IsKnown() {
+		return "(unknown)"
+	}
+
+	if value.IsNull() {
+		return "null"
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var s string
+
+		if value.As(&s) == nil {
+			return strconv.Quote(s)
+		}
+	case value.Type().Is(tftypes.Number):
+		var n big.Float
+
+		if value.As(&n) == nil {
+			return n.Text('g', -1)
+		}
+	case value.Type().Is(tftypes.Bool):
+		var b bool
+
+		if value.As(&b) == nil {
+			return strconv.FormatBool(b)
+		}
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Set{}), value.Type().Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+
+		if value.As(&elements) == nil {
+			result := make([]string, 0, len(elements))
+
+			for _, element := range elements {
+				result = append(result, conformanceValueString(element))
+			}
+
+			return "[" + strings.Join(result, ", ") + "]"
+		}
+	case value.Type().Is(tftypes.Map{}), value.Type().Is(tftypes.Object{}):
+		var elements map[string]tftypes.Value
+
+		if value.As(&elements) == nil {
+			result := make([]string, 0, len(elements))
+
+			for _, key := range slices.Sorted(maps.Keys(elements)) {
+				result = append(result, fmt.Sprintf("%s = %s", key, conformanceValueString(elements[key])))
+			}
+
+			return "{" + strings.Join(result, ", ") + "}"
+		}
+	}
+
+	return value.String()
+}
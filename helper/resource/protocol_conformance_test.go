@@ -0,0 +1,518 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/providerwrap"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+var protocolConformanceTestSchema = &tfprotov6.Schema{
+	Block: &tfprotov6.SchemaBlock{
+		Attributes: []*tfprotov6.SchemaAttribute{
+			{
+				Name:     "id",
+				Type:     tftypes.String,
+				Computed: true,
+			},
+			{
+				Name:     "name",
+				Type:     tftypes.String,
+				Required: true,
+			},
+			{
+				Name:     "tags",
+				Type:     tftypes.Set{ElementType: tftypes.String},
+				Optional: true,
+			},
+		},
+		BlockTypes: []*tfprotov6.SchemaNestedBlock{
+			{
+				TypeName: "rule",
+				Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "port",
+							Type:     tftypes.Number,
+							Required: true,
+						},
+						{
+							Name:     "arn",
+							Type:     tftypes.String,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+var protocolConformanceTestRuleType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"port": tftypes.Number,
+		"arn":  tftypes.String,
+	},
+}
+
+func protocolConformanceTestValue(id any, name string, tags []string, rules ...[2]any) *tfprotov6.DynamicValue {
+	tagValues := make([]tftypes.Value, 0, len(tags))
+
+	for _, tag := range tags {
+		tagValues = append(tagValues, tftypes.NewValue(tftypes.String, tag))
+	}
+
+	ruleValues := make([]tftypes.Value, 0, len(rules))
+
+	for _, rule := range rules {
+		ruleValues = append(ruleValues, tftypes.NewValue(protocolConformanceTestRuleType, map[string]tftypes.Value{
+			"port": tftypes.NewValue(tftypes.Number, rule[0]),
+			"arn":  tftypes.NewValue(tftypes.String, rule[1]),
+		}))
+	}
+
+	value, err := tfprotov6.NewDynamicValue(protocolConformanceTestSchema.ValueType(), tftypes.NewValue(protocolConformanceTestSchema.ValueType(), map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, id),
+		"name": tftypes.NewValue(tftypes.String, name),
+		"tags": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tagValues),
+		"rule": tftypes.NewValue(tftypes.List{ElementType: protocolConformanceTestRuleType}, ruleValues),
+	}))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return &value
+}
+
+func Test_CheckProtocolConformance(t *testing.T) {
+	t.Parallel()
+
+	providerSchema := &tfprotov6.GetProviderSchemaResponse{
+		ResourceSchemas: map[string]*tfprotov6.Schema{
+			"test_resource": protocolConformanceTestSchema,
+		},
+	}
+
+	testCases := map[string]struct {
+		req      any
+		resp     any
+		expected []string
+	}{
+		"plan-valid": {
+			req: &tfprotov6.PlanResourceChangeRequest{
+				TypeName: "test_resource",
+				Config:   protocolConformanceTestValue(nil, "test", []string{"a"}, [2]any{80, nil}),
+			},
+			resp: &tfprotov6.PlanResourceChangeResponse{
+				PlannedState: protocolConformanceTestValue(tftypes.UnknownValue, "test", []string{"a"}, [2]any{80, tftypes.UnknownValue}),
+			},
+		},
+		"plan-destroy": {
+			req: &tfprotov6.PlanResourceChangeRequest{
+				TypeName: "test_resource",
+			},
+			resp: &tfprotov6.PlanResourceChangeResponse{},
+		},
+		"plan-mismatch": {
+			req: &tfprotov6.PlanResourceChangeRequest{
+				TypeName: "test_resource",
+				Config:   protocolConformanceTestValue(nil, "test", []string{"a"}, [2]any{80, nil}),
+			},
+			resp: &tfprotov6.PlanResourceChangeResponse{
+				PlannedState: protocolConformanceTestValue(tftypes.UnknownValue, "other", []string{"a", "b"}, [2]any{8080, tftypes.UnknownValue}),
+			},
+			expected: []string{
+				`name: planned value "other" does not match configuration value "test"`,
+				`rule[0].port: planned value 8080 does not match configuration value 80`,
+				`tags: planned value ["a", "b"] does not match configuration value ["a"]`,
+			},
+		},
+		"plan-legacy-type-system": {
+			req: &tfprotov6.PlanResourceChangeRequest{
+				TypeName: "test_resource",
+				Config:   protocolConformanceTestValue(nil, "test", []string{"a"}, [2]any{80, nil}),
+			},
+			resp: &tfprotov6.PlanResourceChangeResponse{
+				PlannedState:                protocolConformanceTestValue(tftypes.UnknownValue, "other", []string{"a", "b"}, [2]any{8080, tftypes.UnknownValue}),
+				UnsafeToUseLegacyTypeSystem: true,
+			},
+		},
+		"plan-block-count": {
+			req: &tfprotov6.PlanResourceChangeRequest{
+				TypeName: "test_resource",
+				Config:   protocolConformanceTestValue(nil, "test", nil, [2]any{80, nil}),
+			},
+			resp: &tfprotov6.PlanResourceChangeResponse{
+				PlannedState: protocolConformanceTestValue(tftypes.UnknownValue, "test", nil),
+			},
+			expected: []string{
+				`rule: planned 0 elements, configuration has 1 elements`,
+			},
+		},
+		"plan-error-diagnostic": {
+			req: &tfprotov6.PlanResourceChangeRequest{
+				TypeName: "test_resource",
+				Config:   protocolConformanceTestValue(nil, "test", nil),
+			},
+			resp: &tfprotov6.PlanResourceChangeResponse{
+				Diagnostics: []*tfprotov6.Diagnostic{
+					{
+						Severity: tfprotov6.DiagnosticSeverityError,
+						Summary:  "error",
+					},
+				},
+			},
+		},
+		"plan-unknown-resource-type": {
+			req: &tfprotov6.PlanResourceChangeRequest{
+				TypeName: "test_other",
+				Config:   protocolConformanceTestValue(nil, "test", nil),
+			},
+			resp: &tfprotov6.PlanResourceChangeResponse{},
+		},
+		"apply-valid": {
+			req: &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "test_resource",
+				PlannedState: protocolConformanceTestValue(tftypes.UnknownValue, "test", []string{"a", "b"}, [2]any{80, tftypes.UnknownValue}),
+			},
+			resp: &tfprotov6.ApplyResourceChangeResponse{
+				NewState: protocolConformanceTestValue("id-123", "test", []string{"b", "a"}, [2]any{80, "arn"}),
+			},
+		},
+		"apply-mismatch": {
+			req: &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "test_resource",
+				PlannedState: protocolConformanceTestValue(tftypes.UnknownValue, "test", []string{"a", "b"}, [2]any{80, tftypes.UnknownValue}),
+			},
+			resp: &tfprotov6.ApplyResourceChangeResponse{
+				NewState: protocolConformanceTestValue("id-123", "other", []string{"a", "c"}, [2]any{80, tftypes.UnknownValue}),
+			},
+			expected: []string{
+				`name: planned value "test", but applied value is "other"`,
+				`tags: planned set element "b" is missing from applied value ["a", "c"]`,
+				`rule[0].arn: unknown value after apply`,
+			},
+		},
+		"apply-legacy-type-system": {
+			req: &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "test_resource",
+				PlannedState: protocolConformanceTestValue(tftypes.UnknownValue, "test", []string{"a", "b"}, [2]any{80, tftypes.UnknownValue}),
+			},
+			resp: &tfprotov6.ApplyResourceChangeResponse{
+				NewState:                    protocolConformanceTestValue("id-123", "other", []string{"a", "c"}, [2]any{80, tftypes.UnknownValue}),
+				UnsafeToUseLegacyTypeSystem: true,
+			},
+			expected: []string{
+				`rule[0].arn: unknown value after apply`,
+			},
+		},
+		"apply-set-elements": {
+			req: &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "test_resource",
+				PlannedState: protocolConformanceTestValue("id-123", "test", []string{"a", "b"}),
+			},
+			resp: &tfprotov6.ApplyResourceChangeResponse{
+				NewState: protocolConformanceTestValue("id-123", "test", []string{"a"}),
+			},
+			expected: []string{
+				`tags: planned 2 set elements, but applied value has 1 set elements`,
+			},
+		},
+		"apply-destroy": {
+			req: &tfprotov6.ApplyResourceChangeRequest{
+				TypeName: "test_resource",
+			},
+			resp: &tfprotov6.ApplyResourceChangeResponse{
+				NewState: protocolConformanceTestValue("id-123", "test", nil),
+			},
+			expected: []string{
+				`(resource): planned value null, but applied value is {id = "id-123", name = "test", rule = [], tags = []}`,
+			},
+		},
+		"read-valid": {
+			req: &tfprotov6.ReadResourceRequest{
+				TypeName: "test_resource",
+			},
+			resp: &tfprotov6.ReadResourceResponse{
+				NewState: protocolConformanceTestValue("id-123", "test", nil),
+			},
+		},
+		"read-unknown": {
+			req: &tfprotov6.ReadResourceRequest{
+				TypeName: "test_resource",
+			},
+			resp: &tfprotov6.ReadResourceResponse{
+				NewState: protocolConformanceTestValue(tftypes.UnknownValue, "test", nil),
+			},
+			expected: []string{
+				`id: unknown value in ReadResource response`,
+			},
+		},
+		"upgrade-unknown": {
+			req: &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: "test_resource",
+			},
+			resp: &tfprotov6.UpgradeResourceStateResponse{
+				UpgradedState: protocolConformanceTestValue("id-123", "test", nil, [2]any{80, tftypes.UnknownValue}),
+			},
+			expected: []string{
+				`rule[0].arn: unknown value in UpgradeResourceState response`,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := checkProtocolConformance(providerSchema, testCase.req, testCase.resp)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_CheckProtocolConformance_WriteOnly(t *testing.T) {
+	t.Parallel()
+
+	settingsType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"token": tftypes.String,
+		},
+	}
+
+	schema := &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name:      "password",
+					Type:      tftypes.String,
+					Optional:  true,
+					WriteOnly: true,
+				},
+				{
+					Name: "settings",
+					NestedType: &tfprotov6.SchemaObject{
+						Nesting: tfprotov6.SchemaObjectNestingModeList,
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:      "token",
+								Type:      tftypes.String,
+								Optional:  true,
+								WriteOnly: true,
+							},
+						},
+					},
+					Optional: true,
+				},
+			},
+		},
+	}
+
+	providerSchema := &tfprotov6.GetProviderSchemaResponse{
+		ResourceSchemas: map[string]*tfprotov6.Schema{
+			"test_resource": schema,
+		},
+	}
+
+	value := func(id any, password any, token any) *tfprotov6.DynamicValue {
+		dv, err := tfprotov6.NewDynamicValue(schema.ValueType(), tftypes.NewValue(schema.ValueType(), map[string]tftypes.Value{
+			"id":       tftypes.NewValue(tftypes.String, id),
+			"password": tftypes.NewValue(tftypes.String, password),
+			"settings": tftypes.NewValue(tftypes.List{ElementType: settingsType}, []tftypes.Value{
+				tftypes.NewValue(settingsType, map[string]tftypes.Value{
+					"token": tftypes.NewValue(tftypes.String, token),
+				}),
+			}),
+		}))
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		return &dv
+	}
+
+	testCases := map[string]struct {
+		req      any
+		resp     any
+		expected []string
+	}{
+		"plan-valid": {
+			req: &tfprotov6.PlanResourceChangeRequest{
+				TypeName: "test_resource",
+				Config:   value(nil, "secret", "secret"),
+			},
+			resp: &tfprotov6.PlanResourceChangeResponse{
+				PlannedState: value(tftypes.UnknownValue, nil, nil),
+			},
+		},
+		"plan-non-null": {
+			req: &tfprotov6.PlanResourceChangeRequest{
+				TypeName: "test_resource",
+				Config:   value(nil, "secret", "secret"),
+			},
+			resp: &tfprotov6.PlanResourceChangeResponse{
+				PlannedState: value(tftypes.UnknownValue, "secret", "secret"),
+			},
+			expected: []string{
+				`password: non-null write-only value in planned state`,
+				`settings[0].token: non-null write-only value in planned state`,
+			},
+		},
+		"plan-legacy-type-system-non-null": {
+			req: &tfprotov6.PlanResourceChangeRequest{
+				TypeName: "test_resource",
+				Config:   value(nil, "secret", nil),
+			},
+			resp: &tfprotov6.PlanResourceChangeResponse{
+				PlannedState:                value(tftypes.UnknownValue, "secret", nil),
+				UnsafeToUseLegacyTypeSystem: true,
+			},
+			expected: []string{
+				`password: non-null write-only value in planned state`,
+			},
+		},
+		"apply-non-null": {
+			req: &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "test_resource",
+				PlannedState: value(tftypes.UnknownValue, nil, nil),
+			},
+			resp: &tfprotov6.ApplyResourceChangeResponse{
+				NewState:                    value("id-123", nil, "secret"),
+				UnsafeToUseLegacyTypeSystem: true,
+			},
+			expected: []string{
+				`settings[0].token: non-null write-only value after apply`,
+			},
+		},
+		"read-non-null": {
+			req: &tfprotov6.ReadResourceRequest{
+				TypeName: "test_resource",
+			},
+			resp: &tfprotov6.ReadResourceResponse{
+				NewState: value("id-123", "secret", nil),
+			},
+			expected: []string{
+				`password: non-null write-only value in ReadResource response`,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := checkProtocolConformance(providerSchema, testCase.req, testCase.resp)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_ProtocolConformance_Interceptor(t *testing.T) {
+	t.Parallel()
+
+	server, err := providerserver.NewProviderServer(testprovider.Provider{
+		Resources: map[string]testprovider.Resource{
+			"test_resource": {
+				SchemaResponse: &resource.SchemaResponse{
+					Schema: protocolConformanceTestSchema,
+				},
+			},
+		},
+	})()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	conformance := &protocolConformanceChecker{}
+	call := providerwrap.Call{
+		ProtocolVersion: 6,
+		RPC:             "ReadResource",
+		Request:         &tfprotov6.ReadResourceRequest{TypeName: "test_resource"},
+		Server:          server,
+	}
+
+	resp, err := conformance.Interceptor("test")(context.Background(), call, func(ctx context.Context, call providerwrap.Call) (any, error) {
+		return &tfprotov6.ReadResourceResponse{
+			NewState: protocolConformanceTestValue(tftypes.UnknownValue, "test", nil),
+		}, nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	diagnostics := resp.(*tfprotov6.ReadResourceResponse).Diagnostics
+
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diagnostics))
+	}
+
+	expectedDetail := regexp.MustCompile(`The test provider returned a ReadResource response for test_resource which does not conform to the plugin protocol:\n\n- id: unknown value in ReadResource response`)
+
+	if diagnostics[0].Severity != tfprotov6.DiagnosticSeverityError || !expectedDetail.MatchString(diagnostics[0].Detail) {
+		t.Errorf("unexpected diagnostic: %#v", diagnostics[0])
+	}
+}
+
+func Test_ProtocolConformance_ApplyMismatch(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtocolConformance: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": {
+						CreateFunc: func(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+							resp.NewState = tftypes.NewValue(protocolConformanceTestSchema.ValueType(), map[string]tftypes.Value{
+								"id":   tftypes.NewValue(tftypes.String, "test"),
+								"name": tftypes.NewValue(tftypes.String, "other"),
+								"tags": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+								"rule": tftypes.NewValue(tftypes.List{ElementType: protocolConformanceTestRuleType}, []tftypes.Value{}),
+							})
+						},
+						SchemaResponse: &resource.SchemaResponse{
+							Schema: protocolConformanceTestSchema,
+						},
+					},
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config:      `resource "test_resource" "test" { name = "test" }`,
+				ExpectError: regexp.MustCompile(`name: planned value "test", but applied value is "other"`),
+			},
+		},
+	})
+}
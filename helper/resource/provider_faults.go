@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/providerwrap"
+	"github.com/hashicorp/terraform-plugin-testing/providerfault"
+)
+
+// hasProviderFaults returns true if any TestStep has ProviderFaults.
+func (c TestCase) hasProviderFaults() bool {
+	for _, step := range c.Steps {
+		if len(step.ProviderFaults) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// providerFaultInjector injects the ProviderFaults of the current TestStep
+// into the RPCs to the provider servers.
+type providerFaultInjector struct {
+	mu     sync.Mutex
+	faults []providerfault.Fault
+	counts []int
+}
+
+// Start discards the call counts and injects the given faults into subsequent
+// RPCs, or none if empty.
+func (f *providerFaultInjector) Start(faults []providerfault.Fault) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.faults = faults
+	f.counts = make([]int, len(faults))
+}
+
+// Interceptor returns a providerwrap.Interceptor which injects faults into the
+// RPCs to the provider server with the given name.
+func (f *providerFaultInjector) Interceptor(provider string) providerwrap.Interceptor {
+	return func(ctx context.Context, call providerwrap.Call, next providerwrap.Handler) (any, error) {
+		fault, ok := f.match(provider, call)
+
+		if !ok {
+			return next(ctx, call)
+		}
+
+		logging.HelperResourceDebug(ctx, "Injecting TestStep ProviderFaults fault", map[string]interface{}{"tf_provider": provider, "tf_rpc": call.RPC, "tf_type_name": call.TypeName()})
+
+		req := providerfault.InjectFaultRequest{
+			ProtocolVersion: call.ProtocolVersion,
+			RPC:             call.RPC,
+			Request:         call.Request,
+			NewResponse:     call.NewResponse,
+			CallProvider: func(ctx context.Context) (any, error) {
+				return next(ctx, call)
+			},
+		}
+		resp := providerfault.InjectFaultResponse{}
+
+		fault.Action.InjectFault(ctx, req, &resp)
+
+		return resp.Response, resp.Error
+	}
+}
+
+// match returns the first fault matching the call, after counting the call
+// for every fault it matches.
+func (f *providerFaultInjector) match(provider string, call providerwrap.Call) (providerfault.Fault, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var result providerfault.Fault
+	var found bool
+
+	for i, fault := range f.faults {
+		if fault.RPC != call.RPC {
+			continue
+		}
+
+		if fault.Provider != "" && fault.Provider != provider {
+			continue
+		}
+
+		if fault.TypeName != "" && fault.TypeName != call.TypeName() {
+			continue
+		}
+
+		f.counts[i]++
+
+		if found || (fault.Call != 0 && fault.Call != f.counts[i]) {
+			continue
+		}
+
+		result = fault
+		found = true
+	}
+
+	return result, found
+}
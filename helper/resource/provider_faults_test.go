@@ -0,0 +1,100 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/providerwrap"
+	"github.com/hashicorp/terraform-plugin-testing/providerfault"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func Test_ProviderFaultInjector_Call(t *testing.T) {
+	t.Parallel()
+
+	injector := &providerFaultInjector{}
+	injector.Start([]providerfault.Fault{
+		{
+			RPC:      "ReadResource",
+			TypeName: "test_resource",
+			Call:     2,
+			Action:   providerfault.ErrorDiagnostic("injected", ""),
+		},
+	})
+
+	interceptor := injector.Interceptor("test")
+
+	call := func(typeName string) *tfprotov6.ReadResourceResponse {
+		resp, err := interceptor(context.Background(), providerwrap.Call{
+			ProtocolVersion: 6,
+			RPC:             "ReadResource",
+			Request:         &tfprotov6.ReadResourceRequest{TypeName: typeName},
+			NewResponse:     func() any { return new(tfprotov6.ReadResourceResponse) },
+		}, func(context.Context, providerwrap.Call) (any, error) {
+			return &tfprotov6.ReadResourceResponse{}, nil
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		return resp.(*tfprotov6.ReadResourceResponse) //nolint:forcetypeassert // test handler
+	}
+
+	// The other type name is not counted.
+	results := []*tfprotov6.ReadResourceResponse{
+		call("test_resource"),
+		call("test_other"),
+		call("test_resource"),
+		call("test_resource"),
+	}
+
+	for i, result := range results {
+		injected := len(result.Diagnostics) > 0
+
+		if expected := i == 2; injected != expected {
+			t.Errorf("call %d: expected fault injected %t, got %t", i+1, expected, injected)
+		}
+	}
+
+	// Starting the next TestStep without faults resets the injector.
+	injector.Start(nil)
+
+	if result := call("test_resource"); len(result.Diagnostics) > 0 {
+		t.Error("expected no fault after Start(nil)")
+	}
+}
+
+func Test_ProviderFaults_ErrorDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: rpcChecksTestProviderFactories(),
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+				ProviderFaults: []providerfault.Fault{
+					{
+						RPC:      "ApplyResourceChange",
+						TypeName: "test_resource",
+						Call:     1,
+						Action:   providerfault.ErrorDiagnostic("Injected API error", "The remote API returned an error."),
+					},
+				},
+				ExpectError: regexp.MustCompile(`Injected API error`),
+			},
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+		},
+	})
+}
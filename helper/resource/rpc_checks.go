@@ -0,0 +1,146 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/providerwrap"
+	"github.com/hashicorp/terraform-plugin-testing/rpccheck"
+)
+
+// hasRPCChecks returns true if any TestStep has RPCChecks.
+func (c TestCase) hasRPCChecks() bool {
+	for _, step := range c.Steps {
+		if len(step.RPCChecks) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func runRPCChecks(ctx context.Context, t testing.T, calls []rpccheck.Call, rpcChecks []rpccheck.RPCCheck) error {
+	t.Helper()
+
+	var result []error
+
+	for _, rpcCheck := range rpcChecks {
+		resp := rpccheck.CheckRPCsResponse{}
+		rpcCheck.CheckRPCs(ctx, rpccheck.CheckRPCsRequest{Calls: calls}, &resp)
+
+		result = append(result, resp.Error)
+	}
+
+	return errors.Join(result...)
+}
+
+// rpcCallRecorder records the RPCs to the in-process provider servers during
+// a TestStep with RPCChecks.
+type rpcCallRecorder struct {
+	mu        sync.Mutex
+	recording bool
+	calls     []rpccheck.Call
+	schemas   map[string]any
+}
+
+// Start discards any recorded calls and starts or stops recording calls.
+func (r *rpcCallRecorder) Start(recording bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recording = recording
+	r.calls = nil
+	r.schemas = make(map[string]any)
+}
+
+// Calls returns the recorded calls.
+func (r *rpcCallRecorder) Calls() []rpccheck.Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]rpccheck.Call(nil), r.calls...)
+}
+
+// Interceptor returns a providerwrap.Interceptor which records the RPCs to
+// the provider server with the given name.
+func (r *rpcCallRecorder) Interceptor(provider string) providerwrap.Interceptor {
+	return func(ctx context.Context, call providerwrap.Call, next providerwrap.Handler) (any, error) {
+		resp, err := next(ctx, call)
+
+		r.mu.Lock()
+		recording := r.recording
+		r.mu.Unlock()
+
+		if !recording {
+			return resp, err
+		}
+
+		schema := r.providerSchema(ctx, provider, call)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.calls = append(r.calls, rpccheck.Call{
+			Provider:        provider,
+			ProtocolVersion: call.ProtocolVersion,
+			RPC:             call.RPC,
+			Command:         rpcCommand(ctx),
+			Request:         call.Request,
+			Response:        resp,
+			Error:           err,
+			ProviderSchema:  schema,
+		})
+
+		return resp, err
+	}
+}
+
+// providerSchema returns the provider schema, retrieving it from the provider
+// server on first use in the TestStep.
+func (r *rpcCallRecorder) providerSchema(ctx context.Context, provider string, call providerwrap.Call) any {
+	r.mu.Lock()
+	schema, ok := r.schemas[provider]
+	r.mu.Unlock()
+
+	if ok {
+		return schema
+	}
+
+	schema = getProviderSchema(ctx, call.Server)
+
+	r.mu.Lock()
+	r.schemas[provider] = schema
+	r.mu.Unlock()
+
+	return schema
+}
+
+// getProviderSchema returns the *tfprotov5.GetProviderSchemaResponse or
+// *tfprotov6.GetProviderSchemaResponse of the provider server, or nil if it
+// cannot be retrieved.
+func getProviderSchema(ctx context.Context, server any) any {
+	switch server := server.(type) {
+	case tfprotov5.ProviderServer:
+		resp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+		if err == nil {
+			return resp
+		}
+	case tfprotov6.ProviderServer:
+		resp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+		if err == nil {
+			return resp
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/rpccheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+type rpcCheckSpy struct {
+	err   error
+	calls []rpccheck.Call
+}
+
+func (s *rpcCheckSpy) CheckRPCs(ctx context.Context, req rpccheck.CheckRPCsRequest, resp *rpccheck.CheckRPCsResponse) {
+	s.calls = req.Calls
+	resp.Error = s.err
+}
+
+func (s *rpcCheckSpy) called(rpc string) bool {
+	for _, call := range s.calls {
+		if call.RPC == rpc {
+			return true
+		}
+	}
+
+	return false
+}
+
+func rpcChecksTestProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"test": providerserver.NewProviderServer(testprovider.Provider{
+			Resources: map[string]testprovider.Resource{
+				"test_resource": {
+					CreateResponse: &resource.CreateResponse{
+						NewState: tftypes.NewValue(
+							tftypes.Object{
+								AttributeTypes: map[string]tftypes.Type{
+									"id": tftypes.String,
+								},
+							},
+							map[string]tftypes.Value{
+								"id": tftypes.NewValue(tftypes.String, "test"),
+							},
+						),
+					},
+					SchemaResponse: &resource.SchemaResponse{
+						Schema: &tfprotov6.Schema{
+							Block: &tfprotov6.SchemaBlock{
+								Attributes: []*tfprotov6.SchemaAttribute{
+									{
+										Name:     "id",
+										Type:     tftypes.String,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func Test_RPCChecks_CalledPerStep(t *testing.T) {
+	t.Parallel()
+
+	spy1 := &rpcCheckSpy{}
+	spy2 := &rpcCheckSpy{}
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: rpcChecksTestProviderFactories(),
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+				RPCChecks: []rpccheck.RPCCheck{
+					spy1,
+					rpccheck.ExpectResourceCallCount("ApplyResourceChange", "test_resource", 1),
+				},
+			},
+			{
+				RefreshState: true,
+				RPCChecks: []rpccheck.RPCCheck{
+					spy2,
+					rpccheck.ExpectResourceCallCount("ApplyResourceChange", "test_resource", 0),
+				},
+			},
+		},
+	})
+
+	if !spy1.called("ApplyResourceChange") {
+		t.Error("expected first TestStep RPCChecks to include ApplyResourceChange")
+	}
+
+	if !spy2.called("ReadResource") {
+		t.Error("expected second TestStep RPCChecks to include ReadResource")
+	}
+}
+
+func Test_RPCChecks_Errors(t *testing.T) {
+	t.Parallel()
+
+	spy1 := &rpcCheckSpy{}
+	spy2 := &rpcCheckSpy{
+		err: errors.New("spy2 check failed"),
+	}
+	spy3 := &rpcCheckSpy{
+		err: errors.New("spy3 check failed"),
+	}
+
+	calls := []rpccheck.Call{
+		{
+			Provider:        "test",
+			ProtocolVersion: 6,
+			RPC:             "ReadResource",
+			Request:         &tfprotov6.ReadResourceRequest{TypeName: "test_resource"},
+		},
+	}
+
+	err := runRPCChecks(context.Background(), t, calls, []rpccheck.RPCCheck{spy1, spy2, spy3})
+
+	if err == nil || !regexp.MustCompile(`^spy2 check failed\nspy3 check failed$`).MatchString(err.Error()) {
+		t.Errorf("expected spy2 and spy3 errors, got: %v", err)
+	}
+
+	if !spy1.called("ReadResource") {
+		t.Error("expected spy1 to receive ReadResource call")
+	}
+}
+
+func Test_RPCChecks_ImportStateCommand(t *testing.T) {
+	t.Parallel()
+
+	state := tftypes.NewValue(
+		tftypes.Object{
+			AttributeTypes: map[string]tftypes.Type{
+				"id": tftypes.String,
+			},
+		},
+		map[string]tftypes.Value{
+			"id": tftypes.NewValue(tftypes.String, "test"),
+		},
+	)
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": {
+						CreateResponse: &resource.CreateResponse{
+							NewState: state,
+						},
+						ImportStateResponse: &resource.ImportStateResponse{
+							State: state,
+						},
+						ReadResponse: &resource.ReadResponse{
+							NewState: state,
+						},
+						SchemaResponse: &resource.SchemaResponse{
+							Schema: &tfprotov6.Schema{
+								Block: &tfprotov6.SchemaBlock{
+									Attributes: []*tfprotov6.SchemaAttribute{
+										{
+											Name:     "id",
+											Type:     tftypes.String,
+											Computed: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+			{
+				// The import runs in a separate working directory, as
+				// ImportStatePersist is false.
+				ResourceName: "test_resource.test",
+				ImportState:  true,
+				RPCChecks: []rpccheck.RPCCheck{
+					rpccheck.ExpectCommandResourceCallCount("import", "ImportResourceState", "test_resource", 1),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,267 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+// SecretScanner is a set of sensitive values, such as generated passwords or
+// write-only attribute values, which must never be persisted or output by
+// Terraform. When set as the TestCase SecretScanner, the following are
+// scanned for every registered value after each TestStep:
+//
+//   - The raw state file.
+//   - The saved plan, in its JSON representation.
+//   - The human-readable output of the saved plan.
+//   - The Terraform CLI log file, if the TF_ACC_LOG_PATH or TF_LOG_PATH_MASK
+//     environment variable is set.
+//
+// The TestStep fails with the location of each value found, such as the
+// attribute path in the state file or the line in the log file. The TestStep
+// which ended the test, including one which failed such as after a partial
+// apply, is scanned before the post-test destroy. Unlike
+// statecheck.ExpectSensitiveValue, this catches values of write-only and
+// ephemeral attributes which are leaked to other attributes or outputs.
+//
+// Values can be registered before the TestCase or while it runs, such as in
+// a TestStep PreConfig or Check function. Short or common values may cause
+// false positives, so only register values which are unlikely to appear
+// otherwise. The zero value is ready to use and is safe for concurrent use.
+type SecretScanner struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+// Register adds a sensitive value to scan for. The name identifies the value
+// in failure messages, so the value itself is never output. Registering an
+// existing name replaces its value. Empty values are ignored.
+func (s *SecretScanner) Register(name string, value string) {
+	if value == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.values == nil {
+		s.values = make(map[string]string)
+	}
+
+	s.values[name] = value
+}
+
+// secretSource is content scanned by a SecretScanner.
+type secretSource struct {
+	// name describes the content in failure messages.
+	name string
+
+	// data is the content to scan.
+	data []byte
+
+	// json is true if the content is JSON, so that locations are reported
+	// as paths rather than lines. Content which cannot be decoded as JSON
+	// is scanned by lines.
+	json bool
+}
+
+// scan returns an error with the location of each registered value in the
+// sources, or nil if none are found.
+func (s *SecretScanner) scan(sources []secretSource) error {
+	s.mu.Lock()
+	values := maps.Clone(s.values)
+	s.mu.Unlock()
+
+	var result []error
+
+	for _, source := range sources {
+		var locations map[string][]string
+
+		if source.json {
+			locations = secretJSONLocations(source.data, values)
+		}
+
+		if locations == nil {
+			locations = secretLineLocations(source.data, values)
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(locations)) {
+			for _, location := range locations[name] {
+				result = append(result, fmt.Errorf("%s found in %s at %s", name, source.name, location))
+			}
+		}
+	}
+
+	return errors.Join(result...)
+}
+
+// secretJSONLocations returns the paths of the string and number values and
+// object keys containing each value, keyed by value name, or nil if the data
+// cannot be decoded as JSON.
+func secretJSONLocations(data []byte, values map[string]string) map[string][]string {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v any
+
+	if err := decoder.Decode(&v); err != nil {
+		return nil
+	}
+
+	result := make(map[string][]string)
+
+	var walk func(path string, v any)
+
+	match := func(path string, s string) {
+		for name, value := range values {
+			if strings.Contains(s, value) {
+				result[name] = append(result[name], path)
+			}
+		}
+	}
+
+	walk = func(path string, v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for _, key := range slices.Sorted(maps.Keys(v)) {
+				keyPath := key
+
+				if path != "" {
+					keyPath = path + "." + key
+				}
+
+				match(keyPath+" (key)", key)
+				walk(keyPath, v[key])
+			}
+		case []any:
+			for i, elem := range v {
+				walk(path+"["+strconv.Itoa(i)+"]", elem)
+			}
+		case string:
+			match(path, v)
+		case json.Number:
+			match(path, v.String())
+		}
+	}
+
+	walk("", v)
+
+	return result
+}
+
+// secretLineLocations returns the lines containing each value, keyed by value
+// name.
+func secretLineLocations(data []byte, values map[string]string) map[string][]string {
+	result := make(map[string][]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+
+	for line := 1; scanner.Scan(); line++ {
+		for name, value := range values {
+			if strings.Contains(scanner.Text(), value) {
+				result[name] = append(result[name], "line "+strconv.Itoa(line))
+			}
+		}
+	}
+
+	return result
+}
+
+// runSecretScanner scans the state file, saved plan, and Terraform CLI log
+// file of the working directory after a TestStep, returning an error if any
+// value registered with the TestCase SecretScanner is found. It is run after
+// failed TestSteps too, as a failed apply can still write values to state.
+func runSecretScanner(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, providers *providerFactories) error {
+	t.Helper()
+
+	if c.SecretScanner == nil {
+		return nil
+	}
+
+	var sources []secretSource
+
+	if state, err := os.ReadFile(wd.StateFilePath()); err == nil {
+		sources = append(sources, secretSource{name: "state file", data: state, json: true})
+	} else if !errors.Is(err, os.ErrNotExist) {
+		logging.HelperResourceError(ctx,
+			"Error reading state file for secret scanning",
+			map[string]interface{}{logging.KeyError: err},
+		)
+		return fmt.Errorf("error reading state file for secret scanning: %w", err)
+	}
+
+	if wd.HasSavedPlan() {
+		var planJSON []byte
+		var planStdout string
+
+		err := runProviderCommand(ctx, t, wd, providers, func() error {
+			plan, err := wd.SavedPlan(ctx)
+
+			if err != nil {
+				return err
+			}
+
+			planJSON, err = json.Marshal(plan)
+
+			if err != nil {
+				return err
+			}
+
+			planStdout, err = wd.SavedPlanRawStdout(ctx)
+
+			return err
+		})
+
+		if err != nil {
+			logging.HelperResourceError(ctx,
+				"Error retrieving saved plan for secret scanning",
+				map[string]interface{}{logging.KeyError: err},
+			)
+			return fmt.Errorf("error retrieving saved plan for secret scanning: %w", err)
+		}
+
+		sources = append(sources,
+			secretSource{name: "saved plan JSON", data: planJSON, json: true},
+			secretSource{name: "saved plan output", data: []byte(planStdout)},
+		)
+	}
+
+	if logPath := wd.LogPath(); logPath != "" {
+		if logs, err := os.ReadFile(logPath); err == nil {
+			sources = append(sources, secretSource{name: "Terraform CLI log " + logPath, data: logs})
+		} else if !errors.Is(err, os.ErrNotExist) {
+			logging.HelperResourceError(ctx,
+				"Error reading Terraform CLI log file for secret scanning",
+				map[string]interface{}{logging.KeyError: err},
+			)
+			return fmt.Errorf("error reading Terraform CLI log file for secret scanning: %w", err)
+		}
+	}
+
+	if err := c.SecretScanner.scan(sources); err != nil {
+		logging.HelperResourceError(ctx,
+			"Secret leak(s) found",
+			map[string]interface{}{logging.KeyError: err},
+		)
+		return fmt.Errorf("secret leak(s) found:\n%w", err)
+	}
+
+	return nil
+}
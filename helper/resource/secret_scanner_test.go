@@ -0,0 +1,132 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestSecretScanner_scan(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		values      map[string]string
+		sources     []secretSource
+		expectedErr *regexp.Regexp
+	}{
+		"no-values": {
+			sources: []secretSource{
+				{name: "state file", data: []byte(`{"password": "hunter2"}`), json: true},
+			},
+		},
+		"not-found": {
+			values: map[string]string{"password": "hunter2"},
+			sources: []secretSource{
+				{name: "state file", data: []byte(`{"password": "other"}`), json: true},
+				{name: "saved plan output", data: []byte("password = (write-only attribute)")},
+			},
+		},
+		"json-path": {
+			values: map[string]string{"password": "hunter2"},
+			sources: []secretSource{
+				{
+					name: "state file",
+					data: []byte(`{"resources": [{"instances": [{"attributes": {"id": "test", "connection": "user:hunter2@host"}}]}]}`),
+					json: true,
+				},
+			},
+			expectedErr: regexp.MustCompile(`^password found in state file at resources\[0\].instances\[0\].attributes.connection$`),
+		},
+		"json-escaped": {
+			values: map[string]string{"password": `pass"word`},
+			sources: []secretSource{
+				{name: "state file", data: []byte(`{"value": "pass\"word"}`), json: true},
+			},
+			expectedErr: regexp.MustCompile(`^password found in state file at value$`),
+		},
+		"json-key": {
+			values: map[string]string{"token": "abc123"},
+			sources: []secretSource{
+				{name: "saved plan JSON", data: []byte(`{"tags": {"abc123": "true"}}`), json: true},
+			},
+			expectedErr: regexp.MustCompile(`^token found in saved plan JSON at tags.abc123 \(key\)$`),
+		},
+		"json-number": {
+			values: map[string]string{"pin": "84629175"},
+			sources: []secretSource{
+				{name: "state file", data: []byte(`{"pin": 84629175}`), json: true},
+			},
+			expectedErr: regexp.MustCompile(`^pin found in state file at pin$`),
+		},
+		"json-invalid": {
+			values: map[string]string{"password": "hunter2"},
+			sources: []secretSource{
+				{name: "state file", data: []byte("{\n\"password\": hunter2"), json: true},
+			},
+			expectedErr: regexp.MustCompile(`^password found in state file at line 2$`),
+		},
+		"lines": {
+			values: map[string]string{"password": "hunter2"},
+			sources: []secretSource{
+				{name: "Terraform CLI log test.log", data: []byte("first\nsecond hunter2\nthird\nhunter2 fourth")},
+			},
+			expectedErr: regexp.MustCompile(`^password found in Terraform CLI log test.log at line 2\npassword found in Terraform CLI log test.log at line 4$`),
+		},
+		"multiple": {
+			values: map[string]string{"password": "hunter2", "token": "abc123"},
+			sources: []secretSource{
+				{name: "state file", data: []byte(`{"a": "abc123", "b": "hunter2"}`), json: true},
+				{name: "saved plan output", data: []byte("abc123")},
+			},
+			expectedErr: regexp.MustCompile(`^password found in state file at b\ntoken found in state file at a\ntoken found in saved plan output at line 1$`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			scanner := &SecretScanner{}
+
+			for name, value := range testCase.values {
+				scanner.Register(name, value)
+			}
+
+			err := scanner.scan(testCase.sources)
+
+			if err != nil {
+				if testCase.expectedErr == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !testCase.expectedErr.MatchString(err.Error()) {
+					t.Fatalf("expected error matching %q, got: %s", testCase.expectedErr, err)
+				}
+
+				return
+			}
+
+			if testCase.expectedErr != nil {
+				t.Fatalf("expected error matching %q, got none", testCase.expectedErr)
+			}
+		})
+	}
+}
+
+func TestSecretScanner_Register(t *testing.T) {
+	t.Parallel()
+
+	var scanner SecretScanner
+
+	scanner.Register("empty", "")
+	scanner.Register("password", "old")
+	scanner.Register("password", "hunter2")
+
+	err := scanner.scan([]secretSource{{name: "output", data: []byte("old hunter2")}})
+
+	if err == nil || err.Error() != "password found in output at line 1" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
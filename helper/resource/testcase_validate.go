@@ -6,6 +6,7 @@ package resource
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/mitchellh/go-testing-interface"
 
@@ -50,6 +51,12 @@ func (c TestCase) hasProviders(_ context.Context) bool {
 //
 //   - No overlapping ExternalProviders and Providers entries
 //   - No overlapping ExternalProviders and ProviderFactories entries
+//   - UpgradeFrom entries have a VersionConstraint and match a
+//     ProviderFactories, ProtoV5ProviderFactories, or
+//     ProtoV6ProviderFactories entry.
+//   - StepTimeout and DestroyTimeout are not negative.
+//   - DestroyRetry MaxAttempts is at least 1.
+//   - CheckDestroyRefresh is not set with StateStore steps.
 //   - TestStep validations performed by the (TestStep).validate() method.
 func (c TestCase) validate(ctx context.Context, t testing.T) error {
 	logging.HelperResourceTrace(ctx, "Validating TestCase")
@@ -74,6 +81,42 @@ func (c TestCase) validate(ctx context.Context, t testing.T) error {
 		}
 	}
 
+	for name, upgradeFrom := range c.UpgradeFrom {
+		if upgradeFrom.VersionConstraint == "" {
+			err := fmt.Errorf("TestCase UpgradeFrom provider %q missing VersionConstraint", name)
+			logging.HelperResourceError(ctx, "TestCase validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		_, inProviderFactories := c.ProviderFactories[name]
+		_, inProtoV5ProviderFactories := c.ProtoV5ProviderFactories[name]
+		_, inProtoV6ProviderFactories := c.ProtoV6ProviderFactories[name]
+
+		if !inProviderFactories && !inProtoV5ProviderFactories && !inProtoV6ProviderFactories {
+			err := fmt.Errorf("TestCase UpgradeFrom provider %q must be set in ProviderFactories, ProtoV5ProviderFactories, or ProtoV6ProviderFactories", name)
+			logging.HelperResourceError(ctx, "TestCase validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+	}
+
+	if c.StepTimeout < 0 || c.DestroyTimeout < 0 {
+		err := fmt.Errorf("TestCase StepTimeout and DestroyTimeout must not be negative")
+		logging.HelperResourceError(ctx, "TestCase validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if c.DestroyRetry != nil && c.DestroyRetry.MaxAttempts < 1 {
+		err := fmt.Errorf("TestCase DestroyRetry must specify MaxAttempts of at least 1")
+		logging.HelperResourceError(ctx, "TestCase validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if c.CheckDestroyRefresh && slices.ContainsFunc(c.Steps, func(step TestStep) bool { return step.StateStore }) {
+		err := fmt.Errorf("TestCase CheckDestroyRefresh must not be specified with StateStore steps")
+		logging.HelperResourceError(ctx, "TestCase validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
 	testCaseHasExternalProviders := c.hasExternalProviders(ctx)
 	testCaseHasProviders := c.hasProviders(ctx)
 
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
@@ -165,6 +166,102 @@ func TestTestCaseValidate(t *testing.T) {
 			},
 			expectedError: fmt.Errorf("TestCase provider \"test\" set in both ExternalProviders and ProviderFactories"),
 		},
+		"upgradefrom-missing-versionconstraint": {
+			testCase: TestCase{
+				ProviderFactories: map[string]func() (*schema.Provider, error){
+					"test": nil, // does not need to be real
+				},
+				UpgradeFrom: map[string]ExternalProvider{
+					"test": {},
+				},
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+			},
+			expectedError: fmt.Errorf("TestCase UpgradeFrom provider \"test\" missing VersionConstraint"),
+		},
+		"upgradefrom-missing-providerfactories": {
+			testCase: TestCase{
+				ProviderFactories: map[string]func() (*schema.Provider, error){
+					"test": nil, // does not need to be real
+				},
+				UpgradeFrom: map[string]ExternalProvider{
+					"other": {
+						VersionConstraint: "1.0.0",
+					},
+				},
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+			},
+			expectedError: fmt.Errorf("TestCase UpgradeFrom provider \"other\" must be set in ProviderFactories, ProtoV5ProviderFactories, or ProtoV6ProviderFactories"),
+		},
+		"upgradefrom-valid": {
+			testCase: TestCase{
+				ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+					"test": nil, // does not need to be real
+				},
+				UpgradeFrom: map[string]ExternalProvider{
+					"test": {
+						VersionConstraint: "1.0.0",
+					},
+				},
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+			},
+		},
+		"steptimeout-negative": {
+			testCase: TestCase{
+				StepTimeout: -time.Second,
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+			},
+			expectedError: fmt.Errorf("TestCase StepTimeout and DestroyTimeout must not be negative"),
+		},
+		"destroytimeout-negative": {
+			testCase: TestCase{
+				DestroyTimeout: -time.Second,
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+			},
+			expectedError: fmt.Errorf("TestCase StepTimeout and DestroyTimeout must not be negative"),
+		},
+		"destroyretry-maxattempts": {
+			testCase: TestCase{
+				DestroyRetry: &DestroyRetry{},
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+			},
+			expectedError: fmt.Errorf("TestCase DestroyRetry must specify MaxAttempts of at least 1"),
+		},
+		"checkdestroyrefresh-statestore": {
+			testCase: TestCase{
+				CheckDestroyRefresh: true,
+				Steps: []TestStep{
+					{
+						Config:     "# not empty",
+						StateStore: true,
+					},
+				},
+			},
+			expectedError: fmt.Errorf("TestCase CheckDestroyRefresh must not be specified with StateStore steps"),
+		},
 		"steps-missing": {
 			testCase:      TestCase{},
 			expectedError: fmt.Errorf("TestCase missing Steps"),
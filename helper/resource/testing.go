@@ -17,6 +17,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/querycheck"
 
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/mitchellh/go-testing-interface"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
@@ -25,7 +26,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+	"github.com/hashicorp/terraform-plugin-testing/ephemeralcheck"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/providerfault"
+	"github.com/hashicorp/terraform-plugin-testing/rpccheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
@@ -302,9 +308,32 @@ type ImportStateCheckFunc func([]*terraform.InstanceState) error
 // generation for ImportState tests.
 type ImportStateIdFunc func(*terraform.State) (string, error)
 
-// ErrorCheckFunc is a function providers can use to handle errors.
+// ErrorCheckFunc is a function providers can use to handle errors. TestStep
+// errors are a *TestStepError, which records the TestStep number, mode,
+// phase, failed Terraform command, and Terraform diagnostics.
 type ErrorCheckFunc func(error) error
 
+// DriftFunc is a function providers can use to modify real infrastructure or
+// the Terraform state outside of Terraform during a Drift TestStep.
+type DriftFunc func(context.Context, DriftRequest) error
+
+// DriftRequest contains the data available to a DriftFunc.
+type DriftRequest struct {
+	// State is the Terraform state prior to the drift.
+	State *tfjson.State
+
+	// StateFilePath is the path to the Terraform state file in the working
+	// directory, which can be modified to simulate drift in the state.
+	StateFilePath string
+
+	// ProviderFactories, ProtoV5ProviderFactories, and
+	// ProtoV6ProviderFactories are the provider factories for the TestStep,
+	// which can be used to create provider instances and their API clients.
+	ProviderFactories        map[string]func() (*schema.Provider, error)
+	ProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
+	ProtoV6ProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
+}
+
 // TestCase is a single acceptance test case used to test the apply/destroy
 // lifecycle of a resource in a specific configuration.
 //
@@ -400,6 +429,34 @@ type TestCase struct {
 	// one under test.
 	ExternalProviders map[string]ExternalProvider
 
+	// UpgradeFrom enables provider upgrade testing. Each key must match a
+	// provider in the TestCase ProviderFactories, ProtoV5ProviderFactories,
+	// or ProtoV6ProviderFactories, and each value is the released provider
+	// version to upgrade from, which is downloaded from the registry during
+	// init. If the ExternalProvider Source is empty, it defaults to the
+	// registry address of the provider under test.
+	//
+	// Each Config mode TestStep is first planned and applied with the
+	// released provider in a separate working directory, starting from a
+	// copy of the current TestCase state. That state is then
+	// copied into the TestCase working directory and planned with the
+	// in-process providers, both without refresh, to verify that the plan
+	// is empty as UpgradeResourceState does not introduce differences, even
+	// if ExpectNonEmptyPlan is set, and with refresh, to verify that the
+	// plan is empty unless ExpectNonEmptyPlan is set. The TestStep then
+	// continues as usual with the in-process providers. The RPCs to the
+	// providers while applying with the released providers are not included
+	// in the TestStep RPCChecks and EphemeralChecks, or counted for the
+	// TestStep ProviderFaults.
+	//
+	// TestStep with PlanOnly, Destroy, ExpectError, ExpectDiagnostics,
+	// Targets, Replace, ConfigDirectory, ConfigFile, or a Config containing
+	// a terraform configuration block are not upgrade tested. Resources
+	// written by the in-process providers with a newer schema version than
+	// the released provider supports cannot be planned by the released
+	// provider.
+	UpgradeFrom map[string]ExternalProvider
+
 	// PreventPostDestroyRefresh can be set to true for cases where data sources
 	// are tested alongside real resources
 	PreventPostDestroyRefresh bool
@@ -408,6 +465,18 @@ type TestCase struct {
 	// to allow the tester to test that the resource is truly gone.
 	CheckDestroy TestCheckFunc
 
+	// CheckDestroyRefresh, if true, verifies that every managed resource was
+	// destroyed without a hand-written CheckDestroy function. A copy of the
+	// pre-destroy state is refreshed with the last applied configuration
+	// after the post-test destroy, using a refresh-only plan, and the test
+	// fails if the providers still read any resource as existing. Providers
+	// must remove resources which are not found from state during refresh,
+	// and data sources in the configuration are also read.
+	//
+	// Requires Terraform 0.15.4 or later, and is not supported with
+	// StateStore steps.
+	CheckDestroyRefresh bool
+
 	// ErrorCheck allows providers the option to handle errors such as skipping
 	// tests based on certain errors.
 	//
@@ -415,8 +484,19 @@ type TestCase struct {
 	// messaging. While in certain scenarios this can also catch testing logic
 	// error messages, those messages are not protected by compatibility
 	// promises.
+	//
+	// TestStep errors are a *TestStepError, which only includes the
+	// diagnostics when ErrorCheckDiagnostics is true or the TestStep
+	// otherwise captures diagnostics.
 	ErrorCheck ErrorCheckFunc
 
+	// ErrorCheckDiagnostics, if true, runs Terraform commands with
+	// machine-readable output, with Terraform 0.15.3 and later, so that the
+	// TestStepError passed to ErrorCheck includes the diagnostics. Error
+	// messages are then rebuilt from the diagnostics, which may differ in
+	// formatting from the human-readable Terraform output.
+	ErrorCheckDiagnostics bool
+
 	// Steps are the apply sequences done within the context of the
 	// same state. Each step can have its own check to verify correctness.
 	Steps []TestStep
@@ -447,6 +527,65 @@ type TestCase struct {
 	// AdditionalCLIOptions allows an intentionally limited set of options to be passed
 	// to the Terraform CLI when executing test steps.
 	AdditionalCLIOptions *AdditionalCLIOptions
+
+	// HTTPRecorder is the acctest.Recorder whose transport is used by the
+	// provider client code under test, if any. The TestStep number is set on
+	// the Recorder before each TestStep, so that recorded HTTP interactions
+	// are keyed by test name and TestStep number, and reset to 0 before the
+	// post-test destroy.
+	HTTPRecorder *acctest.Recorder
+
+	// ProtocolConformance, if enabled, validates the PlanResourceChange,
+	// ApplyResourceChange, ReadResource, and UpgradeResourceState responses
+	// of the ProtoV5ProviderFactories, ProtoV6ProviderFactories, and
+	// ProviderFactories providers against the plugin protocol invariants,
+	// such as:
+	//
+	//   - Planned values match the configuration for attributes which are not
+	//     computed, or which are computed and configured.
+	//   - Applied values match every known planned value, including set
+	//     elements.
+	//   - Applied, read, and upgraded values contain no unknown values.
+	//   - Planned, applied, read, and upgraded values of write-only attributes
+	//     are null.
+	//
+	// Violations are returned to Terraform as an error diagnostic which names
+	// each attribute path with the mismatched values, failing the TestStep
+	// unless it expects an error. Responses which already contain an error
+	// diagnostic are not validated. As in Terraform, planned and applied
+	// values are not compared with the configuration and planned values when
+	// the response sets UnsafeToUseLegacyTypeSystem, such as for providers
+	// using terraform-plugin-sdk.
+	ProtocolConformance bool
+
+	// SecretScanner, if set, fails a TestStep when any of its registered
+	// sensitive values, such as generated passwords or write-only attribute
+	// values, is found in the state file, saved plan, or Terraform CLI log
+	// file after the TestStep. Refer to the SecretScanner documentation for
+	// details.
+	SecretScanner *SecretScanner
+
+	// StepTimeout, if set, is the default Timeout of every TestStep which does
+	// not set its own Timeout.
+	StepTimeout time.Duration
+
+	// DestroyTimeout is the time reserved before the go test -timeout
+	// deadline to run the post-test destroy. TestSteps still running when
+	// only this time remains are cancelled as if their Timeout was reached,
+	// so that the destroy can run instead of the go test timeout panic
+	// leaking resources. Defaults to a quarter of the remaining time at the
+	// start of the TestCase, up to 5 minutes. Not used if the test has no
+	// deadline.
+	DestroyTimeout time.Duration
+
+	// DestroyRetry, if set, retries the post-test destroy after a failure
+	// which its Retryable function classifies as transient. Refer to the
+	// DestroyRetry documentation for details.
+	//
+	// When the post-test destroy or CheckDestroy finally fails and the
+	// TF_ACC_LEAKED_RESOURCES_DIR environment variable is set, a report of
+	// the resources still in state is written to that directory.
+	DestroyRetry *DestroyRetry
 }
 
 // ExternalProvider holds information about third-party providers that should
@@ -610,6 +749,38 @@ type TestStep struct {
 	// Destroy will create a destroy plan if set to true.
 	Destroy bool
 
+	// Targets is a list of resource or module addresses, such as
+	// "examplecloud_thing.test" or "module.example", which are passed to the
+	// Terraform CLI plan and apply commands of the step as -target options,
+	// limiting the changes to those objects and their dependencies.
+	//
+	// After a targeted apply, the follow-up plans which verify there are no
+	// further changes are run without any targets, so every resource in the
+	// configuration must already match its state unless ExpectNonEmptyPlan
+	// is set. Set SkipTargetedPostApplyPlans to skip those plans instead.
+	//
+	// This cannot be set with PlanOnly or in other test modes, such as
+	// ImportState or RefreshState.
+	Targets []string
+
+	// Replace is a list of resource instance addresses, such as
+	// "examplecloud_thing.test" or "examplecloud_thing.test[0]", which are
+	// passed to the Terraform CLI plan and apply commands of the step as
+	// -replace options, forcing them to be replaced even if there are no
+	// configuration changes. Unlike Taint, the replacement is only part of
+	// the plan for this step and state is not modified beforehand.
+	//
+	// This cannot be set with PlanOnly, Destroy, or in other test modes, such
+	// as ImportState or RefreshState.
+	Replace []string
+
+	// SkipTargetedPostApplyPlans can be set to true to skip the follow-up
+	// plans after applying a step with Targets, for configurations which
+	// intentionally contain other resources that are not yet applied. The
+	// ConfigPlanChecks PostApplyPreRefresh and PostApplyPostRefresh checks
+	// cannot be used when this is set.
+	SkipTargetedPostApplyPlans bool
+
 	// ExpectNonEmptyPlan can be set to true for specific types of tests that are
 	// looking to verify that a diff occurs
 	ExpectNonEmptyPlan bool
@@ -624,6 +795,56 @@ type TestStep struct {
 	// promises.
 	ExpectError *regexp.Regexp
 
+	// ExpectDiagnostics allows the construction of test cases that we expect
+	// to fail, with assertions made against the structured warning and error
+	// diagnostics reported by Terraform rather than the error message text.
+	// Each check receives every diagnostic reported while running the
+	// TestStep, including those reported by commands that succeeded.
+	// Custom diagnostic checks can be created by implementing the
+	// [diagcheck.DiagnosticCheck] interface, or by using a DiagnosticCheck
+	// implementation from the provided [diagcheck] package. For example,
+	// [diagcheck.ExpectConditionFailure] verifies that a specific resource
+	// precondition or postcondition failed, rather than a provider error.
+	//
+	// When set, Terraform commands are run with machine-readable (-json)
+	// output, which requires Terraform 0.15.3 or later. Diagnostics from the
+	// init command are only captured with Terraform 1.9.0 or later. The
+	// terraform import command of the ImportCommandWithID ImportStateKind
+	// does not support machine-readable output, so its diagnostics are
+	// parsed from the human-readable output, where the detail may be
+	// wrapped and the source range only includes the line. This cannot be
+	// set with ExpectError.
+	ExpectDiagnostics []diagcheck.DiagnosticCheck
+
+	// ExpectWarnings allows assertions that specific warning diagnostics,
+	// such as attribute deprecations, are reported by Terraform while
+	// running the TestStep. Each DiagnosticMatcher must match at least one
+	// warning diagnostic reported by the plan, apply, refresh, or import
+	// commands. The DiagnosticMatcher Severity field is ignored.
+	//
+	// When set, Terraform commands are run with machine-readable (-json)
+	// output, which requires Terraform 0.15.3 or later. Warnings of the
+	// terraform import command are parsed from its human-readable output,
+	// as described for ExpectDiagnostics.
+	ExpectWarnings []diagcheck.DiagnosticMatcher
+
+	// ExpectNoWarnings, if enabled, fails the TestStep when Terraform
+	// reports any warning diagnostic which is not matched by ExpectWarnings
+	// or AllowedWarnings. Use this with ExpectWarnings to verify that a
+	// warning is reported on exactly the intended attribute.
+	//
+	// When set, Terraform commands are run with machine-readable (-json)
+	// output, which requires Terraform 0.15.3 or later. Warnings of the
+	// terraform import command are parsed from its human-readable output,
+	// as described for ExpectDiagnostics.
+	ExpectNoWarnings bool
+
+	// AllowedWarnings is the allowlist of warning diagnostics which may be
+	// reported without failing the TestStep when ExpectNoWarnings is
+	// enabled, such as warnings from other providers in the configuration.
+	// Unlike ExpectWarnings, the warnings are not required to be reported.
+	AllowedWarnings []diagcheck.DiagnosticMatcher
+
 	// ConfigPlanChecks allows assertions to be made against the plan file at different points of a Config (apply) test using a plan check.
 	// Custom plan checks can be created by implementing the [PlanCheck] interface, or by using a PlanCheck implementation from the provided [plancheck] package
 	//
@@ -642,6 +863,59 @@ type TestStep struct {
 	// Custom state checks can be created by implementing the [statecheck.StateCheck] interface, or by using a StateCheck implementation from the provided [statecheck] package.
 	ConfigStateChecks []statecheck.StateCheck
 
+	// ExpectErrorPlanChecks allows assertions to be made against a new plan of the Config after the step fails with the
+	// error matched by ExpectError or ExpectDiagnostics, such as verifying that a resource which failed during a partial
+	// apply will be replaced. Custom plan checks can be created by implementing the [plancheck.PlanCheck] interface, or by
+	// using a PlanCheck implementation from the provided [plancheck] package.
+	//
+	// ExpectErrorPlanChecks cannot be used when the expected error is raised while planning, as the new plan would
+	// fail with the same error.
+	ExpectErrorPlanChecks ExpectErrorPlanChecks
+
+	// ExpectErrorStateChecks allow assertions to be made against the state saved after the step fails with the error
+	// matched by ExpectError or ExpectDiagnostics, such as verifying that a resource is tainted or that a dependency was
+	// created before the failure. Custom state checks can be created by implementing the [statecheck.StateCheck]
+	// interface, or by using a StateCheck implementation from the provided [statecheck] package.
+	ExpectErrorStateChecks []statecheck.StateCheck
+
+	// RPCChecks allow assertions to be made against the RPCs Terraform called on the in-process provider servers
+	// during the step, across all Terraform commands the step runs, such as verifying that ReadResource was only
+	// called once or that ConfigureProvider received the expected configuration. Providers in ExternalProviders are
+	// not included. Custom RPC checks can be created by implementing the [rpccheck.RPCCheck] interface, or by using
+	// an RPCCheck implementation from the provided [rpccheck] package.
+	RPCChecks []rpccheck.RPCCheck
+
+	// EphemeralChecks allow assertions to be made against the ephemeral resource lifecycle RPCs Terraform called on
+	// the in-process provider servers during the step, across the plan and apply commands the step runs, such as
+	// verifying that an ephemeral resource was opened, renewed, and closed in order, with the expected RenewAt times
+	// and private data, and that its values were not persisted in the saved plan file or state file. Only
+	// supported with Config, ConfigDirectory, or ConfigFile. Providers in ExternalProviders are not included. Custom
+	// ephemeral checks can be created by implementing the [ephemeralcheck.EphemeralCheck] interface, or by using an
+	// EphemeralCheck implementation from the provided [ephemeralcheck] package.
+	EphemeralChecks []ephemeralcheck.EphemeralCheck
+
+	// ProviderFaults inject faults into the RPCs Terraform calls on the in-process provider servers during the
+	// step, such as returning an error diagnostic, adding latency, simulating a provider crash, or dropping the
+	// new state on the Nth ApplyResourceChange call for a resource type, to verify the provider and Terraform
+	// behavior under partial failures. Calls are counted per step, across all Terraform commands the step runs. Faults are not
+	// injected during the post-test destroy. Custom actions can be created by implementing the
+	// [providerfault.Action] interface, or by using an Action implementation from the provided [providerfault]
+	// package.
+	ProviderFaults []providerfault.Fault
+
+	// Retry, if set, retries the step after a failure which its Retryable function classifies as transient, such as
+	// an error caused by an eventually consistent remote API, instead of failing the TestCase. Only supported with
+	// Config, ConfigDirectory, or ConfigFile, and not with ExpectError or ExpectDiagnostics. Refer to the
+	// TestStepRetry documentation for details.
+	Retry *TestStepRetry
+
+	// Timeout, if set, is the maximum duration of the Terraform commands run by the step, which defaults to the
+	// TestCase StepTimeout. When the timeout is reached, the in-flight Terraform command is interrupted, the
+	// StopProvider RPC is called on the in-process provider servers, and the step fails with the Terraform command
+	// error. Steps are also cancelled when only the TestCase DestroyTimeout remains before the go test -timeout
+	// deadline.
+	Timeout time.Duration
+
 	// QueryResultChecks allow assertions to be made against a collection of found resources that were returned by a query using a query check.
 	// Custom query checks can be created by implementing the [querycheck.QueryResultCheck] interface, or by using a QueryResultCheck implementation from the provided [querycheck] package.
 	QueryResultChecks []querycheck.QueryResultCheck
@@ -778,6 +1052,137 @@ type TestStep struct {
 	// with ImportState.
 	RefreshState bool
 
+	//---------------------------------------------------------------
+	// Drift testing
+	//---------------------------------------------------------------
+
+	// Drift, if set, will test that Terraform detects changes made outside
+	// of Terraform. The function is called with the current state and is
+	// expected to modify the real infrastructure or the Terraform state.
+	// Afterwards, a refresh-only plan is created with the prior TestStep
+	// configuration, which must contain at least one resource_drift entry.
+	// The refresh-only plan is not applied.
+	//
+	// Use DriftPlanChecks to make assertions against the resource_drift
+	// entries of the refresh-only plan, such as with the
+	// [plancheck.ExpectResourceDrift] or [plancheck.ExpectKnownDriftValue]
+	// plan checks.
+	//
+	// Drift cannot be the first TestStep, is mutually exclusive with
+	// Config, ImportState, and RefreshState, and requires Terraform 0.15.4
+	// or later.
+	Drift DriftFunc
+
+	// DriftPlanChecks allows assertions to be made against the refresh-only plan of a Drift test using a plan check.
+	// Custom plan checks can be created by implementing the [PlanCheck] interface, or by using a PlanCheck implementation from the provided [plancheck] package
+	//
+	// [PlanCheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/plancheck#PlanCheck
+	// [plancheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/plancheck
+	DriftPlanChecks DriftPlanChecks
+
+	//---------------------------------------------------------------
+	// Disappears testing
+	//---------------------------------------------------------------
+
+	// Disappears, if set, will test that the provider plans to recreate the
+	// resource at the given address, such as "examplecloud_thing.test", when
+	// its remote object is deleted outside of Terraform.
+	//
+	// The resource instance is read from the prior TestStep state and deleted
+	// by calling the ApplyResourceChange RPC of the in-process provider server
+	// with a null planned state and the private data of the instance. The
+	// provider server is first configured with the last ConfigureProvider
+	// request sent by Terraform, then the prior state is upgraded with the
+	// UpgradeResourceState RPC, as Terraform does. The raw state is read
+	// with terraform state pull, so the state cannot be stored by a provider
+	// state store. Afterwards, a plan is created with the prior TestStep
+	// configuration, in which the resource must be planned for Create. The
+	// plan is not applied.
+	//
+	// The resource must be implemented by a provider in the
+	// ProtoV5ProviderFactories or ProtoV6ProviderFactories.
+	//
+	// Disappears cannot be the first TestStep and is mutually exclusive
+	// with Config, ImportState, RefreshState, and Drift.
+	Disappears string
+
+	//---------------------------------------------------------------
+	// Moved testing
+	//---------------------------------------------------------------
+
+	// MovedFrom and MovedTo, if set, will test moving the resource at the
+	// MovedFrom address to the MovedTo address with a moved block, such as
+	// when a resource is renamed or migrated to a different resource type
+	// with the MoveResourceState RPC.
+	//
+	// The moved block is appended to the Config, ConfigDirectory, or
+	// ConfigFile of the TestStep, which is required and must declare the
+	// resource at the MovedTo address instead of the MovedFrom address. The
+	// plan must show the resource at the MovedTo address with a
+	// previous address of MovedFrom and no replacement, and must otherwise
+	// be empty unless ExpectNonEmptyPlan is true. The plan is then applied.
+	//
+	// Use MovedPlanChecks and MovedStateChecks to make assertions against
+	// the plan and the state of the moved resource.
+	//
+	// MovedFrom and MovedTo must be set together, cannot be set in the
+	// first TestStep, and are mutually exclusive with ImportState,
+	// RefreshState, Drift, and Disappears. Moved blocks require Terraform
+	// 1.1.0 or later, and moving to a different resource type requires
+	// Terraform 1.8.0 or later.
+	MovedFrom string
+	MovedTo   string
+
+	// MovedPlanChecks allows assertions to be made against the plan file of a moved block test using a plan check.
+	// Custom plan checks can be created by implementing the [PlanCheck] interface, or by using a PlanCheck implementation from the provided [plancheck] package
+	//
+	// [PlanCheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/plancheck#PlanCheck
+	// [plancheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/plancheck
+	MovedPlanChecks MovedPlanChecks
+
+	// MovedStateChecks allow assertions to be made against the state file after a moved block test is applied using a state check.
+	// Custom state checks can be created by implementing the [statecheck.StateCheck] interface, or by using a StateCheck implementation from the provided [statecheck] package.
+	MovedStateChecks []statecheck.StateCheck
+
+	//---------------------------------------------------------------
+	// Forget testing
+	//---------------------------------------------------------------
+
+	// Forget, if true, will test removing the resource at ResourceName (must
+	// be set), such as "examplecloud_thing.test", from Terraform management
+	// without destroying it, using a removed block with the destroy
+	// lifecycle argument set to false. ResourceName must be a managed
+	// resource in the root module without an instance key, as removed
+	// blocks cannot refer to resource instances.
+	//
+	// The resource block is removed from the Config, ConfigDirectory, or
+	// ConfigFile of the TestStep, or from the prior TestStep configuration
+	// if none is set, and the removed block is appended. The test fails if
+	// that configuration does not declare the resource. The plan must
+	// forget all instances of the resource and not destroy them. The plan
+	// is then applied, after which the resource must no longer be in state.
+	//
+	// The testing framework does not destroy forgotten remote objects, so a
+	// later TestStep or the test itself is responsible for cleaning them up.
+	//
+	// Forget cannot be the first TestStep, is mutually exclusive with
+	// ImportState, RefreshState, Drift, Disappears, and MovedFrom, and
+	// requires Terraform 1.7.0 or later.
+	Forget bool
+
+	// ForgetPlanChecks allows assertions to be made against the plan file of a Forget test using a plan check.
+	// Custom plan checks can be created by implementing the [PlanCheck] interface, or by using a PlanCheck implementation from the provided [plancheck] package
+	//
+	// [PlanCheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/plancheck#PlanCheck
+	// [plancheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/plancheck
+	ForgetPlanChecks ForgetPlanChecks
+
+	// ForgetCheck is called after a Forget test is applied with the state
+	// from before the resource was forgotten. Similar to CheckDestroy, it is
+	// intended to verify the remote objects, except that they are expected
+	// to still exist, such as by reusing existing "exists" TestCheckFunc.
+	ForgetCheck TestCheckFunc
+
 	// ProviderFactories can be specified for the providers that are valid for
 	// this TestStep. When providers are specified at the TestStep level, all
 	// TestStep within a TestCase must declare providers.
@@ -894,6 +1299,15 @@ type ConfigPlanChecks struct {
 	PostApplyPostRefresh []plancheck.PlanCheck
 }
 
+// ExpectErrorPlanChecks defines the different points in a Config TestStep with an expected error when plan checks can be run.
+type ExpectErrorPlanChecks struct {
+	// PostError runs all plan checks in the slice. This occurs after the error is matched by ExpectError or ExpectDiagnostics,
+	// against a new plan of the Config created from the state saved before the error. All errors by plan checks in this slice
+	// are aggregated, reported, and will result in a test failure. The plan uses the same targeting as the TestStep plan,
+	// and fails the test if the expected error is raised while planning.
+	PostError []plancheck.PlanCheck
+}
+
 // ImportPlanChecks defines the different points in an Import TestStep when plan checks can be run.
 type ImportPlanChecks struct {
 	// PreApply runs all plan checks in the slice. This occurs after the plan of an Import test is computed. This slice cannot be populated
@@ -901,6 +1315,20 @@ type ImportPlanChecks struct {
 	PreApply []plancheck.PlanCheck
 }
 
+// MovedPlanChecks defines the different points in a moved block TestStep when plan checks can be run.
+type MovedPlanChecks struct {
+	// PreApply runs all plan checks in the slice. This occurs after the plan of a moved block test is computed. All errors by plan checks in this
+	// slice are aggregated, reported, and will result in a test failure.
+	PreApply []plancheck.PlanCheck
+}
+
+// ForgetPlanChecks defines the different points in a Forget TestStep when plan checks can be run.
+type ForgetPlanChecks struct {
+	// PreApply runs all plan checks in the slice. This occurs after the plan of a Forget test is computed. All errors by plan checks in this
+	// slice are aggregated, reported, and will result in a test failure.
+	PreApply []plancheck.PlanCheck
+}
+
 // RefreshPlanChecks defines the different points in a Refresh TestStep when plan checks can be run.
 type RefreshPlanChecks struct {
 	// PostRefresh runs all plan checks in the slice. This occurs after the refresh of the Refresh test is run.
@@ -908,6 +1336,13 @@ type RefreshPlanChecks struct {
 	PostRefresh []plancheck.PlanCheck
 }
 
+// DriftPlanChecks defines the different points in a Drift TestStep when plan checks can be run.
+type DriftPlanChecks struct {
+	// PostRefresh runs all plan checks in the slice. This occurs after the refresh-only plan of the Drift test is created.
+	// All errors by plan checks in this slice are aggregated, reported, and will result in a test failure.
+	PostRefresh []plancheck.PlanCheck
+}
+
 // ParallelTest performs an acceptance test on a resource, allowing concurrency
 // with other ParallelTest. The number of concurrent tests is controlled by the
 // "go test" command -parallel flag.
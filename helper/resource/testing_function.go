@@ -0,0 +1,659 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/mitchellh/go-testing-interface"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+// FunctionTestCase is a table of calls to a single provider-defined
+// function, which are run by FunctionTest directly against the provider
+// server without the Terraform CLI.
+type FunctionTestCase struct {
+	// ProtoV5ProviderFactories or ProtoV6ProviderFactories contain the
+	// provider which defines the function. Exactly one provider must be set
+	// across both fields.
+	ProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
+	ProtoV6ProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
+
+	// Function is the name of the function under test, without the
+	// provider::<name>:: prefix. Required.
+	Function string
+
+	// Calls are the function calls, which are each run and checked in order.
+	Calls []FunctionCall
+}
+
+// FunctionCall is a single call within a FunctionTestCase. Exactly one of
+// ExpectResult, ExpectUnknown, or ExpectError must be set.
+type FunctionCall struct {
+	// Name is an optional description of the call, which is included in
+	// failure messages.
+	Name string
+
+	// Arguments are the argument values of the call, in parameter order
+	// followed by any variadic arguments. A nil element is a null value and
+	// the UnknownFunctionArgument function returns an unknown value.
+	// Arguments are converted to the parameter type as in Terraform, and
+	// arguments to dynamic parameters have the type Terraform would infer
+	// from an equivalent literal expression, so list and set values are
+	// tuples, and map values are objects.
+	Arguments []config.Variable
+
+	// ExpectResult checks the known function result.
+	ExpectResult knownvalue.Check
+
+	// ExpectUnknown, if true, expects the function result to be unknown or
+	// to contain unknown values.
+	ExpectUnknown bool
+
+	// ExpectError expects the call to fail with an error text matching the
+	// regular expression. This includes function errors returned by the
+	// provider, as well as argument errors which Terraform would raise before
+	// calling the provider, such as the wrong number of arguments, an
+	// argument which cannot be converted to the parameter type, or a null
+	// argument to a parameter which does not allow null values.
+	ExpectError *regexp.Regexp
+
+	// ExpectErrorArgument, if set with ExpectError, expects the error to be
+	// associated with the zero-based argument position.
+	ExpectErrorArgument *int64
+}
+
+// UnknownFunctionArgument returns an unknown FunctionCall argument value.
+//
+// As in Terraform, if the parameter does not allow unknown values, the
+// provider is not called and the function result is unknown.
+func UnknownFunctionArgument() config.Variable {
+	return unknownFunctionArgument{}
+}
+
+var _ config.Variable = unknownFunctionArgument{}
+
+// unknownFunctionArgument is the unknown FunctionCall argument value. It
+// cannot be encoded as JSON.
+type unknownFunctionArgument struct{}
+
+// MarshalJSON always returns an error, as unknown values have no JSON
+// encoding.
+func (unknownFunctionArgument) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("unknown function argument cannot be encoded as JSON")
+}
+
+// FunctionTest runs the calls of the FunctionTestCase. Each call is encoded
+// according to the function definition in the provider schema and sent with
+// the CallFunction RPC, emulating the argument handling of Terraform. Unlike
+// Test, it does not require the TF_ACC environment variable or the Terraform
+// CLI, and the provider is not configured.
+//
+// A failed call is reported with t.Errorf, and the remaining calls are still
+// run.
+func FunctionTest(t testing.T, c FunctionTestCase) {
+	t.Helper()
+
+	ctx := context.Background()
+	ctx = logging.InitTestContext(ctx, t)
+
+	if err := c.validate(); err != nil {
+		logging.HelperResourceError(ctx,
+			"FunctionTest validation error",
+			map[string]interface{}{logging.KeyError: err},
+		)
+		t.Fatalf("FunctionTest validation error: %s", err)
+	}
+
+	logging.HelperResourceDebug(ctx, "Starting FunctionTestCase")
+
+	server, err := c.functionServer(ctx)
+
+	if err != nil {
+		logging.HelperResourceError(ctx,
+			"Error starting provider server",
+			map[string]interface{}{logging.KeyError: err},
+		)
+		t.Fatalf("Error starting provider server: %s", err)
+	}
+
+	for i, call := range c.Calls {
+		callLabel := fmt.Sprintf("Call %d/%d", i+1, len(c.Calls))
+
+		if call.Name != "" {
+			callLabel += fmt.Sprintf(" (%s)", call.Name)
+		}
+
+		logging.HelperResourceDebug(ctx, fmt.Sprintf("Running function %s", callLabel))
+
+		result, funcErr, err := server.call(ctx, call.Arguments)
+
+		if err != nil {
+			logging.HelperResourceError(ctx,
+				"Error calling function",
+				map[string]interface{}{logging.KeyError: err},
+			)
+			t.Errorf("%s error: %s", callLabel, err)
+
+			continue
+		}
+
+		if err := call.check(result, funcErr); err != nil {
+			logging.HelperResourceError(ctx,
+				"Function call check error",
+				map[string]interface{}{logging.KeyError: err},
+			)
+			t.Errorf("%s error: %s", callLabel, err)
+		}
+	}
+
+	logging.HelperResourceDebug(ctx, "Finished FunctionTestCase")
+}
+
+// validate ensures the FunctionTestCase is valid based on the following
+// criteria:
+//
+//   - Exactly one ProtoV5ProviderFactories or ProtoV6ProviderFactories entry.
+//   - Function is set.
+//   - Has at least one FunctionCall.
+//   - Each FunctionCall has exactly one of ExpectResult, ExpectUnknown, or
+//     ExpectError, and ExpectErrorArgument is only set with ExpectError.
+func (c FunctionTestCase) validate() error {
+	if len(c.ProtoV5ProviderFactories)+len(c.ProtoV6ProviderFactories) != 1 {
+		return errors.New("FunctionTestCase must have exactly one provider in ProtoV5ProviderFactories or ProtoV6ProviderFactories")
+	}
+
+	if c.Function == "" {
+		return errors.New("FunctionTestCase missing Function")
+	}
+
+	if len(c.Calls) == 0 {
+		return errors.New("FunctionTestCase missing Calls")
+	}
+
+	for i, call := range c.Calls {
+		var expectations int
+
+		if call.ExpectResult != nil {
+			expectations++
+		}
+
+		if call.ExpectUnknown {
+			expectations++
+		}
+
+		if call.ExpectError != nil {
+			expectations++
+		}
+
+		if expectations != 1 {
+			return fmt.Errorf("FunctionCall %d/%d must have exactly one of ExpectResult, ExpectUnknown, or ExpectError", i+1, len(c.Calls))
+		}
+
+		if call.ExpectErrorArgument != nil && call.ExpectError == nil {
+			return fmt.Errorf("FunctionCall %d/%d ExpectErrorArgument requires ExpectError", i+1, len(c.Calls))
+		}
+	}
+
+	return nil
+}
+
+// check compares the result or error of the call with its expectations.
+func (call FunctionCall) check(result tftypes.Value, funcErr *functionError) error {
+	if call.ExpectError != nil {
+		if funcErr == nil {
+			return fmt.Errorf("expected an error but got none, with result: %s", result)
+		}
+
+		if !call.ExpectError.MatchString(funcErr.text) {
+			return fmt.Errorf("expected an error with pattern, no match on: %s", funcErr)
+		}
+
+		if call.ExpectErrorArgument == nil {
+			return nil
+		}
+
+		if funcErr.argument == nil {
+			return fmt.Errorf("expected an error for argument %d, got an error without an argument: %s", *call.ExpectErrorArgument, funcErr)
+		}
+
+		if *funcErr.argument != *call.ExpectErrorArgument {
+			return fmt.Errorf("expected an error for argument %d, got an error for argument %d: %s", *call.ExpectErrorArgument, *funcErr.argument, funcErr)
+		}
+
+		return nil
+	}
+
+	if funcErr != nil {
+		return funcErr
+	}
+
+	if call.ExpectUnknown {
+		if result.IsFullyKnown() {
+			return fmt.Errorf("expected an unknown result, got: %s", result)
+		}
+
+		return nil
+	}
+
+	if !result.IsFullyKnown() {
+		return fmt.Errorf("expected a known result, got: %s", result)
+	}
+
+	value, err := tftypesValueJSON(result)
+
+	if err != nil {
+		return fmt.Errorf("decoding function result: %w", err)
+	}
+
+	if err := call.ExpectResult.CheckValue(value); err != nil {
+		return fmt.Errorf("function result: %w", err)
+	}
+
+	return nil
+}
+
+// functionError is an error returned by the function, or an argument error
+// raised before the function is called.
+type functionError struct {
+	text     string
+	argument *int64
+}
+
+func (e *functionError) Error() string {
+	if e.argument == nil {
+		return e.text
+	}
+
+	return fmt.Sprintf("argument %d: %s", *e.argument, e.text)
+}
+
+// functionParameter is a protocol version agnostic function parameter.
+type functionParameter struct {
+	name         string
+	typ          tftypes.Type
+	allowNull    bool
+	allowUnknown bool
+}
+
+// functionServer calls a single function of a provider server.
+type functionServer struct {
+	name              string
+	parameters        []functionParameter
+	variadicParameter *functionParameter
+	returnType        tftypes.Type
+
+	callFunction func(context.Context, []*tfprotov6.DynamicValue) (*tfprotov6.DynamicValue, *functionError, error)
+}
+
+// functionServer starts the provider server of the FunctionTestCase and
+// looks up the function definition in the provider schema.
+func (c FunctionTestCase) functionServer(ctx context.Context) (*functionServer, error) {
+	for providerName, factory := range c.ProtoV5ProviderFactories {
+		server, err := factory()
+
+		if err != nil {
+			return nil, fmt.Errorf("creating %s provider server: %w", providerName, err)
+		}
+
+		resp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+		if err != nil {
+			return nil, fmt.Errorf("getting %s provider schema: %w", providerName, err)
+		}
+
+		function, ok := resp.Functions[c.Function]
+
+		if !ok || function == nil {
+			return nil, fmt.Errorf("function %q not found in %s provider schema", c.Function, providerName)
+		}
+
+		result := &functionServer{
+			name: c.Function,
+			callFunction: func(ctx context.Context, args []*tfprotov6.DynamicValue) (*tfprotov6.DynamicValue, *functionError, error) {
+				v5Args := make([]*tfprotov5.DynamicValue, 0, len(args))
+
+				for _, arg := range args {
+					v5Args = append(v5Args, &tfprotov5.DynamicValue{MsgPack: arg.MsgPack})
+				}
+
+				resp, err := server.CallFunction(ctx, &tfprotov5.CallFunctionRequest{
+					Name:      c.Function,
+					Arguments: v5Args,
+				})
+
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if resp.Error != nil {
+					return nil, &functionError{text: resp.Error.Text, argument: resp.Error.FunctionArgument}, nil
+				}
+
+				if resp.Result == nil {
+					return nil, nil, nil
+				}
+
+				return &tfprotov6.DynamicValue{MsgPack: resp.Result.MsgPack, JSON: resp.Result.JSON}, nil, nil
+			},
+		}
+
+		for _, parameter := range function.Parameters {
+			result.parameters = append(result.parameters, functionParameter{
+				name:         parameter.Name,
+				typ:          parameter.Type,
+				allowNull:    parameter.AllowNullValue,
+				allowUnknown: parameter.AllowUnknownValues,
+			})
+		}
+
+		if parameter := function.VariadicParameter; parameter != nil {
+			result.variadicParameter = &functionParameter{
+				name:         parameter.Name,
+				typ:          parameter.Type,
+				allowNull:    parameter.AllowNullValue,
+				allowUnknown: parameter.AllowUnknownValues,
+			}
+		}
+
+		if function.Return != nil {
+			result.returnType = function.Return.Type
+		}
+
+		return result, nil
+	}
+
+	for providerName, factory := range c.ProtoV6ProviderFactories {
+		server, err := factory()
+
+		if err != nil {
+			return nil, fmt.Errorf("creating %s provider server: %w", providerName, err)
+		}
+
+		resp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+		if err != nil {
+			return nil, fmt.Errorf("getting %s provider schema: %w", providerName, err)
+		}
+
+		function, ok := resp.Functions[c.Function]
+
+		if !ok || function == nil {
+			return nil, fmt.Errorf("function %q not found in %s provider schema", c.Function, providerName)
+		}
+
+		result := &functionServer{
+			name: c.Function,
+			callFunction: func(ctx context.Context, args []*tfprotov6.DynamicValue) (*tfprotov6.DynamicValue, *functionError, error) {
+				resp, err := server.CallFunction(ctx, &tfprotov6.CallFunctionRequest{
+					Name:      c.Function,
+					Arguments: args,
+				})
+
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if resp.Error != nil {
+					return nil, &functionError{text: resp.Error.Text, argument: resp.Error.FunctionArgument}, nil
+				}
+
+				return resp.Result, nil, nil
+			},
+		}
+
+		for _, parameter := range function.Parameters {
+			result.parameters = append(result.parameters, functionParameter{
+				name:         parameter.Name,
+				typ:          parameter.Type,
+				allowNull:    parameter.AllowNullValue,
+				allowUnknown: parameter.AllowUnknownValues,
+			})
+		}
+
+		if parameter := function.VariadicParameter; parameter != nil {
+			result.variadicParameter = &functionParameter{
+				name:         parameter.Name,
+				typ:          parameter.Type,
+				allowNull:    parameter.AllowNullValue,
+				allowUnknown: parameter.AllowUnknownValues,
+			}
+		}
+
+		if function.Return != nil {
+			result.returnType = function.Return.Type
+		}
+
+		return result, nil
+	}
+
+	return nil, errors.New("no provider server")
+}
+
+// call encodes the arguments and calls the function. Similar to Terraform,
+// null arguments are checked before unknown arguments, and the provider is
+// not called if an argument is unknown but its parameter does not allow
+// unknown values.
+func (s *functionServer) call(ctx context.Context, arguments []config.Variable) (tftypes.Value, *functionError, error) {
+	if s.returnType == nil {
+		return tftypes.Value{}, nil, fmt.Errorf("function %q has no return type", s.name)
+	}
+
+	if len(arguments) < len(s.parameters) {
+		return tftypes.Value{}, &functionError{
+			text: fmt.Sprintf("Not enough function arguments: function %q expects %d argument(s), missing value for %q.", s.name, len(s.parameters), s.parameters[len(arguments)].name),
+		}, nil
+	}
+
+	if len(arguments) > len(s.parameters) && s.variadicParameter == nil {
+		argument := int64(len(s.parameters))
+
+		return tftypes.Value{}, &functionError{
+			text:     fmt.Sprintf("Too many function arguments: function %q expects only %d argument(s).", s.name, len(s.parameters)),
+			argument: &argument,
+		}, nil
+	}
+
+	values := make([]tftypes.Value, 0, len(arguments))
+	unknown := false
+
+	for i, argument := range arguments {
+		parameter := s.variadicParameter
+
+		if i < len(s.parameters) {
+			parameter = &s.parameters[i]
+		}
+
+		value, err := functionArgumentValue(argument, parameter)
+
+		if err != nil {
+			position := int64(i)
+
+			return tftypes.Value{}, &functionError{
+				text:     fmt.Sprintf("Invalid value for %q parameter: %s.", parameter.name, err),
+				argument: &position,
+			}, nil
+		}
+
+		if !value.IsKnown() && !parameter.allowUnknown {
+			unknown = true
+		}
+
+		values = append(values, value)
+	}
+
+	if unknown {
+		return tftypes.NewValue(s.returnType, tftypes.UnknownValue), nil, nil
+	}
+
+	args := make([]*tfprotov6.DynamicValue, 0, len(values))
+
+	for i, value := range values {
+		parameter := s.variadicParameter
+
+		if i < len(s.parameters) {
+			parameter = &s.parameters[i]
+		}
+
+		arg, err := tfprotov6.NewDynamicValue(parameter.typ, value)
+
+		if err != nil {
+			return tftypes.Value{}, nil, fmt.Errorf("encoding argument %d: %w", i, err)
+		}
+
+		args = append(args, &arg)
+	}
+
+	result, funcErr, err := s.callFunction(ctx, args)
+
+	if err != nil || funcErr != nil {
+		return tftypes.Value{}, funcErr, err
+	}
+
+	if result == nil {
+		return tftypes.Value{}, nil, errors.New("provider returned no function result")
+	}
+
+	value, err := result.Unmarshal(s.returnType)
+
+	if err != nil {
+		return tftypes.Value{}, nil, fmt.Errorf("decoding function result: %w", err)
+	}
+
+	return value, nil, nil
+}
+
+// functionArgumentValue converts an argument to a value of the parameter
+// type, or returns an error if the argument is invalid for the parameter.
+func functionArgumentValue(argument config.Variable, parameter *functionParameter) (tftypes.Value, error) {
+	if argument == nil {
+		if !parameter.allowNull {
+			return tftypes.Value{}, errors.New("argument must not be null")
+		}
+
+		return tftypes.NewValue(parameter.typ, nil), nil
+	}
+
+	if _, ok := argument.(unknownFunctionArgument); ok {
+		return tftypes.NewValue(parameter.typ, tftypes.UnknownValue), nil
+	}
+
+	data, err := argument.MarshalJSON()
+
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	parameterType, err := functionCtyType(parameter.typ)
+
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	impliedType, err := ctyjson.ImpliedType(data)
+
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	value, err := ctyjson.Unmarshal(data, impliedType)
+
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	value, err = convert.Convert(value, parameterType)
+
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	// Dynamic values are encoded with their type, which ValueFromJSON
+	// decodes for dynamic parameter types.
+	data, err = ctyjson.Marshal(value, parameterType)
+
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	return tftypes.ValueFromJSON(data, parameter.typ)
+}
+
+// functionCtyType returns the equivalent cty type of a parameter type, so
+// arguments are converted with the same rules as in Terraform.
+func functionCtyType(typ tftypes.Type) (cty.Type, error) {
+	switch typ := typ.(type) {
+	case tftypes.List:
+		elementType, err := functionCtyType(typ.ElementType)
+
+		return cty.List(elementType), err
+	case tftypes.Set:
+		elementType, err := functionCtyType(typ.ElementType)
+
+		return cty.Set(elementType), err
+	case tftypes.Map:
+		elementType, err := functionCtyType(typ.ElementType)
+
+		return cty.Map(elementType), err
+	case tftypes.Tuple:
+		elementTypes := make([]cty.Type, 0, len(typ.ElementTypes))
+
+		for _, elementType := range typ.ElementTypes {
+			t, err := functionCtyType(elementType)
+
+			if err != nil {
+				return cty.NilType, err
+			}
+
+			elementTypes = append(elementTypes, t)
+		}
+
+		return cty.Tuple(elementTypes), nil
+	case tftypes.Object:
+		attributeTypes := make(map[string]cty.Type, len(typ.AttributeTypes))
+
+		for name, attributeType := range typ.AttributeTypes {
+			t, err := functionCtyType(attributeType)
+
+			if err != nil {
+				return cty.NilType, err
+			}
+
+			attributeTypes[name] = t
+		}
+
+		if len(typ.OptionalAttributes) > 0 {
+			return cty.ObjectWithOptionalAttrs(attributeTypes, slices.Sorted(maps.Keys(typ.OptionalAttributes))), nil
+		}
+
+		return cty.Object(attributeTypes), nil
+	}
+
+	switch {
+	case typ.Is(tftypes.String):
+		return cty.String, nil
+	case typ.Is(tftypes.Number):
+		return cty.Number, nil
+	case typ.Is(tftypes.Bool):
+		return cty.Bool, nil
+	case typ.Is(tftypes.DynamicPseudoType):
+		return cty.DynamicPseudoType, nil
+	}
+
+	return cty.NilType, fmt.Errorf("unsupported parameter type %s", typ)
+}
@@ -0,0 +1,518 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+// functionProviderServer is a provider server which only implements the
+// "join" and "identity" functions.
+type functionProviderServer struct {
+	tfprotov6.ProviderServer
+
+	calls int
+}
+
+func (s *functionProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	return &tfprotov6.GetProviderSchemaResponse{
+		Functions: map[string]*tfprotov6.Function{
+			// join returns the separator-joined non-null string arguments.
+			"join": {
+				Parameters: []*tfprotov6.FunctionParameter{
+					{
+						Name: "separator",
+						Type: tftypes.String,
+					},
+				},
+				VariadicParameter: &tfprotov6.FunctionParameter{
+					Name:           "values",
+					Type:           tftypes.String,
+					AllowNullValue: true,
+				},
+				Return: &tfprotov6.FunctionReturn{
+					Type: tftypes.String,
+				},
+			},
+			// identity returns its argument.
+			"identity": {
+				Parameters: []*tfprotov6.FunctionParameter{
+					{
+						Name:               "value",
+						Type:               tftypes.DynamicPseudoType,
+						AllowNullValue:     true,
+						AllowUnknownValues: true,
+					},
+				},
+				Return: &tfprotov6.FunctionReturn{
+					Type: tftypes.DynamicPseudoType,
+				},
+			},
+		},
+	}, nil
+}
+
+func (s *functionProviderServer) CallFunction(ctx context.Context, req *tfprotov6.CallFunctionRequest) (*tfprotov6.CallFunctionResponse, error) {
+	s.calls++
+
+	if req.Name == "identity" {
+		return &tfprotov6.CallFunctionResponse{Result: req.Arguments[0]}, nil
+	}
+
+	var separator string
+	var values []string
+
+	for i, arg := range req.Arguments {
+		value, err := arg.Unmarshal(tftypes.String)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if value.IsNull() {
+			continue
+		}
+
+		var v string
+
+		_ = value.As(&v)
+
+		if v == "invalid" {
+			position := int64(i)
+
+			return &tfprotov6.CallFunctionResponse{
+				Error: &tfprotov6.FunctionError{
+					Text:             "The value is invalid.",
+					FunctionArgument: &position,
+				},
+			}, nil
+		}
+
+		if i == 0 {
+			separator = v
+		} else {
+			values = append(values, v)
+		}
+	}
+
+	result, err := tfprotov6.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, strings.Join(values, separator)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.CallFunctionResponse{Result: &result}, nil
+}
+
+func TestFunctionTest(t *testing.T) {
+	t.Parallel()
+
+	server := &functionProviderServer{}
+	argument := int64(2)
+
+	FunctionTest(t, FunctionTestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": func() (tfprotov6.ProviderServer, error) {
+				return server, nil
+			},
+		},
+		Function: "join",
+		Calls: []FunctionCall{
+			{
+				Name:         "no-variadic-arguments",
+				Arguments:    []config.Variable{config.StringVariable(",")},
+				ExpectResult: knownvalue.StringExact(""),
+			},
+			{
+				Name: "variadic-arguments",
+				Arguments: []config.Variable{
+					config.StringVariable(","),
+					config.StringVariable("a"),
+					nil,
+					config.StringVariable("b"),
+				},
+				ExpectResult: knownvalue.StringExact("a,b"),
+			},
+			{
+				Name: "unknown",
+				Arguments: []config.Variable{
+					config.StringVariable(","),
+					UnknownFunctionArgument(),
+				},
+				ExpectUnknown: true,
+			},
+			{
+				Name: "function-error",
+				Arguments: []config.Variable{
+					config.StringVariable(","),
+					config.StringVariable("a"),
+					config.StringVariable("invalid"),
+				},
+				ExpectError:         regexp.MustCompile(`The value is invalid.`),
+				ExpectErrorArgument: &argument,
+			},
+			{
+				Name:        "null",
+				Arguments:   []config.Variable{nil},
+				ExpectError: regexp.MustCompile(`Invalid value for "separator" parameter: argument must not be null.`),
+			},
+		},
+	})
+
+	// The unknown and null calls do not reach the provider.
+	if server.calls != 3 {
+		t.Errorf("expected 3 provider calls, got %d", server.calls)
+	}
+}
+
+func TestFunctionTest_ProtoV5(t *testing.T) {
+	t.Parallel()
+
+	FunctionTest(t, FunctionTestCase{
+		ProtoV5ProviderFactories: map[string]func() (tfprotov5.ProviderServer, error){
+			"test": func() (tfprotov5.ProviderServer, error) {
+				return &functionProviderServerV5{}, nil
+			},
+		},
+		Function: "upper",
+		Calls: []FunctionCall{
+			{
+				Arguments:    []config.Variable{config.StringVariable("test")},
+				ExpectResult: knownvalue.StringExact("TEST"),
+			},
+		},
+	})
+}
+
+// functionProviderServerV5 is a protocol version 5 provider server which only
+// implements the "upper" function.
+type functionProviderServerV5 struct {
+	tfprotov5.ProviderServer
+}
+
+func (s *functionProviderServerV5) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	return &tfprotov5.GetProviderSchemaResponse{
+		Functions: map[string]*tfprotov5.Function{
+			"upper": {
+				Parameters: []*tfprotov5.FunctionParameter{
+					{
+						Name: "value",
+						Type: tftypes.String,
+					},
+				},
+				Return: &tfprotov5.FunctionReturn{
+					Type: tftypes.String,
+				},
+			},
+		},
+	}, nil
+}
+
+func (s *functionProviderServerV5) CallFunction(ctx context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
+	value, err := req.Arguments[0].Unmarshal(tftypes.String)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var v string
+
+	_ = value.As(&v)
+
+	result, err := tfprotov5.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, strings.ToUpper(v)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov5.CallFunctionResponse{Result: &result}, nil
+}
+
+func TestFunctionCall_Check(t *testing.T) {
+	t.Parallel()
+
+	argument := int64(0)
+	otherArgument := int64(1)
+
+	testCases := map[string]struct {
+		function    string
+		call        FunctionCall
+		expectedErr *regexp.Regexp
+	}{
+		"dynamic-list": {
+			function: "identity",
+			call: FunctionCall{
+				Arguments: []config.Variable{
+					config.TupleVariable(config.StringVariable("a"), config.IntegerVariable(1)),
+				},
+				ExpectResult: knownvalue.TupleExact([]knownvalue.Check{
+					knownvalue.StringExact("a"),
+					knownvalue.Int64Exact(1),
+				}),
+			},
+		},
+		"dynamic-object": {
+			function: "identity",
+			call: FunctionCall{
+				Arguments: []config.Variable{
+					config.MapVariable(map[string]config.Variable{
+						"enabled": config.BoolVariable(true),
+					}),
+				},
+				ExpectResult: knownvalue.ObjectExact(map[string]knownvalue.Check{
+					"enabled": knownvalue.Bool(true),
+				}),
+			},
+		},
+		"dynamic-null": {
+			function: "identity",
+			call: FunctionCall{
+				Arguments:    []config.Variable{nil},
+				ExpectResult: knownvalue.Null(),
+			},
+		},
+		"dynamic-unknown-allowed": {
+			function: "identity",
+			call: FunctionCall{
+				Arguments:     []config.Variable{UnknownFunctionArgument()},
+				ExpectUnknown: true,
+			},
+		},
+		"result-mismatch": {
+			function: "join",
+			call: FunctionCall{
+				Arguments:    []config.Variable{config.StringVariable("-"), config.StringVariable("a"), config.StringVariable("b")},
+				ExpectResult: knownvalue.StringExact("a,b"),
+			},
+			expectedErr: regexp.MustCompile(`function result: expected value a,b for StringExact check, got: a-b`),
+		},
+		"unexpected-unknown": {
+			function: "join",
+			call: FunctionCall{
+				Arguments:    []config.Variable{UnknownFunctionArgument()},
+				ExpectResult: knownvalue.StringExact(""),
+			},
+			expectedErr: regexp.MustCompile(`expected a known result, got: tftypes.String<unknown>`),
+		},
+		"unexpected-known": {
+			function: "join",
+			call: FunctionCall{
+				Arguments:     []config.Variable{config.StringVariable(",")},
+				ExpectUnknown: true,
+			},
+			expectedErr: regexp.MustCompile(`expected an unknown result, got: tftypes.String<"">`),
+		},
+		"unexpected-error": {
+			function: "join",
+			call: FunctionCall{
+				Arguments:    []config.Variable{config.StringVariable("invalid")},
+				ExpectResult: knownvalue.StringExact(""),
+			},
+			expectedErr: regexp.MustCompile(`argument 0: The value is invalid.`),
+		},
+		"expected-error": {
+			function: "join",
+			call: FunctionCall{
+				Arguments:   []config.Variable{config.StringVariable(",")},
+				ExpectError: regexp.MustCompile(`.`),
+			},
+			expectedErr: regexp.MustCompile(`expected an error but got none, with result: tftypes.String<"">`),
+		},
+		"converted-type": {
+			function: "join",
+			call: FunctionCall{
+				Arguments:    []config.Variable{config.StringVariable(","), config.IntegerVariable(1), config.BoolVariable(true)},
+				ExpectResult: knownvalue.StringExact("1,true"),
+			},
+		},
+		"expected-error-argument": {
+			function: "join",
+			call: FunctionCall{
+				Arguments:           []config.Variable{config.StringVariable("invalid")},
+				ExpectError:         regexp.MustCompile(`invalid`),
+				ExpectErrorArgument: &argument,
+			},
+		},
+		"expected-error-other-argument": {
+			function: "join",
+			call: FunctionCall{
+				Arguments:           []config.Variable{config.StringVariable("invalid")},
+				ExpectError:         regexp.MustCompile(`invalid`),
+				ExpectErrorArgument: &otherArgument,
+			},
+			expectedErr: regexp.MustCompile(`expected an error for argument 1, got an error for argument 0`),
+		},
+		"not-enough-arguments": {
+			function: "join",
+			call: FunctionCall{
+				ExpectError: regexp.MustCompile(`Not enough function arguments: function "join" expects 1 argument\(s\), missing value for "separator".`),
+			},
+		},
+		"too-many-arguments": {
+			function: "identity",
+			call: FunctionCall{
+				Arguments:           []config.Variable{nil, nil},
+				ExpectError:         regexp.MustCompile(`Too many function arguments: function "identity" expects only 1 argument\(s\).`),
+				ExpectErrorArgument: &otherArgument,
+			},
+		},
+		"invalid-type": {
+			function: "join",
+			call: FunctionCall{
+				Arguments:           []config.Variable{config.StringVariable(","), config.ListVariable(config.StringVariable("a"))},
+				ExpectError:         regexp.MustCompile(`Invalid value for "values" parameter`),
+				ExpectErrorArgument: &otherArgument,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server, err := FunctionTestCase{
+				ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+					"test": func() (tfprotov6.ProviderServer, error) {
+						return &functionProviderServer{}, nil
+					},
+				},
+				Function: testCase.function,
+			}.functionServer(context.Background())
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			result, funcErr, err := server.call(context.Background(), testCase.call.Arguments)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			err = testCase.call.check(result, funcErr)
+
+			if err == nil && testCase.expectedErr != nil {
+				t.Fatalf("expected error matching %q, got none", testCase.expectedErr)
+			}
+
+			if err != nil && testCase.expectedErr == nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err != nil && !testCase.expectedErr.MatchString(err.Error()) {
+				t.Errorf("expected error matching %q, got: %s", testCase.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestFunctionTestCaseValidate(t *testing.T) {
+	t.Parallel()
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"test": func() (tfprotov6.ProviderServer, error) {
+			return &functionProviderServer{}, nil
+		},
+	}
+	argument := int64(0)
+
+	testCases := map[string]struct {
+		testCase    FunctionTestCase
+		expectedErr *regexp.Regexp
+	}{
+		"valid": {
+			testCase: FunctionTestCase{
+				ProtoV6ProviderFactories: providerFactories,
+				Function:                 "join",
+				Calls:                    []FunctionCall{{ExpectUnknown: true}},
+			},
+		},
+		"missing-provider": {
+			testCase: FunctionTestCase{
+				Function: "join",
+				Calls:    []FunctionCall{{ExpectUnknown: true}},
+			},
+			expectedErr: regexp.MustCompile(`FunctionTestCase must have exactly one provider`),
+		},
+		"multiple-providers": {
+			testCase: FunctionTestCase{
+				ProtoV5ProviderFactories: map[string]func() (tfprotov5.ProviderServer, error){
+					"other": nil,
+				},
+				ProtoV6ProviderFactories: providerFactories,
+				Function:                 "join",
+				Calls:                    []FunctionCall{{ExpectUnknown: true}},
+			},
+			expectedErr: regexp.MustCompile(`FunctionTestCase must have exactly one provider`),
+		},
+		"missing-function": {
+			testCase: FunctionTestCase{
+				ProtoV6ProviderFactories: providerFactories,
+				Calls:                    []FunctionCall{{ExpectUnknown: true}},
+			},
+			expectedErr: regexp.MustCompile(`FunctionTestCase missing Function`),
+		},
+		"missing-calls": {
+			testCase: FunctionTestCase{
+				ProtoV6ProviderFactories: providerFactories,
+				Function:                 "join",
+			},
+			expectedErr: regexp.MustCompile(`FunctionTestCase missing Calls`),
+		},
+		"missing-expectation": {
+			testCase: FunctionTestCase{
+				ProtoV6ProviderFactories: providerFactories,
+				Function:                 "join",
+				Calls:                    []FunctionCall{{}},
+			},
+			expectedErr: regexp.MustCompile(`FunctionCall 1/1 must have exactly one of ExpectResult, ExpectUnknown, or ExpectError`),
+		},
+		"multiple-expectations": {
+			testCase: FunctionTestCase{
+				ProtoV6ProviderFactories: providerFactories,
+				Function:                 "join",
+				Calls:                    []FunctionCall{{ExpectUnknown: true, ExpectError: regexp.MustCompile(`.`)}},
+			},
+			expectedErr: regexp.MustCompile(`FunctionCall 1/1 must have exactly one of ExpectResult, ExpectUnknown, or ExpectError`),
+		},
+		"error-argument-without-error": {
+			testCase: FunctionTestCase{
+				ProtoV6ProviderFactories: providerFactories,
+				Function:                 "join",
+				Calls:                    []FunctionCall{{ExpectUnknown: true, ExpectErrorArgument: &argument}},
+			},
+			expectedErr: regexp.MustCompile(`FunctionCall 1/1 ExpectErrorArgument requires ExpectError`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.testCase.validate()
+
+			if err == nil && testCase.expectedErr != nil {
+				t.Fatalf("expected error matching %q, got none", testCase.expectedErr)
+			}
+
+			if err != nil && testCase.expectedErr == nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err != nil && !testCase.expectedErr.MatchString(err.Error()) {
+				t.Errorf("expected error matching %q, got: %s", testCase.expectedErr, err)
+			}
+		})
+	}
+}
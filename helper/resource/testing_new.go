@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-version"
@@ -24,12 +25,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
-func runPostTestDestroy(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, providers *providerFactories, statePreDestroy *terraform.State) error {
+func runPostTestDestroy(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, providers *providerFactories, statePreDestroy *terraform.State, refreshWd *plugintest.WorkingDir) error {
 	t.Helper()
 
-	err := runProviderCommand(ctx, t, wd, providers, func() error {
-		return wd.Destroy(ctx)
-	})
+	err := runPostTestDestroyCommand(ctx, t, c, wd, providers)
 	if err != nil {
 		return err
 	}
@@ -45,6 +44,16 @@ func runPostTestDestroy(ctx context.Context, t testing.T, c TestCase, wd *plugin
 		logging.HelperResourceDebug(ctx, "Called TestCase CheckDestroy")
 	}
 
+	if refreshWd != nil {
+		logging.HelperResourceDebug(ctx, "Running TestCase CheckDestroyRefresh")
+
+		if err := testCheckDestroyRefresh(ctx, t, refreshWd, providers); err != nil {
+			return err
+		}
+
+		logging.HelperResourceDebug(ctx, "Finished TestCase CheckDestroyRefresh")
+	}
+
 	return nil
 }
 
@@ -62,18 +71,142 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 		protov6: c.ProtoV6ProviderFactories,
 	}
 
+	cassette, err := newProviderCassette(t)
+
+	if err != nil {
+		logging.HelperResourceError(ctx,
+			"Error creating provider cassette",
+			map[string]interface{}{logging.KeyError: err},
+		)
+		t.Fatalf("Error creating provider cassette: %s", err)
+	}
+
+	if cassette != nil {
+		logging.HelperResourceDebug(ctx, "Using provider cassette", map[string]interface{}{"tf_provider_cassette": cassette.Path(), "tf_provider_cassette_mode": cassette.Mode()})
+
+		// Registered before the post-test destroy, so the destroy RPCs
+		// are also recorded, or replayed before checking for unreplayed
+		// interactions.
+		defer func() {
+			t.Helper()
+
+			if err := cassette.Save(); err != nil {
+				logging.HelperResourceError(ctx,
+					"Error saving provider cassette",
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Error saving provider cassette: %s", err)
+			}
+
+			// Interactions are left unreplayed by earlier failures.
+			if t.Failed() {
+				return
+			}
+
+			if err := cassette.UnreplayedError(); err != nil {
+				logging.HelperResourceError(ctx,
+					"Error replaying provider cassette",
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Error replaying provider cassette: %s", err)
+			}
+		}()
+	}
+
+	// RPCs are only recorded for TestCase with RPCChecks, EphemeralChecks, or
+	// Disappears, and faults are only injected for TestCase with
+	// ProviderFaults, as the provider servers are otherwise not wrapped.
+	var rpcCalls *rpcCallRecorder
+	var ephemeralEvents *ephemeralEventRecorder
+	var faults *providerFaultInjector
+
+	if c.hasRPCChecks() {
+		rpcCalls = &rpcCallRecorder{}
+
+		providers.stepInterceptors = append(providers.stepInterceptors, rpcCalls.Interceptor)
+	}
+
+	if c.hasEphemeralChecks() {
+		ephemeralEvents = &ephemeralEventRecorder{}
+
+		providers.stepInterceptors = append(providers.stepInterceptors, ephemeralEvents.Interceptor)
+	}
+
+	// The ConfigureProvider requests sent by Terraform are replayed to
+	// configure the provider servers which delete Disappears resources.
+	var providerConfigs *providerConfigureRecorder
+
+	if c.hasDisappears() {
+		providerConfigs = &providerConfigureRecorder{}
+
+		providers.interceptors = append(providers.interceptors, providerConfigs.Interceptor)
+	}
+
+	if c.hasProviderFaults() {
+		faults = &providerFaultInjector{}
+
+		providers.stepInterceptors = append(providers.stepInterceptors, faults.Interceptor)
+	}
+
+	// Protocol conformance is validated inside the fault injection, so only
+	// the provider responses are validated.
+	if c.ProtocolConformance {
+		conformance := &protocolConformanceChecker{}
+
+		providers.interceptors = append(providers.interceptors, conformance.Interceptor)
+	}
+
+	// The cassette is innermost, so the RPCChecks see the injected faults
+	// and replayed responses, while only the provider responses are recorded.
+	if cassette != nil {
+		providers.interceptors = append(providers.interceptors, cassette.Interceptor)
+	}
+
 	// If any of the test steps used the StateStore mode and tested an error, make sure we don't execute any more commands with an invalid state store
 	var initializationErrorOccurred bool
 
+	// appliedCfg and appliedCfgVariables are the last applied configuration
+	// and its variables, used by later import and CheckDestroyRefresh.
+	var appliedCfg teststep.Config
+	var appliedCfgVariables config.Variables
+
+	deadlines := newTestDeadlines(t, c, time.Now())
+	cancelStepCommands := func() {}
+
+	// use this to track last step successfully applied
+	// acts as default for import tests
+	var stepNumber int
+
+	// secretScannedStep is the last TestStep scanned by the SecretScanner.
+	var secretScannedStep int
+
 	defer func() {
 		t.Helper()
 
+		cancelStepCommands()
+
+		destroyCtx, cancelDestroyCommands := deadlines.destroyContext(ctx)
+		defer cancelDestroyCommands()
+
+		wd.SetCommandContext(destroyCtx)
+
 		// We can't retrieve the state because the backend/state store isn't fully initialized.
 		if initializationErrorOccurred {
 			wd.Close()
 			return
 		}
 
+		// The last TestStep is scanned here, rather than after the TestStep
+		// loop, so that TestSteps which failed the test are also scanned.
+		// Leaks are reported without stopping the post-test destroy.
+		if stepNumber > secretScannedStep {
+			if err := runSecretScanner(ctx, t, c, wd, providers); err != nil {
+				t.Errorf("Step %d/%d, %s", stepNumber, len(c.Steps), err)
+			}
+		}
+
+		wd.SetCaptureDiagnostics(false)
+
 		var statePreDestroy *terraform.State
 		var err error
 		err = runProviderCommand(ctx, t, wd, providers, func() error {
@@ -92,9 +225,52 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 			return
 		}
 
+		if c.HTTPRecorder != nil {
+			c.HTTPRecorder.SetStep(0)
+		}
+
+		if rpcCalls != nil {
+			rpcCalls.Start(false)
+		}
+
+		if ephemeralEvents != nil {
+			ephemeralEvents.Start(false)
+		}
+
+		if faults != nil {
+			faults.Start(nil)
+		}
+
 		if !stateIsEmpty(statePreDestroy) {
-			err := runPostTestDestroy(ctx, t, c, wd, providers, statePreDestroy)
+			var refreshWd *plugintest.WorkingDir
+
+			if c.CheckDestroyRefresh {
+				refreshWd, err = newCheckDestroyRefreshWorkingDir(ctx, t, helper, wd, appliedCfg, appliedCfgVariables)
+
+				// The post-test destroy still runs without the check.
+				if err != nil {
+					logging.HelperResourceError(ctx,
+						"Error copying pre-destroy state for CheckDestroyRefresh",
+						map[string]interface{}{logging.KeyError: err},
+					)
+					t.Errorf("Error copying pre-destroy state for CheckDestroyRefresh: %s", err)
+				}
+
+				if refreshWd != nil {
+					defer refreshWd.Close()
+				}
+			}
+
+			err := runPostTestDestroy(ctx, t, c, wd, providers, statePreDestroy, refreshWd)
 			if err != nil {
+				if reportErr := writeLeakedResourceReport(ctx, t, wd, providers, err); reportErr != nil {
+					logging.HelperResourceError(ctx,
+						"Error writing leaked resource report",
+						map[string]interface{}{logging.KeyError: reportErr},
+					)
+					t.Errorf("Error writing leaked resource report: %s", reportErr)
+				}
+
 				logging.HelperResourceError(ctx,
 					"Error running post-test destroy, there may be dangling resources",
 					map[string]interface{}{logging.KeyError: err},
@@ -138,18 +314,29 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 		}
 	}
 
-	// use this to track last step successfully applied
-	// acts as default for import tests
-	var appliedCfg teststep.Config
-	var stepNumber int
+	// upgradeWd is the working directory for applying TestStep with the
+	// TestCase UpgradeFrom providers, created on first use.
+	var upgradeWd *plugintest.WorkingDir
 
 	for stepIndex, step := range c.Steps {
 		if stepNumber > 0 {
 			copyWorkingDir(ctx, t, stepNumber, wd)
+
+			secretScannedStep = stepNumber
+
+			if err := runSecretScanner(ctx, t, c, wd, providers); err != nil {
+				t.Fatalf("Step %d/%d, %s", stepNumber, len(c.Steps), err)
+			}
 		}
 
+		cancelStepCommands()
+
 		stepNumber = stepIndex + 1 // 1-based indexing for humans
 
+		if c.HTTPRecorder != nil {
+			c.HTTPRecorder.SetStep(stepNumber)
+		}
+
 		configRequest := teststep.PrepareConfigurationRequest{
 			Directory: step.ConfigDirectory,
 			File:      step.ConfigFile,
@@ -166,6 +353,12 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 
 		logging.HelperResourceDebug(ctx, "Starting TestStep")
 
+		var stepCommandCtx context.Context
+
+		stepCommandCtx, cancelStepCommands = deadlines.stepContext(ctx, t, c, step, stepNumber, time.Now())
+
+		wd.SetCommandContext(stepCommandCtx)
+
 		if step.PreConfig != nil {
 			logging.HelperResourceDebug(ctx, "Calling TestStep PreConfig")
 			step.PreConfig()
@@ -193,6 +386,34 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 			}
 		}
 
+		if rpcCalls != nil {
+			rpcCalls.Start(len(step.RPCChecks) > 0)
+		}
+
+		if ephemeralEvents != nil {
+			ephemeralEvents.Start(len(step.EphemeralChecks) > 0)
+		}
+
+		if faults != nil {
+			faults.Start(step.ProviderFaults)
+		}
+
+		// Diagnostics are only captured for steps which check them or retry,
+		// or when the TestCase ErrorCheck opted in, as the machine-readable output
+		// replaces the human-readable output.
+		wd.SetCaptureDiagnostics(step.capturesDiagnostics(helper) || c.capturesDiagnostics(helper))
+		wd.ClearDiagnostics()
+
+		if step.checksDiagnostics() {
+			if err := diagnosticsPreconditions(helper); err != nil {
+				logging.HelperResourceError(ctx,
+					"TestStep error checking diagnostics preconditions",
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Step %d/%d error checking diagnostics preconditions: %s", stepNumber, len(c.Steps), err)
+			}
+		}
+
 		if cfg != nil && !step.Destroy && len(step.Taint) > 0 {
 			err := testStepTaint(ctx, step, wd)
 
@@ -220,6 +441,9 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 				legacy:  sdkProviderFactories(c.ProviderFactories).merge(step.ProviderFactories),
 				protov5: protov5ProviderFactories(c.ProtoV5ProviderFactories).merge(step.ProtoV5ProviderFactories),
 				protov6: protov6ProviderFactories(c.ProtoV6ProviderFactories).merge(step.ProtoV6ProviderFactories),
+
+				interceptors:     providers.interceptors,
+				stepInterceptors: providers.stepInterceptors,
 			}
 
 			var hasProviderBlock bool
@@ -293,35 +517,7 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 			logging.HelperResourceTrace(ctx, "TestStep is ImportState mode")
 
 			err := testStepNewImportState(ctx, t, helper, wd, step, appliedCfg, providers, stepNumber)
-			if step.ExpectError != nil {
-				logging.HelperResourceDebug(ctx, "Checking TestStep ExpectError")
-				if err == nil {
-					logging.HelperResourceError(ctx,
-						"Error running import: expected an error but got none",
-					)
-					t.Fatalf("Step %d/%d error running import: expected an error but got none", stepNumber, len(c.Steps))
-				}
-				if !step.ExpectError.MatchString(err.Error()) {
-					logging.HelperResourceError(ctx,
-						fmt.Sprintf("Error running import: expected an error with pattern (%s)", step.ExpectError.String()),
-						map[string]interface{}{logging.KeyError: err},
-					)
-					t.Fatalf("Step %d/%d error running import, expected an error with pattern (%s), no match on: %s", stepNumber, len(c.Steps), step.ExpectError.String(), err)
-				}
-			} else {
-				if err != nil && c.ErrorCheck != nil {
-					logging.HelperResourceDebug(ctx, "Calling TestCase ErrorCheck")
-					err = c.ErrorCheck(err)
-					logging.HelperResourceDebug(ctx, "Called TestCase ErrorCheck")
-				}
-				if err != nil {
-					logging.HelperResourceError(ctx,
-						"Error running import",
-						map[string]interface{}{logging.KeyError: err},
-					)
-					t.Fatalf("Step %d/%d error running import: %s", stepNumber, len(c.Steps), err)
-				}
-			}
+			handleStepResult(ctx, t, c, wd, step, stepNumber, TestStepModeImportState, rpcCalls, err)
 
 			logging.HelperResourceDebug(ctx, "Finished TestStep")
 
@@ -332,34 +528,45 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 			logging.HelperResourceTrace(ctx, "TestStep is RefreshState mode")
 
 			err := testStepNewRefreshState(ctx, t, wd, step, providers)
-			if step.ExpectError != nil {
-				logging.HelperResourceDebug(ctx, "Checking TestStep ExpectError")
-				if err == nil {
-					logging.HelperResourceError(ctx,
-						"Error running refresh: expected an error but got none",
-					)
-					t.Fatalf("Step %d/%d error running refresh: expected an error but got none", stepNumber, len(c.Steps))
-				}
-				if !step.ExpectError.MatchString(err.Error()) {
-					logging.HelperResourceError(ctx,
-						fmt.Sprintf("Error running refresh: expected an error with pattern (%s)", step.ExpectError.String()),
-						map[string]interface{}{logging.KeyError: err},
-					)
-					t.Fatalf("Step %d/%d error running refresh, expected an error with pattern (%s), no match on: %s", stepNumber, len(c.Steps), step.ExpectError.String(), err)
-				}
-			} else {
-				if err != nil && c.ErrorCheck != nil {
-					logging.HelperResourceDebug(ctx, "Calling TestCase ErrorCheck")
-					err = c.ErrorCheck(err)
-					logging.HelperResourceDebug(ctx, "Called TestCase ErrorCheck")
-				}
-				if err != nil {
-					logging.HelperResourceError(ctx,
-						"Error running refresh",
-						map[string]interface{}{logging.KeyError: err},
-					)
-					t.Fatalf("Step %d/%d error running refresh: %s", stepNumber, len(c.Steps), err)
-				}
+			handleStepResult(ctx, t, c, wd, step, stepNumber, TestStepModeRefreshState, rpcCalls, err)
+
+			logging.HelperResourceDebug(ctx, "Finished TestStep")
+
+			continue
+		}
+
+		if step.Drift != nil {
+			logging.HelperResourceTrace(ctx, "TestStep is Drift mode")
+
+			err := testStepNewDrift(ctx, t, wd, step, providers)
+			handleStepResult(ctx, t, c, wd, step, stepNumber, TestStepModeDrift, rpcCalls, err)
+
+			logging.HelperResourceDebug(ctx, "Finished TestStep")
+
+			continue
+		}
+
+		if step.Disappears != "" {
+			logging.HelperResourceTrace(ctx, "TestStep is Disappears mode")
+
+			err := testStepNewDisappears(ctx, t, wd, step, providers, providerConfigs)
+			handleStepResult(ctx, t, c, wd, step, stepNumber, TestStepModeDisappears, rpcCalls, err)
+
+			logging.HelperResourceDebug(ctx, "Finished TestStep")
+
+			continue
+		}
+
+		if step.MovedFrom != "" {
+			logging.HelperResourceTrace(ctx, "TestStep is Moved mode")
+
+			movedCfg, err := testStepNewMoved(ctx, t, c, wd, step, providers, stepNumber, helper)
+			handleStepResult(ctx, t, c, wd, step, stepNumber, TestStepModeMovedFrom, rpcCalls, err)
+
+			// Preserve the step config for future test steps to use (import state)
+			if movedCfg != nil {
+				appliedCfg = movedCfg
+				appliedCfgVariables = step.ConfigVariables
 			}
 
 			logging.HelperResourceDebug(ctx, "Finished TestStep")
@@ -367,36 +574,22 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 			continue
 		}
 
+		if step.Forget {
+			logging.HelperResourceTrace(ctx, "TestStep is Forget mode")
+
+			err := testStepNewForget(ctx, t, c, wd, step, appliedCfg, providers, stepNumber, helper)
+			handleStepResult(ctx, t, c, wd, step, stepNumber, TestStepModeForget, rpcCalls, err)
+
+			logging.HelperResourceDebug(ctx, "Finished TestStep")
+
+			continue
+		}
+
 		if step.Query {
 			logging.HelperResourceTrace(ctx, "TestStep is Query mode")
 
 			err := testStepNewQuery(ctx, t, wd, step, providers)
-
-			if step.ExpectError != nil {
-				logging.HelperResourceDebug(ctx, "Checking TestStep ExpectError")
-				if err == nil {
-					logging.HelperResourceError(ctx, "Error running query: expected an error but got none")
-					t.Fatalf("Step %d/%d error running query: expected an error but got none", stepNumber, len(c.Steps))
-				}
-				if !step.ExpectError.MatchString(err.Error()) {
-					logging.HelperResourceError(ctx, fmt.Sprintf("Error running query: expected an error with pattern (%s)", step.ExpectError.String()),
-						map[string]interface{}{logging.KeyError: err},
-					)
-					t.Fatalf("Step %d/%d error running query, expected an error with pattern (%s), no match on: %s", stepNumber, len(c.Steps), step.ExpectError.String(), err)
-				}
-			} else {
-				if err != nil && c.ErrorCheck != nil {
-					logging.HelperResourceDebug(ctx, "Calling TestCase ErrorCheck")
-					err = c.ErrorCheck(err)
-					logging.HelperResourceDebug(ctx, "Called TestCase ErrorCheck")
-				}
-				if err != nil {
-					logging.HelperResourceError(ctx, "Error running query",
-						map[string]interface{}{logging.KeyError: err},
-					)
-					t.Fatalf("Step %d/%d error running query checks: %s", stepNumber, len(c.Steps), err)
-				}
-			}
+			handleStepResult(ctx, t, c, wd, step, stepNumber, TestStepModeQuery, rpcCalls, err)
 
 			logging.HelperResourceDebug(ctx, "Finished TestStep")
 
@@ -409,7 +602,7 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 			err := testStepNewStateStore(ctx, t, wd, step, providers, cfg)
 			if err == nil && step.VerifyStateStoreLock {
 				logging.HelperResourceTrace(ctx, "TestStep is running VerifyStateStoreLock logic")
-				err = testStepVerifyStateStoreLock(ctx, t, step, providers, cfg, helper)
+				err = testStepVerifyStateStoreLock(ctx, t, step, providers, cfg, helper, stepCommandCtx)
 			}
 
 			if err != nil {
@@ -417,32 +610,7 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 				initializationErrorOccurred = true
 			}
 
-			if step.ExpectError != nil {
-				logging.HelperResourceDebug(ctx, "Checking TestStep ExpectError")
-				if err == nil {
-					logging.HelperResourceError(ctx, "Error running state store tests: expected an error but got none")
-					t.Fatalf("Step %d/%d error running state store tests: expected an error but got none", stepNumber, len(c.Steps))
-				}
-
-				if !step.ExpectError.MatchString(err.Error()) {
-					logging.HelperResourceError(ctx, fmt.Sprintf("Error running state store tests: expected an error with pattern (%s)", step.ExpectError.String()),
-						map[string]interface{}{logging.KeyError: err},
-					)
-					t.Fatalf("Step %d/%d error running state store tests, expected an error with pattern (%s), no match on: %s", stepNumber, len(c.Steps), step.ExpectError.String(), err)
-				}
-			} else {
-				if err != nil && c.ErrorCheck != nil {
-					logging.HelperResourceDebug(ctx, "Calling TestCase ErrorCheck")
-					err = c.ErrorCheck(err)
-					logging.HelperResourceDebug(ctx, "Called TestCase ErrorCheck")
-				}
-				if err != nil {
-					logging.HelperResourceError(ctx, "Error running state store tests",
-						map[string]interface{}{logging.KeyError: err},
-					)
-					t.Fatalf("Step %d/%d error running state store tests: %s", stepNumber, len(c.Steps), err)
-				}
-			}
+			handleStepResult(ctx, t, c, wd, step, stepNumber, TestStepModeStateStore, rpcCalls, err)
 
 			logging.HelperResourceDebug(ctx, "Finished TestStep")
 
@@ -452,40 +620,49 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 		if cfg != nil {
 			logging.HelperResourceTrace(ctx, "TestStep is Config mode")
 
-			err := testStepNewConfig(ctx, t, c, wd, step, providers, stepIndex, helper)
-			if step.ExpectError != nil {
-				logging.HelperResourceDebug(ctx, "Checking TestStep ExpectError")
-
-				if err == nil {
-					logging.HelperResourceError(ctx,
-						"Expected an error but got none",
-					)
-					t.Fatalf("Step %d/%d, expected an error but got none", stepNumber, len(c.Steps))
-				}
-				if !step.ExpectError.MatchString(err.Error()) {
-					logging.HelperResourceError(ctx,
-						fmt.Sprintf("Expected an error with pattern (%s)", step.ExpectError.String()),
-						map[string]interface{}{logging.KeyError: err},
-					)
-					t.Fatalf("Step %d/%d, expected an error with pattern, no match on: %s", stepNumber, len(c.Steps), err)
+			if len(c.UpgradeFrom) > 0 {
+				if upgradeWd == nil {
+					upgradeWd = helper.RequireNewWorkingDir(ctx, t, "")
+					defer upgradeWd.Close()
 				}
-			} else {
+
+				upgradeWd.SetCommandContext(stepCommandCtx)
+
+				err := testStepNewUpgradeFrom(ctx, t, c, wd, upgradeWd, step, providers, stepIndex, helper)
+
 				if err != nil && c.ErrorCheck != nil {
 					logging.HelperResourceDebug(ctx, "Calling TestCase ErrorCheck")
 
-					err = c.ErrorCheck(err)
+					err = c.ErrorCheck(newTestStepError(err, stepNumber, TestStepModeConfig, wd))
 
 					logging.HelperResourceDebug(ctx, "Called TestCase ErrorCheck")
 				}
+
 				if err != nil {
 					logging.HelperResourceError(ctx,
-						"Unexpected error",
+						"Error running UpgradeFrom",
 						map[string]interface{}{logging.KeyError: err},
 					)
-					t.Fatalf("Step %d/%d error: %s", stepNumber, len(c.Steps), err)
+					t.Fatalf("Step %d/%d error running UpgradeFrom: %s", stepNumber, len(c.Steps), err)
 				}
 			}
 
+			err := runTestStepWithRetry(ctx, t, c, step, stepNumber, wd,
+				func() error {
+					return testStepNewConfig(ctx, t, c, wd, step, providers, stepIndex, helper)
+				},
+				func() {
+					if rpcCalls != nil {
+						rpcCalls.Start(len(step.RPCChecks) > 0)
+					}
+
+					if ephemeralEvents != nil {
+						ephemeralEvents.Start(len(step.EphemeralChecks) > 0)
+					}
+				},
+			)
+			handleStepError(ctx, t, c, wd, step, stepNumber, TestStepModeConfig, err)
+
 			var hasTerraformBlock bool
 			var hasProviderBlock bool
 
@@ -533,6 +710,57 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 			}.Exec()
 
 			appliedCfg = teststep.Configuration(confRequest)
+			appliedCfgVariables = step.ConfigVariables
+
+			if len(step.ExpectWarnings) > 0 || step.ExpectNoWarnings {
+				logging.HelperResourceDebug(ctx, "Checking TestStep ExpectWarnings and ExpectNoWarnings")
+
+				if err := testStepExpectWarnings(ctx, t, wd, step); err != nil {
+					logging.HelperResourceError(ctx,
+						"Unexpected warnings",
+						map[string]interface{}{logging.KeyError: err},
+					)
+					t.Fatalf("Step %d/%d, unexpected warnings: %s", stepNumber, len(c.Steps), err)
+				}
+			}
+
+			// Reaching this point with ExpectError or ExpectDiagnostics means
+			// the step failed as expected.
+			if (step.ExpectError != nil || len(step.ExpectDiagnostics) > 0) && step.hasExpectErrorChecks() {
+				logging.HelperResourceDebug(ctx, "Checking TestStep ExpectErrorPlanChecks and ExpectErrorStateChecks")
+
+				if err := testStepNewExpectErrorChecks(ctx, t, c, wd, step, providers); err != nil {
+					logging.HelperResourceError(ctx,
+						"Error running post-error checks",
+						map[string]interface{}{logging.KeyError: err},
+					)
+					t.Fatalf("Step %d/%d, error running post-error checks: %s", stepNumber, len(c.Steps), err)
+				}
+			}
+
+			if len(step.RPCChecks) > 0 {
+				logging.HelperResourceDebug(ctx, "Running TestStep RPCChecks")
+
+				if err := runRPCChecks(ctx, t, rpcCalls.Calls(), step.RPCChecks); err != nil {
+					logging.HelperResourceError(ctx,
+						"RPC check(s) failed",
+						map[string]interface{}{logging.KeyError: err},
+					)
+					t.Fatalf("Step %d/%d, RPC check(s) failed:\n%s", stepNumber, len(c.Steps), err)
+				}
+			}
+
+			if len(step.EphemeralChecks) > 0 {
+				logging.HelperResourceDebug(ctx, "Running TestStep EphemeralChecks")
+
+				if err := runEphemeralChecks(ctx, t, wd, ephemeralEvents.Events(), step.EphemeralChecks); err != nil {
+					logging.HelperResourceError(ctx,
+						"Ephemeral check(s) failed",
+						map[string]interface{}{logging.KeyError: err},
+					)
+					t.Fatalf("Step %d/%d, ephemeral check(s) failed:\n%s", stepNumber, len(c.Steps), err)
+				}
+			}
 
 			logging.HelperResourceDebug(ctx, "Finished TestStep")
 
@@ -545,6 +773,131 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 	if stepNumber > 0 {
 		copyWorkingDir(ctx, t, stepNumber, wd)
 	}
+
+	cancelStepCommands()
+}
+
+// testStepModeActions are the descriptions of the TestStep modes used in
+// errors and logs.
+var testStepModeActions = map[TestStepMode]string{
+	TestStepModeConfig:       "config",
+	TestStepModeImportState:  "import",
+	TestStepModeRefreshState: "refresh",
+	TestStepModeDrift:        "drift",
+	TestStepModeDisappears:   "disappears",
+	TestStepModeMovedFrom:    "moved",
+	TestStepModeForget:       "forget",
+	TestStepModeQuery:        "query",
+	TestStepModeStateStore:   "state store tests",
+}
+
+// handleStepResult fails the test if the error of a TestStep, other than a
+// Config mode TestStep, does not match the TestStep ExpectError or
+// ExpectDiagnostics, or else is not cleared by the TestCase ErrorCheck. It
+// then runs the TestStep ExpectWarnings, ExpectNoWarnings, and RPCChecks.
+func handleStepResult(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, step TestStep, stepNumber int, mode TestStepMode, rpcCalls *rpcCallRecorder, err error) {
+	t.Helper()
+
+	handleStepError(ctx, t, c, wd, step, stepNumber, mode, err)
+
+	if len(step.ExpectWarnings) > 0 || step.ExpectNoWarnings {
+		logging.HelperResourceDebug(ctx, "Checking TestStep ExpectWarnings and ExpectNoWarnings")
+
+		if err := testStepExpectWarnings(ctx, t, wd, step); err != nil {
+			logging.HelperResourceError(ctx,
+				"Unexpected warnings",
+				map[string]interface{}{logging.KeyError: err},
+			)
+			t.Fatalf("Step %d/%d, unexpected warnings: %s", stepNumber, len(c.Steps), err)
+		}
+	}
+
+	if len(step.RPCChecks) > 0 {
+		logging.HelperResourceDebug(ctx, "Running TestStep RPCChecks")
+
+		if err := runRPCChecks(ctx, t, rpcCalls.Calls(), step.RPCChecks); err != nil {
+			logging.HelperResourceError(ctx,
+				"RPC check(s) failed",
+				map[string]interface{}{logging.KeyError: err},
+			)
+			t.Fatalf("Step %d/%d, RPC check(s) failed:\n%s", stepNumber, len(c.Steps), err)
+		}
+	}
+}
+
+// handleStepError fails the test if the error of a TestStep does not match
+// the TestStep ExpectError or ExpectDiagnostics, or else is not cleared by the
+// TestCase ErrorCheck. Config mode TestSteps keep their original messages,
+// which existing tests may match against.
+func handleStepError(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, step TestStep, stepNumber int, mode TestStepMode, err error) {
+	t.Helper()
+
+	action := testStepModeActions[mode]
+
+	if step.ExpectError != nil {
+		logging.HelperResourceDebug(ctx, "Checking TestStep ExpectError")
+
+		if err == nil {
+			logging.HelperResourceError(ctx,
+				fmt.Sprintf("Error running %s: expected an error but got none", action),
+			)
+			if mode == TestStepModeConfig {
+				t.Fatalf("Step %d/%d, expected an error but got none", stepNumber, len(c.Steps))
+			}
+
+			t.Fatalf("Step %d/%d error running %s: expected an error but got none", stepNumber, len(c.Steps), action)
+		}
+
+		if !step.ExpectError.MatchString(err.Error()) {
+			logging.HelperResourceError(ctx,
+				fmt.Sprintf("Error running %s: expected an error with pattern (%s)", action, step.ExpectError.String()),
+				map[string]interface{}{logging.KeyError: err},
+			)
+			if mode == TestStepModeConfig {
+				t.Fatalf("Step %d/%d, expected an error with pattern, no match on: %s", stepNumber, len(c.Steps), err)
+			}
+
+			t.Fatalf("Step %d/%d error running %s, expected an error with pattern (%s), no match on: %s", stepNumber, len(c.Steps), action, step.ExpectError.String(), err)
+		}
+
+		return
+	}
+
+	if len(step.ExpectDiagnostics) > 0 {
+		logging.HelperResourceDebug(ctx, "Checking TestStep ExpectDiagnostics")
+
+		if err := testStepExpectDiagnostics(ctx, t, wd, step, err); err != nil {
+			logging.HelperResourceError(ctx,
+				fmt.Sprintf("Error running %s: unexpected diagnostics", action),
+				map[string]interface{}{logging.KeyError: err},
+			)
+			if mode == TestStepModeConfig {
+				t.Fatalf("Step %d/%d, %s", stepNumber, len(c.Steps), err)
+			}
+
+			t.Fatalf("Step %d/%d error running %s: %s", stepNumber, len(c.Steps), action, err)
+		}
+
+		return
+	}
+
+	if err != nil && c.ErrorCheck != nil {
+		logging.HelperResourceDebug(ctx, "Calling TestCase ErrorCheck")
+		err = c.ErrorCheck(newTestStepError(err, stepNumber, mode, wd))
+		logging.HelperResourceDebug(ctx, "Called TestCase ErrorCheck")
+	}
+
+	if err != nil {
+		logging.HelperResourceError(ctx,
+			fmt.Sprintf("Error running %s", action),
+			map[string]interface{}{logging.KeyError: err},
+		)
+		if mode == TestStepModeConfig {
+			t.Fatalf("Step %d/%d error: %s", stepNumber, len(c.Steps), err)
+		}
+
+		t.Fatalf("Step %d/%d error running %s: %s", stepNumber, len(c.Steps), action, err)
+	}
 }
 
 func getState(ctx context.Context, t testing.T, wd *plugintest.WorkingDir) (*tfjson.State, *terraform.State, error) {
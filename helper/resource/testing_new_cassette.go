@@ -0,0 +1,41 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/providercassette"
+)
+
+// defaultProviderCassetteDir is the default directory of provider cassette
+// files, relative to the test package.
+const defaultProviderCassetteDir = "testdata/cassettes"
+
+// newProviderCassette returns the provider cassette for the test, or nil if
+// the TF_ACC_PROVIDER_CASSETTE_MODE environment variable is not set.
+func newProviderCassette(t testing.T) (*providercassette.Cassette, error) {
+	mode := os.Getenv(EnvTfAccProviderCassetteMode)
+
+	if mode == "" {
+		return nil, nil
+	}
+
+	dir := os.Getenv(EnvTfAccProviderCassetteDir)
+
+	if dir == "" {
+		dir = defaultProviderCassetteDir
+	}
+
+	return providercassette.New(providerCassettePath(dir, t.Name()), providercassette.Mode(mode))
+}
+
+// providerCassettePath returns the cassette file path for the test name.
+func providerCassettePath(dir string, testName string) string {
+	return filepath.Join(dir, strings.ReplaceAll(testName, "/", "_")+".json")
+}
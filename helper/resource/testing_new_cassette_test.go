@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func cassetteTestCase(createCalls *int) TestCase {
+	return TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": {
+						CreateFunc: func(_ context.Context, _ resource.CreateRequest, resp *resource.CreateResponse) {
+							*createCalls++
+
+							resp.NewState = tftypes.NewValue(
+								tftypes.Object{
+									AttributeTypes: map[string]tftypes.Type{
+										"id": tftypes.String,
+									},
+								},
+								map[string]tftypes.Value{
+									"id": tftypes.NewValue(tftypes.String, "test"),
+								},
+							)
+						},
+						SchemaResponse: &resource.SchemaResponse{
+							Schema: &tfprotov6.Schema{
+								Block: &tfprotov6.SchemaBlock{
+									Attributes: []*tfprotov6.SchemaAttribute{
+										{
+											Name:     "id",
+											Type:     tftypes.String,
+											Computed: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+		},
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func Test_ProviderCassette_RecordReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Setenv(EnvTfAccProviderCassetteDir, dir)
+	t.Setenv(EnvTfAccProviderCassetteMode, "record")
+
+	var recordCreateCalls int
+
+	UnitTest(t, cassetteTestCase(&recordCreateCalls))
+
+	if recordCreateCalls != 1 {
+		t.Fatalf("expected 1 Create call while recording, got: %d", recordCreateCalls)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "Test_ProviderCassette_RecordReplay.json")); err != nil {
+		t.Fatalf("expected cassette file: %s", err)
+	}
+
+	t.Setenv(EnvTfAccProviderCassetteMode, "replay")
+
+	var replayCreateCalls int
+
+	UnitTest(t, cassetteTestCase(&replayCreateCalls))
+
+	if replayCreateCalls != 0 {
+		t.Errorf("expected no Create calls while replaying, got: %d", replayCreateCalls)
+	}
+}
+
+func TestProviderCassettePath(t *testing.T) {
+	t.Parallel()
+
+	got := providerCassettePath("testdata/cassettes", "TestAccThing_basic/subtest")
+	expected := filepath.Join("testdata", "cassettes", "TestAccThing_basic_subtest.json")
+
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+	"github.com/hashicorp/terraform-plugin-testing/internal/teststep"
+)
+
+// newCheckDestroyRefreshWorkingDir returns a new working directory with the
+// last applied configuration and a copy of the state of the TestCase working
+// directory, which must be created before the post-test destroy. The caller
+// must close the working directory.
+func newCheckDestroyRefreshWorkingDir(ctx context.Context, t testing.T, helper *plugintest.Helper, wd *plugintest.WorkingDir, cfg teststep.Config, vars config.Variables) (*plugintest.WorkingDir, error) {
+	t.Helper()
+
+	if cfg == nil {
+		return nil, errors.New("no configuration was applied")
+	}
+
+	if wd.GetHelper().TerraformVersion().LessThan(driftMinTerraformVersion) {
+		return nil, errors.New("CheckDestroyRefresh requires Terraform 0.15.4 or later")
+	}
+
+	refreshWd := helper.RequireNewWorkingDir(ctx, t, "")
+	refreshWd.SetCommandContext(wd.CommandContext())
+
+	err := refreshWd.SetConfig(ctx, cfg, vars)
+
+	if err == nil {
+		err = refreshWd.CopyState(ctx, wd.StateFilePath())
+	}
+
+	if err != nil {
+		refreshWd.Close()
+
+		return nil, err
+	}
+
+	return refreshWd, nil
+}
+
+// testCheckDestroyRefresh runs a refresh-only plan of the pre-destroy state
+// copied by newCheckDestroyRefreshWorkingDir, returning an error with every
+// managed resource which the providers still read as existing.
+func testCheckDestroyRefresh(ctx context.Context, t testing.T, refreshWd *plugintest.WorkingDir, providers *providerFactories) error {
+	t.Helper()
+
+	err := runProviderCommand(ctx, t, refreshWd, providers, func() error {
+		return refreshWd.Init(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("Error running init: %w", err)
+	}
+
+	err = runProviderCommand(ctx, t, refreshWd, providers, func() error {
+		return refreshWd.CreatePlan(ctx, tfexec.RefreshOnly(true))
+	})
+	if err != nil {
+		return fmt.Errorf("Error running refresh-only plan: %w", err)
+	}
+
+	var plan *tfjson.Plan
+	err = runProviderCommand(ctx, t, refreshWd, providers, func() error {
+		var err error
+		plan, err = refreshWd.SavedPlan(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error retrieving refresh-only plan: %w", err)
+	}
+
+	// The prior state of a refresh-only plan is the refreshed state, which
+	// no longer includes resources the providers read as not found.
+	var result []error
+
+	for _, resource := range leakedResources(plan.PriorState) {
+		if resource.ID != "" {
+			result = append(result, fmt.Errorf("%s (id %q) still exists after destroy", resource.Address, resource.ID))
+
+			continue
+		}
+
+		result = append(result, fmt.Errorf("%s still exists after destroy", resource.Address))
+	}
+
+	return errors.Join(result...)
+}
@@ -111,7 +111,7 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 
 		// Plan!
 		err := runProviderCommand(ctx, t, wd, providers, func() error {
-			var opts []tfexec.PlanOption
+			opts := step.targetPlanOptions()
 			if step.Destroy {
 				opts = append(opts, tfexec.Destroy(true))
 			}
@@ -185,7 +185,7 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 
 			// Create Destroy Plan
 			err = runProviderCommand(ctx, t, wd, providers, func() error {
-				var opts []tfexec.PlanOption
+				opts := step.targetPlanOptions()
 				opts = append(opts, tfexec.Destroy(true))
 
 				return wd.CreatePlan(ctx, opts...)
@@ -199,6 +199,11 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 		err = runProviderCommand(ctx, t, wd, providers, func() error {
 			var opts []tfexec.ApplyOption
 
+			// A saved plan already includes any targets and replacements.
+			if !wd.HasSavedPlan() {
+				opts = append(opts, step.targetApplyOptions()...)
+			}
+
 			if c.AdditionalCLIOptions != nil && c.AdditionalCLIOptions.Apply.AllowDeferral {
 				opts = append(opts, tfexec.AllowDeferral(true))
 			}
@@ -262,6 +267,18 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 		}
 	}
 
+	if len(step.Targets) > 0 && step.SkipTargetedPostApplyPlans {
+		logging.HelperResourceDebug(ctx, "Skipping Terraform CLI plan to check for perpetual differences after targeted apply")
+
+		if step.PostApplyFunc != nil {
+			logging.HelperResourceDebug(ctx, "Calling TestCase PostApplyFunc")
+			step.PostApplyFunc()
+			logging.HelperResourceDebug(ctx, "Called TestCase PostApplyFunc")
+		}
+
+		return nil
+	}
+
 	// Test for perpetual diffs by performing a plan, a refresh, and another plan
 	logging.HelperResourceDebug(ctx, "Running Terraform CLI plan to check for perpetual differences")
 
@@ -484,3 +501,35 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 
 	return nil
 }
+
+// targetPlanOptions returns the Terraform CLI plan command options for the
+// TestStep Targets and Replace addresses.
+func (s TestStep) targetPlanOptions() []tfexec.PlanOption {
+	var opts []tfexec.PlanOption
+
+	for _, address := range s.Targets {
+		opts = append(opts, tfexec.Target(address))
+	}
+
+	for _, address := range s.Replace {
+		opts = append(opts, tfexec.Replace(address))
+	}
+
+	return opts
+}
+
+// targetApplyOptions returns the Terraform CLI apply command options for the
+// TestStep Targets and Replace addresses.
+func (s TestStep) targetApplyOptions() []tfexec.ApplyOption {
+	var opts []tfexec.ApplyOption
+
+	for _, address := range s.Targets {
+		opts = append(opts, tfexec.Target(address))
+	}
+
+	for _, address := range s.Replace {
+		opts = append(opts, tfexec.Replace(address))
+	}
+
+	return opts
+}
@@ -962,3 +962,87 @@ func Test_Destroy_Checks_Avoid_Stale_Plan(t *testing.T) {
 		},
 	})
 }
+
+func Test_Targets_UntargetedPostApplyPlan_Error(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_4_0), // terraform_data
+		},
+		ExternalProviders: map[string]ExternalProvider{
+			"terraform": {Source: "terraform.io/builtin/terraform"},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "terraform_data" "one" {}
+
+				resource "terraform_data" "two" {}`,
+				Targets:     []string{"terraform_data.one"},
+				ExpectError: regexp.MustCompile("After applying this test step, the non-refresh plan was not empty."),
+			},
+		},
+	})
+}
+
+func Test_Targets_SkipTargetedPostApplyPlans(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_4_0), // terraform_data
+		},
+		ExternalProviders: map[string]ExternalProvider{
+			"terraform": {Source: "terraform.io/builtin/terraform"},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "terraform_data" "one" {}
+
+				resource "terraform_data" "two" {}`,
+				Targets:                    []string{"terraform_data.one"},
+				SkipTargetedPostApplyPlans: true,
+				Check: ComposeAggregateTestCheckFunc(
+					TestCheckResourceAttrSet("terraform_data.one", "id"),
+					func(s *terraform.State) error {
+						if _, ok := s.RootModule().Resources["terraform_data.two"]; ok {
+							return errors.New("expected terraform_data.two to not be applied")
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func Test_Replace(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_4_0), // terraform_data
+		},
+		ExternalProviders: map[string]ExternalProvider{
+			"terraform": {Source: "terraform.io/builtin/terraform"},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "terraform_data" "test" {}`,
+			},
+			{
+				Config:  `resource "terraform_data" "test" {}`,
+				Replace: []string{"terraform_data.test"},
+				ConfigPlanChecks: ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("terraform_data.test", plancheck.ResourceActionReplace),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
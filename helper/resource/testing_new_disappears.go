@@ -0,0 +1,556 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+	"github.com/hashicorp/terraform-plugin-testing/internal/providerwrap"
+)
+
+// hasDisappears returns true if any TestStep has Disappears.
+func (c TestCase) hasDisappears() bool {
+	for _, step := range c.Steps {
+		if step.Disappears != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// providerConfigureRecorder records the last ConfigureProvider request sent
+// by Terraform to each in-process provider server, so the request can be
+// replayed to configure the provider server which deletes the Disappears
+// resource.
+type providerConfigureRecorder struct {
+	mu       sync.Mutex
+	requests map[string]any
+}
+
+// Request returns the last *tfprotov5.ConfigureProviderRequest or
+// *tfprotov6.ConfigureProviderRequest to the provider server with the given
+// name, or nil if there was none.
+func (r *providerConfigureRecorder) Request(provider string) any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.requests[provider]
+}
+
+// Interceptor returns a providerwrap.Interceptor which records the
+// ConfigureProvider requests to the provider server with the given name.
+func (r *providerConfigureRecorder) Interceptor(provider string) providerwrap.Interceptor {
+	return func(ctx context.Context, call providerwrap.Call, next providerwrap.Handler) (any, error) {
+		if call.RPC == "ConfigureProvider" {
+			r.mu.Lock()
+
+			if r.requests == nil {
+				r.requests = make(map[string]any)
+			}
+
+			r.requests[provider] = call.Request
+
+			r.mu.Unlock()
+		}
+
+		return next(ctx, call)
+	}
+}
+
+// testStepNewDisappears deletes the remote object of the Disappears resource
+// by calling the in-process provider ApplyResourceChange RPC with a null
+// planned state, then verifies that the next plan recreates the resource.
+func testStepNewDisappears(ctx context.Context, t testing.T, wd *plugintest.WorkingDir, step TestStep, providers *providerFactories, providerConfigs *providerConfigureRecorder) error {
+	t.Helper()
+
+	var state *tfjson.State
+	var err error
+
+	err = runProviderCommand(ctx, t, wd, providers, func() error {
+		state, _, err = getState(ctx, t, wd)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting state: %w", err)
+	}
+
+	if state == nil || state.Values == nil || state.Values.RootModule == nil {
+		return fmt.Errorf("%s - Resource not found in state", step.Disappears)
+	}
+
+	stateResource := disappearsStateResource(state.Values.RootModule, step.Disappears)
+
+	if stateResource == nil {
+		return fmt.Errorf("%s - Resource not found in state", step.Disappears)
+	}
+
+	if stateResource.Mode != tfjson.ManagedResourceMode {
+		return fmt.Errorf("%s - Disappears must refer to a managed resource, got %s resource", step.Disappears, stateResource.Mode)
+	}
+
+	// The private data and schema version of the resource instance are only
+	// in the raw state.
+	var rawState []byte
+	err = runProviderCommand(ctx, t, wd, providers, func() error {
+		var err error
+		rawState, err = wd.RawState(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting raw state: %w", err)
+	}
+
+	instance, err := disappearsRawInstance(rawState, step.Disappears)
+	if err != nil {
+		return fmt.Errorf("Error reading %s from raw state: %w", step.Disappears, err)
+	}
+
+	providerName := disappearsProviderName(stateResource.ProviderName)
+	configureReq := providerConfigs.Request(providerName)
+
+	if configureReq == nil {
+		return fmt.Errorf("Error deleting %s for Disappears: provider %q was not configured by a prior TestStep", step.Disappears, providerName)
+	}
+
+	logging.HelperResourceDebug(ctx, "Calling provider ApplyResourceChange to delete TestStep Disappears resource")
+
+	// The provider server is wrapped with any interceptors, so the RPCs are
+	// also recorded or replayed with provider cassettes.
+	if factory, ok := providers.protov6[providerName]; ok {
+		var server tfprotov6.ProviderServer
+
+		server, err = factory()
+		if err == nil {
+			err = disappearsDelete(ctx, disappearsProtoV6Server{providers.wrapProtoV6(providerName, nil, server)}, instance, configureReq)
+		}
+	} else if factory, ok := providers.protov5[providerName]; ok {
+		var server tfprotov5.ProviderServer
+
+		server, err = factory()
+		if err == nil {
+			err = disappearsDelete(ctx, disappearsProtoV5Server{providers.wrapProtoV5(providerName, nil, server)}, instance, configureReq)
+		}
+	} else {
+		err = fmt.Errorf("provider %q must be defined in ProtoV5ProviderFactories or ProtoV6ProviderFactories", providerName)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error deleting %s for Disappears: %w", step.Disappears, err)
+	}
+
+	logging.HelperResourceDebug(ctx, "Called provider ApplyResourceChange to delete TestStep Disappears resource")
+
+	err = runProviderCommand(ctx, t, wd, providers, func() error {
+		return wd.CreatePlan(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("Error running plan after Disappears: %w", err)
+	}
+
+	var plan *tfjson.Plan
+	err = runProviderCommand(ctx, t, wd, providers, func() error {
+		var err error
+		plan, err = wd.SavedPlan(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error retrieving plan after Disappears: %w", err)
+	}
+
+	// The plan is only used for assertions and must not be applied by a
+	// later TestStep.
+	err = wd.ClearPlan(ctx)
+	if err != nil {
+		return fmt.Errorf("Error clearing plan after Disappears: %w", err)
+	}
+
+	for _, rc := range plan.ResourceChanges {
+		if rc.Address != step.Disappears {
+			continue
+		}
+
+		if rc.Change == nil || !rc.Change.Actions.Create() {
+			var actions tfjson.Actions
+
+			if rc.Change != nil {
+				actions = rc.Change.Actions
+			}
+
+			return fmt.Errorf("Expected %s to be planned for Create after Disappears, got action(s): %v", step.Disappears, actions)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("Expected %s to be planned for Create after Disappears, but it was not found in the plan", step.Disappears)
+}
+
+// disappearsStateResource returns the resource with the given address in the
+// module or its child modules, or nil if it is not found.
+func disappearsStateResource(module *tfjson.StateModule, address string) *tfjson.StateResource {
+	for _, r := range module.Resources {
+		if r.Address == address {
+			return r
+		}
+	}
+
+	for _, childModule := range module.ChildModules {
+		if r := disappearsStateResource(childModule, address); r != nil {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// disappearsProviderName returns the provider factory name of a provider
+// source address from state, such as "registry.terraform.io/hashicorp/test".
+func disappearsProviderName(providerAddress string) string {
+	return providerAddress[strings.LastIndex(providerAddress, "/")+1:]
+}
+
+// disappearsInstance is a resource instance in the raw state.
+type disappearsInstance struct {
+	// TypeName is the resource type.
+	TypeName string
+
+	// SchemaVersion is the resource schema version of the Attributes.
+	SchemaVersion int64
+
+	// Attributes is the JSON state of the resource instance, which must be
+	// upgraded with the provider UpgradeResourceState RPC.
+	Attributes json.RawMessage
+
+	// Private is the provider private data of the resource instance.
+	Private []byte
+}
+
+// disappearsRawState is the subset of the raw state, as written to the state
+// file, used to find a resource instance.
+type disappearsRawState struct {
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey      any             `json:"index_key"`
+			SchemaVersion int64           `json:"schema_version"`
+			Attributes    json.RawMessage `json:"attributes"`
+			Private       []byte          `json:"private"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// disappearsRawInstance returns the resource instance with the given address
+// in the raw state.
+func disappearsRawInstance(rawState []byte, address string) (disappearsInstance, error) {
+	var state disappearsRawState
+
+	if err := json.Unmarshal(rawState, &state); err != nil {
+		return disappearsInstance{}, err
+	}
+
+	for _, resource := range state.Resources {
+		prefix := resource.Type + "." + resource.Name
+
+		if resource.Mode == string(tfjson.DataResourceMode) {
+			prefix = "data." + prefix
+		}
+
+		if resource.Module != "" {
+			prefix = resource.Module + "." + prefix
+		}
+
+		for _, instance := range resource.Instances {
+			instanceAddress := prefix
+
+			switch key := instance.IndexKey.(type) {
+			case float64:
+				instanceAddress += fmt.Sprintf("[%d]", int64(key))
+			case string:
+				instanceAddress += fmt.Sprintf("[%q]", key)
+			}
+
+			if instanceAddress != address {
+				continue
+			}
+
+			return disappearsInstance{
+				TypeName:      resource.Type,
+				SchemaVersion: instance.SchemaVersion,
+				Attributes:    instance.Attributes,
+				Private:       instance.Private,
+			}, nil
+		}
+	}
+
+	return disappearsInstance{}, errors.New("resource instance not found")
+}
+
+// disappearsServer is a protocol version 5 or 6 provider server which deletes
+// the Disappears resource.
+type disappearsServer interface {
+	// ResourceType returns the type of the resource from the provider schema.
+	ResourceType(ctx context.Context, typeName string) (tftypes.Type, error)
+
+	// ConfigureProvider calls the ConfigureProvider RPC with a request
+	// recorded by the providerConfigureRecorder.
+	ConfigureProvider(ctx context.Context, req any) error
+
+	// UpgradeResourceState calls the UpgradeResourceState RPC and returns
+	// the upgraded state.
+	UpgradeResourceState(ctx context.Context, resourceType tftypes.Type, instance disappearsInstance) (tftypes.Value, error)
+
+	// DeleteResource calls the ApplyResourceChange RPC with a null planned
+	// state and configuration.
+	DeleteResource(ctx context.Context, resourceType tftypes.Type, instance disappearsInstance, priorState tftypes.Value) error
+
+	// StopProvider calls the StopProvider RPC.
+	StopProvider(ctx context.Context)
+}
+
+// disappearsDelete deletes the remote object of the resource instance in the
+// same order of RPCs as Terraform, which configures the provider with the
+// same request as Terraform and upgrades the prior state before planning.
+func disappearsDelete(ctx context.Context, server disappearsServer, instance disappearsInstance, configureReq any) error {
+	defer server.StopProvider(ctx)
+
+	resourceType, err := server.ResourceType(ctx, instance.TypeName)
+	if err != nil {
+		return err
+	}
+
+	if err := server.ConfigureProvider(ctx, configureReq); err != nil {
+		return fmt.Errorf("unable to configure provider: %w", err)
+	}
+
+	priorState, err := server.UpgradeResourceState(ctx, resourceType, instance)
+	if err != nil {
+		return fmt.Errorf("unable to upgrade resource state: %w", err)
+	}
+
+	if err := server.DeleteResource(ctx, resourceType, instance, priorState); err != nil {
+		return fmt.Errorf("unable to apply resource change: %w", err)
+	}
+
+	return nil
+}
+
+type disappearsProtoV5Server struct {
+	server tfprotov5.ProviderServer
+}
+
+func (s disappearsProtoV5Server) ResourceType(ctx context.Context, typeName string) (tftypes.Type, error) {
+	resp, err := s.server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err == nil {
+		err = protoV5DiagnosticsError(resp.Diagnostics)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get provider schema: %w", err)
+	}
+
+	schema, ok := resp.ResourceSchemas[typeName]
+	if !ok || schema == nil {
+		return nil, fmt.Errorf("resource type %q not found in provider schema", typeName)
+	}
+
+	return schema.ValueType(), nil
+}
+
+func (s disappearsProtoV5Server) ConfigureProvider(ctx context.Context, req any) error {
+	configureReq, ok := req.(*tfprotov5.ConfigureProviderRequest)
+	if !ok {
+		return fmt.Errorf("unexpected ConfigureProvider request type %T", req)
+	}
+
+	resp, err := s.server.ConfigureProvider(ctx, configureReq)
+	if err != nil {
+		return err
+	}
+
+	return protoV5DiagnosticsError(resp.Diagnostics)
+}
+
+func (s disappearsProtoV5Server) UpgradeResourceState(ctx context.Context, resourceType tftypes.Type, instance disappearsInstance) (tftypes.Value, error) {
+	resp, err := s.server.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: instance.TypeName,
+		Version:  instance.SchemaVersion,
+		RawState: &tfprotov5.RawState{JSON: instance.Attributes},
+	})
+	if err == nil {
+		err = protoV5DiagnosticsError(resp.Diagnostics)
+	}
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	if resp.UpgradedState == nil {
+		return tftypes.NewValue(resourceType, nil), nil
+	}
+
+	return resp.UpgradedState.Unmarshal(resourceType)
+}
+
+func (s disappearsProtoV5Server) DeleteResource(ctx context.Context, resourceType tftypes.Type, instance disappearsInstance, priorStateValue tftypes.Value) error {
+	priorState, err := tfprotov5.NewDynamicValue(resourceType, priorStateValue)
+	if err != nil {
+		return fmt.Errorf("unable to create prior state: %w", err)
+	}
+
+	nullValue, err := tfprotov5.NewDynamicValue(resourceType, tftypes.NewValue(resourceType, nil))
+	if err != nil {
+		return fmt.Errorf("unable to create planned state: %w", err)
+	}
+
+	resp, err := s.server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:       instance.TypeName,
+		PriorState:     &priorState,
+		PlannedState:   &nullValue,
+		Config:         &nullValue,
+		PlannedPrivate: instance.Private,
+	})
+	if err != nil {
+		return err
+	}
+
+	return protoV5DiagnosticsError(resp.Diagnostics)
+}
+
+func (s disappearsProtoV5Server) StopProvider(ctx context.Context) {
+	s.server.StopProvider(ctx, &tfprotov5.StopProviderRequest{}) //nolint:errcheck // best effort
+}
+
+type disappearsProtoV6Server struct {
+	server tfprotov6.ProviderServer
+}
+
+func (s disappearsProtoV6Server) ResourceType(ctx context.Context, typeName string) (tftypes.Type, error) {
+	resp, err := s.server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err == nil {
+		err = protoV6DiagnosticsError(resp.Diagnostics)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get provider schema: %w", err)
+	}
+
+	schema, ok := resp.ResourceSchemas[typeName]
+	if !ok || schema == nil {
+		return nil, fmt.Errorf("resource type %q not found in provider schema", typeName)
+	}
+
+	return schema.ValueType(), nil
+}
+
+func (s disappearsProtoV6Server) ConfigureProvider(ctx context.Context, req any) error {
+	configureReq, ok := req.(*tfprotov6.ConfigureProviderRequest)
+	if !ok {
+		return fmt.Errorf("unexpected ConfigureProvider request type %T", req)
+	}
+
+	resp, err := s.server.ConfigureProvider(ctx, configureReq)
+	if err != nil {
+		return err
+	}
+
+	return protoV6DiagnosticsError(resp.Diagnostics)
+}
+
+func (s disappearsProtoV6Server) UpgradeResourceState(ctx context.Context, resourceType tftypes.Type, instance disappearsInstance) (tftypes.Value, error) {
+	resp, err := s.server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: instance.TypeName,
+		Version:  instance.SchemaVersion,
+		RawState: &tfprotov6.RawState{JSON: instance.Attributes},
+	})
+	if err == nil {
+		err = protoV6DiagnosticsError(resp.Diagnostics)
+	}
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	if resp.UpgradedState == nil {
+		return tftypes.NewValue(resourceType, nil), nil
+	}
+
+	return resp.UpgradedState.Unmarshal(resourceType)
+}
+
+func (s disappearsProtoV6Server) DeleteResource(ctx context.Context, resourceType tftypes.Type, instance disappearsInstance, priorStateValue tftypes.Value) error {
+	priorState, err := tfprotov6.NewDynamicValue(resourceType, priorStateValue)
+	if err != nil {
+		return fmt.Errorf("unable to create prior state: %w", err)
+	}
+
+	nullValue, err := tfprotov6.NewDynamicValue(resourceType, tftypes.NewValue(resourceType, nil))
+	if err != nil {
+		return fmt.Errorf("unable to create planned state: %w", err)
+	}
+
+	resp, err := s.server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       instance.TypeName,
+		PriorState:     &priorState,
+		PlannedState:   &nullValue,
+		Config:         &nullValue,
+		PlannedPrivate: instance.Private,
+	})
+	if err != nil {
+		return err
+	}
+
+	return protoV6DiagnosticsError(resp.Diagnostics)
+}
+
+func (s disappearsProtoV6Server) StopProvider(ctx context.Context) {
+	s.server.StopProvider(ctx, &tfprotov6.StopProviderRequest{}) //nolint:errcheck // best effort
+}
+
+// protoV5DiagnosticsError returns an error combining the error diagnostics, or
+// nil if there are none.
+func protoV5DiagnosticsError(diags []*tfprotov5.Diagnostic) error {
+	var result []error
+
+	for _, diag := range diags {
+		if diag == nil || diag.Severity != tfprotov5.DiagnosticSeverityError {
+			continue
+		}
+
+		result = append(result, fmt.Errorf("%s: %s", diag.Summary, diag.Detail))
+	}
+
+	return errors.Join(result...)
+}
+
+// protoV6DiagnosticsError returns an error combining the error diagnostics, or
+// nil if there are none.
+func protoV6DiagnosticsError(diags []*tfprotov6.Diagnostic) error {
+	var result []error
+
+	for _, diag := range diags {
+		if diag == nil || diag.Severity != tfprotov6.DiagnosticSeverityError {
+			continue
+		}
+
+		result = append(result, fmt.Errorf("%s: %s", diag.Summary, diag.Detail))
+	}
+
+	return errors.Join(result...)
+}
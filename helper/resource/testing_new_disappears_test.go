@@ -0,0 +1,443 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/providerwrap"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func Test_Disappears(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: disappearsTestResourceState(),
+	}
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": disappearsTestResource(readResponse, true),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+			{
+				Disappears: "test_resource.test",
+			},
+		},
+	})
+}
+
+func Test_Disappears_NotRecreated(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: disappearsTestResourceState(),
+	}
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					// Delete does not remove the remote object, so Read
+					// continues to return it.
+					"test_resource": disappearsTestResource(readResponse, false),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+			{
+				Disappears:  "test_resource.test",
+				ExpectError: regexp.MustCompile(`Expected test_resource.test to be planned for Create after Disappears`),
+			},
+		},
+	})
+}
+
+func Test_Disappears_ResourceNotFound(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: disappearsTestResourceState(),
+	}
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": disappearsTestResource(readResponse, true),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+			{
+				Disappears:  "test_resource.other",
+				ExpectError: regexp.MustCompile(`test_resource.other - Resource not found in state`),
+			},
+		},
+	})
+}
+
+func TestDisappearsDelete(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: disappearsTestResourceState(),
+	}
+
+	server, err := providerserver.NewProviderServer(testprovider.Provider{
+		Resources: map[string]testprovider.Resource{
+			"test_resource": disappearsTestResource(readResponse, true),
+		},
+	})()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var rpcs []string
+	var applyReq *tfprotov6.ApplyResourceChangeRequest
+
+	server = providerwrap.ProtoV6ProviderServer(server, func(ctx context.Context, call providerwrap.Call, next providerwrap.Handler) (any, error) {
+		rpcs = append(rpcs, call.RPC)
+
+		if req, ok := call.Request.(*tfprotov6.ApplyResourceChangeRequest); ok {
+			applyReq = req
+		}
+
+		return next(ctx, call)
+	})
+
+	config, err := tfprotov6.NewDynamicValue(tftypes.Object{}, tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{}))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	configureReq := &tfprotov6.ConfigureProviderRequest{
+		Config:           &config,
+		TerraformVersion: "1.0.0",
+	}
+
+	instance := disappearsInstance{
+		TypeName:   "test_resource",
+		Attributes: []byte(`{"id":"test"}`),
+		Private:    []byte(`{"key":"value"}`),
+	}
+
+	err = disappearsDelete(context.Background(), disappearsProtoV6Server{server}, instance, configureReq)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !readResponse.NewState.IsNull() {
+		t.Errorf("expected resource to be deleted, got state: %s", readResponse.NewState)
+	}
+
+	expectedRPCs := []string{"GetProviderSchema", "ConfigureProvider", "UpgradeResourceState", "ApplyResourceChange", "StopProvider"}
+
+	if diff := cmp.Diff(expectedRPCs, rpcs); diff != "" {
+		t.Errorf("unexpected RPCs: %s", diff)
+	}
+
+	if applyReq == nil {
+		t.Fatal("expected ApplyResourceChange request, got none")
+	}
+
+	if diff := cmp.Diff(instance.Private, applyReq.PlannedPrivate); diff != "" {
+		t.Errorf("unexpected PlannedPrivate: %s", diff)
+	}
+}
+
+func TestDisappearsDelete_UnknownResourceType(t *testing.T) {
+	t.Parallel()
+
+	server, err := providerserver.NewProviderServer(testprovider.Provider{
+		Resources: map[string]testprovider.Resource{
+			"test_resource": disappearsTestResource(&resource.ReadResponse{}, true),
+		},
+	})()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	instance := disappearsInstance{
+		TypeName:   "test_other",
+		Attributes: []byte(`{"id":"test"}`),
+	}
+
+	err = disappearsDelete(context.Background(), disappearsProtoV6Server{server}, instance, &tfprotov6.ConfigureProviderRequest{})
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	expected := `resource type "test_other" not found in provider schema`
+
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err)
+	}
+}
+
+func TestDisappearsDelete_ConfigureProviderRequestType(t *testing.T) {
+	t.Parallel()
+
+	server, err := providerserver.NewProviderServer(testprovider.Provider{
+		Resources: map[string]testprovider.Resource{
+			"test_resource": disappearsTestResource(&resource.ReadResponse{}, true),
+		},
+	})()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	instance := disappearsInstance{
+		TypeName:   "test_resource",
+		Attributes: []byte(`{"id":"test"}`),
+	}
+
+	err = disappearsDelete(context.Background(), disappearsProtoV6Server{server}, instance, &tfprotov5.ConfigureProviderRequest{})
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	expected := `unable to configure provider: unexpected ConfigureProvider request type *tfprotov5.ConfigureProviderRequest`
+
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err)
+	}
+}
+
+func TestDisappearsRawInstance(t *testing.T) {
+	t.Parallel()
+
+	rawState := []byte(`{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "test_resource",
+      "name": "test",
+      "provider": "provider[\"registry.terraform.io/hashicorp/test\"]",
+      "instances": [
+        {
+          "schema_version": 1,
+          "attributes": {"id": "single"},
+          "private": "eyJrZXkiOiJ2YWx1ZSJ9"
+        }
+      ]
+    },
+    {
+      "mode": "data",
+      "type": "test_resource",
+      "name": "test",
+      "provider": "provider[\"registry.terraform.io/hashicorp/test\"]",
+      "instances": [
+        {
+          "schema_version": 0,
+          "attributes": {"id": "data"}
+        }
+      ]
+    },
+    {
+      "module": "module.child[\"a\"]",
+      "mode": "managed",
+      "type": "test_resource",
+      "name": "counted",
+      "provider": "provider[\"registry.terraform.io/hashicorp/test\"]",
+      "instances": [
+        {
+          "index_key": 0,
+          "schema_version": 0,
+          "attributes": {"id": "zero"}
+        },
+        {
+          "index_key": 1,
+          "schema_version": 0,
+          "attributes": {"id": "one"}
+        }
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "test_resource",
+      "name": "each",
+      "provider": "provider[\"registry.terraform.io/hashicorp/test\"]",
+      "instances": [
+        {
+          "index_key": "key",
+          "schema_version": 0,
+          "attributes": {"id": "key"}
+        }
+      ]
+    }
+  ]
+}`)
+
+	testCases := map[string]struct {
+		address       string
+		expected      disappearsInstance
+		expectedError string
+	}{
+		"single": {
+			address: "test_resource.test",
+			expected: disappearsInstance{
+				TypeName:      "test_resource",
+				SchemaVersion: 1,
+				Attributes:    []byte(`{"id": "single"}`),
+				Private:       []byte(`{"key":"value"}`),
+			},
+		},
+		"data": {
+			address: "data.test_resource.test",
+			expected: disappearsInstance{
+				TypeName:   "test_resource",
+				Attributes: []byte(`{"id": "data"}`),
+			},
+		},
+		"module-count": {
+			address: `module.child["a"].test_resource.counted[1]`,
+			expected: disappearsInstance{
+				TypeName:   "test_resource",
+				Attributes: []byte(`{"id": "one"}`),
+			},
+		},
+		"for-each": {
+			address: `test_resource.each["key"]`,
+			expected: disappearsInstance{
+				TypeName:   "test_resource",
+				Attributes: []byte(`{"id": "key"}`),
+			},
+		},
+		"not-found": {
+			address:       "test_resource.other",
+			expectedError: "resource instance not found",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := disappearsRawInstance(rawState, testCase.address)
+
+			if testCase.expectedError != "" {
+				if err == nil || err.Error() != testCase.expectedError {
+					t.Fatalf("expected error %q, got: %v", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDisappearsProviderName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"registry.terraform.io/hashicorp/test": "test",
+		"example.com/namespace/test":           "test",
+		"test":                                 "test",
+	}
+
+	for providerAddress, expected := range testCases {
+		t.Run(providerAddress, func(t *testing.T) {
+			t.Parallel()
+
+			got := disappearsProviderName(providerAddress)
+
+			if got != expected {
+				t.Errorf("expected %q, got %q", expected, got)
+			}
+		})
+	}
+}
+
+// disappearsTestResource returns a resource whose remote object is
+// represented by the given ReadResponse. If deletes is true, deleting the
+// resource removes the remote object.
+func disappearsTestResource(readResponse *resource.ReadResponse, deletes bool) testprovider.Resource {
+	return testprovider.Resource{
+		CreateResponse: &resource.CreateResponse{
+			NewState: disappearsTestResourceState(),
+		},
+		DeleteFunc: func(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+			if deletes {
+				readResponse.NewState = tftypes.NewValue(disappearsTestResourceState().Type(), nil)
+			}
+		},
+		ReadResponse: readResponse,
+		SchemaResponse: &resource.SchemaResponse{
+			Schema: &tfprotov6.Schema{
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "id",
+							Type:     tftypes.String,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func disappearsTestResourceState() tftypes.Value {
+	return tftypes.NewValue(
+		tftypes.Object{
+			AttributeTypes: map[string]tftypes.Type{
+				"id": tftypes.String,
+			},
+		},
+		map[string]tftypes.Value{
+			"id": tftypes.NewValue(tftypes.String, "test"),
+		},
+	)
+}
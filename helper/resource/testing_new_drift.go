@@ -0,0 +1,100 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-exec/tfexec"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+// driftMinTerraformVersion is the minimum Terraform CLI version which
+// supports refresh-only plans.
+var driftMinTerraformVersion = version.Must(version.NewVersion("0.15.4"))
+
+func testStepNewDrift(ctx context.Context, t testing.T, wd *plugintest.WorkingDir, step TestStep, providers *providerFactories) error {
+	t.Helper()
+
+	if wd.GetHelper().TerraformVersion().LessThan(driftMinTerraformVersion) {
+		return fmt.Errorf(
+			`Drift steps require Terraform 0.15.4 or later. Either ` +
+				`upgrade the Terraform version running the test or add a ` + "`TerraformVersionChecks`" + ` to ` +
+				`the test case to skip this test.` + "\n\n" +
+				`https://developer.hashicorp.com/terraform/plugin/testing/acceptance-tests/tfversion-checks#skip-version-checks`)
+	}
+
+	var state *tfjson.State
+	var err error
+
+	err = runProviderCommand(ctx, t, wd, providers, func() error {
+		state, _, err = getState(ctx, t, wd)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting state: %w", err)
+	}
+
+	logging.HelperResourceDebug(ctx, "Calling TestStep Drift")
+
+	err = step.Drift(ctx, DriftRequest{
+		State:                    state,
+		StateFilePath:            wd.StateFilePath(),
+		ProviderFactories:        providers.legacy,
+		ProtoV5ProviderFactories: providers.protov5,
+		ProtoV6ProviderFactories: providers.protov6,
+	})
+	if err != nil {
+		return fmt.Errorf("Error calling Drift: %w", err)
+	}
+
+	logging.HelperResourceDebug(ctx, "Called TestStep Drift")
+
+	err = runProviderCommand(ctx, t, wd, providers, func() error {
+		return wd.CreatePlan(ctx, tfexec.RefreshOnly(true))
+	})
+	if err != nil {
+		return fmt.Errorf("Error running refresh-only plan: %w", err)
+	}
+
+	var plan *tfjson.Plan
+	err = runProviderCommand(ctx, t, wd, providers, func() error {
+		var err error
+		plan, err = wd.SavedPlan(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error retrieving refresh-only plan: %w", err)
+	}
+
+	// The refresh-only plan is only used for assertions and must not be
+	// applied by a later TestStep.
+	err = wd.ClearPlan(ctx)
+	if err != nil {
+		return fmt.Errorf("Error clearing refresh-only plan: %w", err)
+	}
+
+	if len(plan.ResourceDrift) == 0 {
+		return fmt.Errorf("Expected refresh to detect drift after Drift, but the refresh-only plan had no resource drift")
+	}
+
+	// Run post-refresh plan checks
+	if len(step.DriftPlanChecks.PostRefresh) > 0 {
+		err = runPlanChecks(ctx, t, plan, step.DriftPlanChecks.PostRefresh)
+		if err != nil {
+			return fmt.Errorf("Post-refresh drift plan check(s) failed:\n%w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func Test_Drift_PostRefresh_Called(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: driftTestResourceState("original"),
+	}
+	spy1 := &planCheckSpy{}
+	spy2 := &planCheckSpy{}
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": driftTestResource(readResponse),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+			{
+				Drift: func(ctx context.Context, req DriftRequest) error {
+					readResponse.NewState = driftTestResourceState("drifted")
+
+					return nil
+				},
+				DriftPlanChecks: DriftPlanChecks{
+					PostRefresh: []plancheck.PlanCheck{
+						spy1,
+						spy2,
+					},
+				},
+			},
+		},
+	})
+
+	if !spy1.called {
+		t.Error("expected DriftPlanChecks.PostRefresh spy1 to be called at least once")
+	}
+
+	if !spy2.called {
+		t.Error("expected DriftPlanChecks.PostRefresh spy2 to be called at least once")
+	}
+}
+
+func Test_Drift_PostRefresh_Errors(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: driftTestResourceState("original"),
+	}
+	spy1 := &planCheckSpy{}
+	spy2 := &planCheckSpy{
+		err: errors.New("spy2 check failed"),
+	}
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": driftTestResource(readResponse),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+			{
+				Drift: func(ctx context.Context, req DriftRequest) error {
+					readResponse.NewState = driftTestResourceState("drifted")
+
+					return nil
+				},
+				DriftPlanChecks: DriftPlanChecks{
+					PostRefresh: []plancheck.PlanCheck{
+						spy1,
+						spy2,
+					},
+				},
+				ExpectError: regexp.MustCompile(`.*?(spy2 check failed)`),
+			},
+		},
+	})
+}
+
+func Test_Drift_Error(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: driftTestResourceState("original"),
+	}
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": driftTestResource(readResponse),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+			{
+				Drift: func(ctx context.Context, req DriftRequest) error {
+					return errors.New("drift failed")
+				},
+				ExpectError: regexp.MustCompile(`Error calling Drift: drift failed`),
+			},
+		},
+	})
+}
+
+func Test_Drift_NoResourceDrift(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: driftTestResourceState("original"),
+	}
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": driftTestResource(readResponse),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+			{
+				Drift: func(ctx context.Context, req DriftRequest) error {
+					if req.State == nil || req.State.Values == nil {
+						return errors.New("expected state")
+					}
+
+					return nil
+				},
+				ExpectError: regexp.MustCompile(`the refresh-only plan had no resource drift`),
+			},
+		},
+	})
+}
+
+// driftTestResource returns a resource whose remote object is represented by
+// the given ReadResponse, which a Drift function can modify.
+func driftTestResource(readResponse *resource.ReadResponse) testprovider.Resource {
+	return testprovider.Resource{
+		CreateResponse: &resource.CreateResponse{
+			NewState: driftTestResourceState("original"),
+		},
+		ReadResponse: readResponse,
+		SchemaResponse: &resource.SchemaResponse{
+			Schema: &tfprotov6.Schema{
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "id",
+							Type:     tftypes.String,
+							Computed: true,
+						},
+						{
+							Name:     "value",
+							Type:     tftypes.String,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func driftTestResourceState(value string) tftypes.Value {
+	return tftypes.NewValue(
+		tftypes.Object{
+			AttributeTypes: map[string]tftypes.Type{
+				"id":    tftypes.String,
+				"value": tftypes.String,
+			},
+		},
+		map[string]tftypes.Value{
+			"id":    tftypes.NewValue(tftypes.String, "test"),
+			"value": tftypes.NewValue(tftypes.String, value),
+		},
+	)
+}
@@ -0,0 +1,93 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+// hasExpectErrorChecks returns true if the TestStep has plan or state checks
+// to run after an expected error.
+func (s TestStep) hasExpectErrorChecks() bool {
+	return len(s.ExpectErrorPlanChecks.PostError) > 0 || len(s.ExpectErrorStateChecks) > 0
+}
+
+// testStepNewExpectErrorChecks runs the TestStep ExpectErrorStateChecks
+// against the state saved after an expected error, then creates a new plan of
+// the TestStep configuration to run the ExpectErrorPlanChecks against.
+func testStepNewExpectErrorChecks(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, step TestStep, providers *providerFactories) error {
+	t.Helper()
+
+	if len(step.ExpectErrorStateChecks) > 0 {
+		var state *tfjson.State
+
+		err := runProviderCommand(ctx, t, wd, providers, func() error {
+			var err error
+			state, err = wd.State(ctx)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("Error retrieving post-error state: %w", err)
+		}
+
+		err = runStateChecks(ctx, t, state, step.ExpectErrorStateChecks)
+		if err != nil {
+			return fmt.Errorf("Post-error state check(s) failed:\n%w", err)
+		}
+	}
+
+	if len(step.ExpectErrorPlanChecks.PostError) == 0 {
+		return nil
+	}
+
+	logging.HelperResourceDebug(ctx, "Running Terraform CLI plan after expected error")
+
+	err := runProviderCommand(ctx, t, wd, providers, func() error {
+		opts := step.targetPlanOptions()
+		if step.Destroy {
+			opts = append(opts, tfexec.Destroy(true))
+		}
+
+		if c.AdditionalCLIOptions != nil {
+			if c.AdditionalCLIOptions.Plan.AllowDeferral {
+				opts = append(opts, tfexec.AllowDeferral(true))
+			}
+			if c.AdditionalCLIOptions.Plan.NoRefresh {
+				opts = append(opts, tfexec.Refresh(false))
+			}
+		}
+
+		return wd.CreatePlan(ctx, opts...)
+	})
+	if err != nil {
+		return fmt.Errorf("Error running post-error plan: %w", err)
+	}
+
+	plan, err := runProviderCommandSavedPlan(ctx, t, wd, providers)
+	if err != nil {
+		return fmt.Errorf("Error retrieving post-error plan: %w", err)
+	}
+
+	// The post-error plan is only used for assertions and must not be
+	// applied by a later TestStep.
+	err = wd.ClearPlan(ctx)
+	if err != nil {
+		return fmt.Errorf("Error clearing post-error plan: %w", err)
+	}
+
+	err = runPlanChecks(ctx, t, plan, step.ExpectErrorPlanChecks.PostError)
+	if err != nil {
+		return fmt.Errorf("Post-error plan check(s) failed:\n%w", err)
+	}
+
+	return nil
+}
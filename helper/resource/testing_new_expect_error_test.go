@@ -0,0 +1,132 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func Test_ExpectErrorChecks_PartialApply(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_4_0), // terraform_data
+		},
+		ExternalProviders: map[string]ExternalProvider{
+			"terraform": {Source: "terraform.io/builtin/terraform"},
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": expectErrorTestResource(),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "terraform_data" "one" {
+					input = "hello"
+				}
+
+				resource "test_resource" "two" {
+					depends_on = [terraform_data.one]
+				}`,
+				ExpectError: regexp.MustCompile(`error creating resource`),
+				ExpectErrorStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("terraform_data.one", tfjsonpath.New("input"), knownvalue.StringExact("hello")),
+				},
+				ExpectErrorPlanChecks: ExpectErrorPlanChecks{
+					PostError: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("terraform_data.one", plancheck.ResourceActionNoop),
+						plancheck.ExpectResourceAction("test_resource.two", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func Test_ExpectErrorChecks_Called(t *testing.T) {
+	t.Parallel()
+
+	planSpy := &planCheckSpy{}
+	stateSpy := &stateCheckSpy{}
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": expectErrorTestResource(),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config:      `resource "test_resource" "test" {}`,
+				ExpectError: regexp.MustCompile(`error creating resource`),
+				ExpectErrorPlanChecks: ExpectErrorPlanChecks{
+					PostError: []plancheck.PlanCheck{
+						planSpy,
+					},
+				},
+				ExpectErrorStateChecks: []statecheck.StateCheck{
+					stateSpy,
+				},
+			},
+		},
+	})
+
+	if !planSpy.called {
+		t.Error("expected ExpectErrorPlanChecks.PostError spy to be called at least once")
+	}
+
+	if !stateSpy.called {
+		t.Error("expected ExpectErrorStateChecks spy to be called at least once")
+	}
+}
+
+// expectErrorTestResource returns a resource which always returns an error
+// diagnostic on create, without saving any state.
+func expectErrorTestResource() testprovider.Resource {
+	return testprovider.Resource{
+		CreateResponse: &resource.CreateResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "error creating resource",
+				},
+			},
+		},
+		SchemaResponse: &resource.SchemaResponse{
+			Schema: &tfprotov6.Schema{
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "id",
+							Type:     tftypes.String,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+	"github.com/hashicorp/terraform-plugin-testing/internal/teststep"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testStepNewForget removes the ResourceName resource from the TestStep
+// configuration, or the prior TestStep configuration if none is given, and
+// appends a removed block which does not destroy it. It then verifies the plan
+// forgets the resource without destroying it, applies, and verifies the
+// resource is no longer in state.
+func testStepNewForget(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, step TestStep, priorStepCfg teststep.Config, providers *providerFactories, stepNumber int, helper *plugintest.Helper) error {
+	t.Helper()
+
+	if helper.TerraformVersion().LessThan(tfversion.Version1_7_0) {
+		return fmt.Errorf(
+			`Forget requires Terraform 1.7.0 or later. Either ` +
+				`upgrade the Terraform version running the test or add a ` + "`TerraformVersionChecks`" + ` to ` +
+				`the test case to skip this test.` + "\n\n" +
+				`https://developer.hashicorp.com/terraform/plugin/testing/acceptance-tests/tfversion-checks#skip-version-checks`)
+	}
+
+	resourceName := step.ResourceName
+
+	// Keep the state from before the resource is forgotten, so ForgetCheck
+	// can verify the remote object still exists.
+	var priorState *terraform.State
+	var err error
+
+	err = runProviderCommand(ctx, t, wd, providers, func() error {
+		_, priorState, err = getState(ctx, t, wd)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting state: %w", err)
+	}
+
+	testStepConfig, err := testStepMergedConfig(ctx, t, c, step, stepNumber, helper)
+	if err != nil {
+		return err
+	}
+
+	// If the current forget test step doesn't have configuration, use the prior test step config
+	if testStepConfig == nil {
+		if priorStepCfg == nil {
+			return fmt.Errorf("Cannot test removed block with no specified config")
+		}
+
+		logging.HelperResourceTrace(ctx, "Using prior TestStep Config for removed block")
+
+		testStepConfig = priorStepCfg
+	}
+
+	testStepConfig = appendRemovedBlock(testStepConfig, resourceName)
+
+	err = wd.SetConfig(ctx, testStepConfig, step.ConfigVariables)
+	if err != nil {
+		return fmt.Errorf("Error setting config: %w", err)
+	}
+
+	removed, err := wd.RemoveResourceConfig(ctx, resourceName)
+	if err != nil {
+		return fmt.Errorf("removing resource %s from config: %w", resourceName, err)
+	}
+
+	if !removed {
+		return fmt.Errorf("resource %s not found in configuration", resourceName)
+	}
+
+	err = runProviderCommandCreatePlan(ctx, t, wd, providers)
+	if err != nil {
+		return fmt.Errorf("generating plan with removed config: %w", err)
+	}
+
+	plan, err := runProviderCommandSavedPlan(ctx, t, wd, providers)
+	if err != nil {
+		return fmt.Errorf("reading generated plan with removed config: %w", err)
+	}
+
+	var forgotten bool
+
+	for _, change := range plan.ResourceChanges {
+		if !forgetAddressMatches(resourceName, change.Address) {
+			continue
+		}
+
+		if change.Change == nil || !change.Change.Actions.Forget() {
+			var actions tfjson.Actions
+
+			if change.Change != nil {
+				actions = change.Change.Actions
+			}
+
+			return fmt.Errorf("forgetting resource %s: expected a forget operation, got %q action with plan \nstdout:\n\n%s", change.Address, actions, savedPlanRawStdout(ctx, t, wd, providers))
+		}
+
+		forgotten = true
+	}
+
+	if !forgotten {
+		return fmt.Errorf("forgetting resource %s: expected a resource change, got no changes", resourceName)
+	}
+
+	if err := runPlanChecks(ctx, t, plan, step.ForgetPlanChecks.PreApply); err != nil {
+		return fmt.Errorf("Pre-apply plan check(s) failed:\n%w", err)
+	}
+
+	logging.HelperResourceDebug(ctx, "Running Terraform CLI apply with removed config")
+
+	err = runProviderCommand(ctx, t, wd, providers, func() error {
+		return wd.Apply(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("Error running apply with removed config: %w", err)
+	}
+
+	var state *tfjson.State
+
+	err = runProviderCommand(ctx, t, wd, providers, func() error {
+		var err error
+		state, err = wd.State(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error retrieving state after forgetting resource: %w", err)
+	}
+
+	if state.Values != nil {
+		if address := forgetStateAddress(state.Values.RootModule, resourceName); address != "" {
+			return fmt.Errorf("forgetting resource %s: expected %s to be removed from state", resourceName, address)
+		}
+	}
+
+	if step.ForgetCheck != nil {
+		logging.HelperResourceDebug(ctx, "Calling TestStep ForgetCheck")
+
+		if err := step.ForgetCheck(priorState); err != nil {
+			return fmt.Errorf("Check failed: %w", err)
+		}
+
+		logging.HelperResourceDebug(ctx, "Called TestStep ForgetCheck")
+	}
+
+	return nil
+}
+
+// forgetAddressMatches returns true if the resource instance address is the
+// resource address or one of its instances.
+func forgetAddressMatches(resourceAddress, instanceAddress string) bool {
+	return instanceAddress == resourceAddress || strings.HasPrefix(instanceAddress, resourceAddress+"[")
+}
+
+// forgetStateAddress returns the address of the first instance of the
+// resource in the module or its child modules, or an empty string if there
+// is none.
+func forgetStateAddress(module *tfjson.StateModule, resourceAddress string) string {
+	if module == nil {
+		return ""
+	}
+
+	for _, r := range module.Resources {
+		if forgetAddressMatches(resourceAddress, r.Address) {
+			return r.Address
+		}
+	}
+
+	for _, childModule := range module.ChildModules {
+		if address := forgetStateAddress(childModule, resourceAddress); address != "" {
+			return address
+		}
+	}
+
+	return ""
+}
+
+func appendRemovedBlock(config teststep.Config, resourceName string) teststep.Config {
+	return config.Append(
+		fmt.Sprintf(``+"\n"+
+			`removed {`+"\n"+
+			`	from = %s`+"\n"+
+			`	lifecycle {`+"\n"+
+			`		destroy = false`+"\n"+
+			`	}`+"\n"+
+			`}`,
+			resourceName))
+}
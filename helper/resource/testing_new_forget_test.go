@@ -0,0 +1,225 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func Test_Forget(t *testing.T) {
+	t.Parallel()
+
+	var deleted bool
+	var checkState *terraform.State
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0), // removed blocks
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": forgetTestResource(&deleted),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+			{
+				Forget:       true,
+				ResourceName: "test_resource.test",
+				ForgetCheck: func(s *terraform.State) error {
+					checkState = s
+
+					if deleted {
+						return errors.New("expected test_resource.test to not be deleted")
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+
+	if checkState == nil {
+		t.Fatal("expected ForgetCheck to be called")
+	}
+
+	if _, ok := checkState.RootModule().Resources["test_resource.test"]; !ok {
+		t.Error("expected ForgetCheck state to contain test_resource.test")
+	}
+}
+
+func Test_Forget_ForgetCheck_Error(t *testing.T) {
+	t.Parallel()
+
+	var deleted bool
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0), // removed blocks
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": forgetTestResource(&deleted),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+			{
+				Forget:       true,
+				ResourceName: "test_resource.test",
+				ForgetCheck: func(s *terraform.State) error {
+					return errors.New("remote object not found")
+				},
+				ExpectError: regexp.MustCompile(`Check failed: remote object not found`),
+			},
+		},
+	})
+}
+
+func Test_Forget_ResourceNotInConfig(t *testing.T) {
+	t.Parallel()
+
+	var deleted bool
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0), // removed blocks
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": forgetTestResource(&deleted),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+			{
+				Forget:       true,
+				ResourceName: "test_resource.other",
+				ExpectError:  regexp.MustCompile(`resource test_resource.other not found in configuration`),
+			},
+		},
+	})
+}
+
+func TestForgetStateAddress(t *testing.T) {
+	t.Parallel()
+
+	module := &tfjson.StateModule{
+		Resources: []*tfjson.StateResource{
+			{Address: "test_resource.other"},
+		},
+		ChildModules: []*tfjson.StateModule{
+			{
+				Address: "module.child",
+				Resources: []*tfjson.StateResource{
+					{Address: "module.child.test_resource.test"},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		module          *tfjson.StateModule
+		resourceAddress string
+		expected        string
+	}{
+		"nil": {
+			resourceAddress: "test_resource.test",
+		},
+		"root-module": {
+			module:          module,
+			resourceAddress: "test_resource.other",
+			expected:        "test_resource.other",
+		},
+		"root-module-instance": {
+			module: &tfjson.StateModule{
+				Resources: []*tfjson.StateResource{
+					{Address: "test_resource.test[1]"},
+				},
+			},
+			resourceAddress: "test_resource.test",
+			expected:        "test_resource.test[1]",
+		},
+		"child-module": {
+			module:          module,
+			resourceAddress: "module.child.test_resource.test",
+			expected:        "module.child.test_resource.test",
+		},
+		"not-found": {
+			module:          module,
+			resourceAddress: "test_resource.test",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := forgetStateAddress(testCase.module, testCase.resourceAddress)
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+// forgetTestResource returns a resource which sets deleted when it is
+// deleted.
+func forgetTestResource(deleted *bool) testprovider.Resource {
+	return testprovider.Resource{
+		CreateResponse: &resource.CreateResponse{
+			NewState: tftypes.NewValue(
+				tftypes.Object{
+					AttributeTypes: map[string]tftypes.Type{
+						"id": tftypes.String,
+					},
+				},
+				map[string]tftypes.Value{
+					"id": tftypes.NewValue(tftypes.String, "test"),
+				},
+			),
+		},
+		DeleteFunc: func(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+			*deleted = true
+		},
+		SchemaResponse: &resource.SchemaResponse{
+			Schema: &tfprotov6.Schema{
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "id",
+							Type:     tftypes.String,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
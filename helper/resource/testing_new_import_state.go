@@ -155,7 +155,13 @@ func testStepNewImportState(ctx context.Context, t testing.T, helper *plugintest
 		workingDir = testCaseWorkingDir
 	} else {
 		workingDir = helper.RequireNewWorkingDir(ctx, t, "")
-		defer workingDir.Close()
+		workingDir.SetCaptureDiagnostics(testCaseWorkingDir.IsCapturingDiagnostics())
+		workingDir.SetCommandContext(testCaseWorkingDir.CommandContext())
+
+		defer func() {
+			testCaseWorkingDir.AppendDiagnostics(workingDir.Diagnostics()...)
+			workingDir.Close()
+		}()
 	}
 
 	err = workingDir.SetConfig(ctx, testStepConfig, step.ConfigVariables)
@@ -0,0 +1,204 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+	"github.com/hashicorp/terraform-plugin-testing/internal/teststep"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testStepNewMoved appends a moved block from MovedFrom to MovedTo to the
+// TestStep configuration, then verifies the plan moves the resource without
+// replacing it before applying. The applied configuration is returned for
+// later TestStep to use.
+func testStepNewMoved(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, step TestStep, providers *providerFactories, stepNumber int, helper *plugintest.Helper) (teststep.Config, error) {
+	t.Helper()
+
+	if err := movedPreconditions(helper, step); err != nil {
+		return nil, err
+	}
+
+	testStepConfig, err := testStepMergedConfig(ctx, t, c, step, stepNumber, helper)
+	if err != nil {
+		return nil, err
+	}
+
+	if testStepConfig == nil {
+		return nil, fmt.Errorf("Cannot test moved block with no specified config")
+	}
+
+	testStepConfig = appendMovedBlock(testStepConfig, step.MovedFrom, step.MovedTo)
+
+	err = wd.SetConfig(ctx, testStepConfig, step.ConfigVariables)
+	if err != nil {
+		return nil, fmt.Errorf("Error setting config: %w", err)
+	}
+
+	err = runProviderCommandCreatePlan(ctx, t, wd, providers)
+	if err != nil {
+		return nil, fmt.Errorf("generating plan with moved config: %w", err)
+	}
+
+	plan, err := runProviderCommandSavedPlan(ctx, t, wd, providers)
+	if err != nil {
+		return nil, fmt.Errorf("reading generated plan with moved config: %w", err)
+	}
+
+	var resourceChangeUnderTest *tfjson.ResourceChange
+
+	for _, change := range plan.ResourceChanges {
+		if change.Address == step.MovedTo {
+			resourceChangeUnderTest = change
+		}
+	}
+
+	if resourceChangeUnderTest == nil || resourceChangeUnderTest.Change == nil || resourceChangeUnderTest.Change.Actions == nil {
+		return nil, fmt.Errorf("moving resource %s to %s: expected a resource change, got no changes", step.MovedFrom, step.MovedTo)
+	}
+
+	actions := resourceChangeUnderTest.Change.Actions
+
+	switch {
+	case resourceChangeUnderTest.PreviousAddress != step.MovedFrom:
+		return nil, fmt.Errorf("moving resource %s to %s: expected previous address %q, got %q with plan \nstdout:\n\n%s", step.MovedFrom, step.MovedTo, step.MovedFrom, resourceChangeUnderTest.PreviousAddress, savedPlanRawStdout(ctx, t, wd, providers))
+	case actions.Create() || actions.Delete() || actions.Replace():
+		return nil, fmt.Errorf("moving resource %s to %s: expected a move without replacement, got %q action with plan \nstdout:\n\n%s", step.MovedFrom, step.MovedTo, actions, savedPlanRawStdout(ctx, t, wd, providers))
+	// By default we want to ensure there isn't a proposed plan after moving, but for some resources this is unavoidable.
+	case !step.ExpectNonEmptyPlan && !actions.NoOp():
+		return nil, fmt.Errorf("moving resource %s to %s: expected a no-op move operation, got %q action with plan \nstdout:\n\n%s", step.MovedFrom, step.MovedTo, actions, savedPlanRawStdout(ctx, t, wd, providers))
+	}
+
+	if err := runPlanChecks(ctx, t, plan, step.MovedPlanChecks.PreApply); err != nil {
+		return nil, fmt.Errorf("Pre-apply plan check(s) failed:\n%w", err)
+	}
+
+	logging.HelperResourceDebug(ctx, "Running Terraform CLI apply with moved config")
+
+	err = runProviderCommand(ctx, t, wd, providers, func() error {
+		return wd.Apply(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error running apply with moved config: %w", err)
+	}
+
+	if len(step.MovedStateChecks) > 0 {
+		var state *tfjson.State
+
+		err = runProviderCommand(ctx, t, wd, providers, func() error {
+			var err error
+			state, err = wd.State(ctx)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving state after moving resource: %w", err)
+		}
+
+		if err := runStateChecks(ctx, t, state, step.MovedStateChecks); err != nil {
+			return nil, fmt.Errorf("After applying moved config, state check(s) failed:\n%w", err)
+		}
+	}
+
+	return testStepConfig, nil
+}
+
+// testStepMergedConfig returns the merged TestStep configuration, or nil if the
+// TestStep has no configuration.
+func testStepMergedConfig(ctx context.Context, t testing.T, c TestCase, step TestStep, stepNumber int, helper *plugintest.Helper) (teststep.Config, error) {
+	testStepConfigRequest := config.TestStepConfigRequest{
+		StepNumber: stepNumber,
+		TestName:   t.Name(),
+	}
+
+	cfg := teststep.Configuration(teststep.PrepareConfigurationRequest{
+		Directory:             step.ConfigDirectory,
+		File:                  step.ConfigFile,
+		Raw:                   step.Config,
+		TestStepConfigRequest: testStepConfigRequest,
+	}.Exec())
+
+	if cfg == nil {
+		return nil, nil
+	}
+
+	hasTerraformBlock, err := cfg.HasTerraformBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error determining whether configuration contains terraform block: %w", err)
+	}
+
+	hasProviderBlock, err := cfg.HasProviderBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error determining whether configuration contains provider block: %w", err)
+	}
+
+	mergedConfig, err := step.mergedConfig(ctx, c, hasTerraformBlock, hasProviderBlock, helper.TerraformVersion())
+	if err != nil {
+		return nil, fmt.Errorf("Error generating merged configuration: %w", err)
+	}
+
+	return teststep.Configuration(teststep.PrepareConfigurationRequest{
+		Directory:             step.ConfigDirectory,
+		File:                  step.ConfigFile,
+		Raw:                   mergedConfig,
+		TestStepConfigRequest: testStepConfigRequest,
+	}.Exec()), nil
+}
+
+func movedPreconditions(helper *plugintest.Helper, step TestStep) error {
+	minVersion := tfversion.Version1_1_0
+
+	// Moving a resource to a different resource type requires the
+	// MoveResourceState RPC, which was introduced in Terraform 1.8.
+	if movedResourceType(step.MovedFrom) != movedResourceType(step.MovedTo) {
+		minVersion = tfversion.Version1_8_0
+	}
+
+	if helper.TerraformVersion().LessThan(minVersion) {
+		return fmt.Errorf(
+			`MovedFrom and MovedTo require Terraform %s or later when moving %s to %s. Either `+
+				`upgrade the Terraform version running the test or add a `+"`TerraformVersionChecks`"+` to `+
+				`the test case to skip this test.`+"\n\n"+
+				`https://developer.hashicorp.com/terraform/plugin/testing/acceptance-tests/tfversion-checks#skip-version-checks`,
+			minVersion, step.MovedFrom, step.MovedTo)
+	}
+
+	return nil
+}
+
+// movedResourceType returns the resource type of a resource address, such as
+// "examplecloud_thing" for module.example.examplecloud_thing.test[0].
+func movedResourceType(address string) string {
+	if strings.HasSuffix(address, "]") {
+		if i := strings.LastIndex(address, "["); i >= 0 {
+			address = address[:i]
+		}
+	}
+
+	parts := strings.Split(address, ".")
+
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return parts[len(parts)-2]
+}
+
+func appendMovedBlock(config teststep.Config, from string, to string) teststep.Config {
+	return config.Append(
+		fmt.Sprintf(``+"\n"+
+			`moved {`+"\n"+
+			`	from = %s`+"\n"+
+			`	to   = %s`+"\n"+
+			`}`,
+			from, to))
+}
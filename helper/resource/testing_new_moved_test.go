@@ -0,0 +1,148 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func Test_Moved_Rename(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_1_0), // moved blocks
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": movedTestResource(),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "one" {}`,
+			},
+			{
+				Config:    `resource "test_resource" "two" {}`,
+				MovedFrom: "test_resource.one",
+				MovedTo:   "test_resource.two",
+				MovedPlanChecks: MovedPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("test_resource.two", plancheck.ResourceActionNoop),
+					},
+				},
+				MovedStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"test_resource.two",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("test"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func Test_Moved_PlanChecks_Error(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_1_0), // moved blocks
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": movedTestResource(),
+				},
+			}),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "one" {}`,
+			},
+			{
+				Config:    `resource "test_resource" "two" {}`,
+				MovedFrom: "test_resource.one",
+				MovedTo:   "test_resource.two",
+				MovedPlanChecks: MovedPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("test_resource.two", plancheck.ResourceActionCreate),
+					},
+				},
+				ExpectError: regexp.MustCompile(`'test_resource.two' - expected Create, got action\(s\): \[no-op\]`),
+			},
+		},
+	})
+}
+
+func TestMovedResourceType(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"test_resource.test":                      "test_resource",
+		"test_resource.test[0]":                   "test_resource",
+		`test_resource.test["a.b"]`:               "test_resource",
+		"module.example.test_resource.test":       "test_resource",
+		`module.example["a"].test_resource.test`:  "test_resource",
+		`module.example[0].test_resource.test[1]`: "test_resource",
+		"test_resource":                           "",
+	}
+
+	for address, expected := range testCases {
+		t.Run(address, func(t *testing.T) {
+			t.Parallel()
+
+			got := movedResourceType(address)
+
+			if got != expected {
+				t.Errorf("expected %q, got %q", expected, got)
+			}
+		})
+	}
+}
+
+func movedTestResource() testprovider.Resource {
+	return testprovider.Resource{
+		CreateResponse: &resource.CreateResponse{
+			NewState: tftypes.NewValue(
+				tftypes.Object{
+					AttributeTypes: map[string]tftypes.Type{
+						"id": tftypes.String,
+					},
+				},
+				map[string]tftypes.Value{
+					"id": tftypes.NewValue(tftypes.String, "test"),
+				},
+			),
+		},
+		SchemaResponse: &resource.SchemaResponse{
+			Schema: &tfprotov6.Schema{
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "id",
+							Type:     tftypes.String,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
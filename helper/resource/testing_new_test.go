@@ -4,14 +4,18 @@
 package resource
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	tfjson "github.com/hashicorp/terraform-json"
 
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
@@ -1161,3 +1165,80 @@ func TestShimState(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleStepError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		testCase    TestCase
+		step        TestStep
+		err         error
+		expectFatal bool
+	}{
+		"no-error": {},
+		"error": {
+			err:         errors.New("test error"),
+			expectFatal: true,
+		},
+		"ExpectError-match": {
+			step: TestStep{
+				ExpectError: regexp.MustCompile(`test error`),
+			},
+			err: errors.New("test error"),
+		},
+		"ExpectError-no-match": {
+			step: TestStep{
+				ExpectError: regexp.MustCompile(`other error`),
+			},
+			err:         errors.New("test error"),
+			expectFatal: true,
+		},
+		"ExpectError-no-error": {
+			step: TestStep{
+				ExpectError: regexp.MustCompile(`test error`),
+			},
+			expectFatal: true,
+		},
+		"ErrorCheck-cleared": {
+			testCase: TestCase{
+				ErrorCheck: func(err error) error {
+					var stepErr *TestStepError
+
+					if errors.As(err, &stepErr) && stepErr.Mode == TestStepModeDrift {
+						return nil
+					}
+
+					return err
+				},
+			},
+			err: errors.New("test error"),
+		},
+		"ErrorCheck-not-cleared": {
+			testCase: TestCase{
+				ErrorCheck: func(err error) error {
+					return err
+				},
+			},
+			err:         errors.New("test error"),
+			expectFatal: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			run := func() {
+				handleStepError(context.Background(), &mockT{}, testCase.testCase, &plugintest.WorkingDir{}, testCase.step, 1, TestStepModeDrift, testCase.err)
+			}
+
+			if testCase.expectFatal {
+				plugintest.TestExpectTFatal(t, run)
+
+				return
+			}
+
+			run()
+		})
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+	"github.com/hashicorp/terraform-plugin-testing/internal/teststep"
+)
+
+// upgradeFromTestCase returns a copy of the TestCase where the providers in
+// UpgradeFrom are removed from the provider factories and instead included in
+// ExternalProviders, so the existing configuration and provider factory
+// handling will use the released provider versions.
+func (c TestCase) upgradeFromTestCase() TestCase {
+	upgradeCase := c
+
+	upgradeCase.ProviderFactories = maps.Clone(c.ProviderFactories)
+	upgradeCase.ProtoV5ProviderFactories = maps.Clone(c.ProtoV5ProviderFactories)
+	upgradeCase.ProtoV6ProviderFactories = maps.Clone(c.ProtoV6ProviderFactories)
+	upgradeCase.ExternalProviders = maps.Clone(c.ExternalProviders)
+
+	if upgradeCase.ExternalProviders == nil {
+		upgradeCase.ExternalProviders = make(map[string]ExternalProvider, len(c.UpgradeFrom))
+	}
+
+	for name, externalProvider := range c.UpgradeFrom {
+		delete(upgradeCase.ProviderFactories, name)
+		delete(upgradeCase.ProtoV5ProviderFactories, name)
+		delete(upgradeCase.ProtoV6ProviderFactories, name)
+
+		if externalProvider.Source == "" {
+			externalProvider.Source = getProviderAddr(name)
+		}
+
+		upgradeCase.ExternalProviders[name] = externalProvider
+	}
+
+	return upgradeCase
+}
+
+// upgradeFromApplies returns true if the Config mode TestStep should be
+// upgrade tested with the TestCase UpgradeFrom providers.
+func (s TestStep) upgradeFromApplies(ctx context.Context, cfg teststep.Config) (bool, error) {
+	if s.PlanOnly || s.Destroy || s.ExpectError != nil || len(s.ExpectDiagnostics) > 0 || len(s.Targets) > 0 || len(s.Replace) > 0 {
+		return false, nil
+	}
+
+	// The released provider versions can only be swapped in when the
+	// terraform configuration block is generated by the testing framework.
+	if s.ConfigDirectory != nil || s.ConfigFile != nil || cfg == nil {
+		return false, nil
+	}
+
+	hasTerraformBlock, err := cfg.HasTerraformBlock(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	return !hasTerraformBlock, nil
+}
+
+// testStepNewUpgradeFrom plans and applies the TestStep configuration with the
+// TestCase UpgradeFrom released providers in the upgrade working directory,
+// copies the resulting state into the TestCase working directory, then
+// verifies that planning with the in-process providers produces no changes.
+func testStepNewUpgradeFrom(ctx context.Context, t testing.T, c TestCase, wd, upgradeWd *plugintest.WorkingDir, step TestStep, providers *providerFactories, stepIndex int, helper *plugintest.Helper) error {
+	t.Helper()
+
+	configRequest := teststep.PrepareConfigurationRequest{
+		Raw: step.Config,
+		TestStepConfigRequest: config.TestStepConfigRequest{
+			StepNumber: stepIndex + 1,
+			TestName:   t.Name(),
+		},
+	}.Exec()
+
+	cfg := teststep.Configuration(configRequest)
+
+	applies, err := step.upgradeFromApplies(ctx, cfg)
+
+	if err != nil {
+		return fmt.Errorf("Error determining whether TestStep applies to UpgradeFrom: %w", err)
+	}
+
+	if !applies {
+		logging.HelperResourceDebug(ctx, "Skipping TestCase UpgradeFrom for TestStep")
+
+		return nil
+	}
+
+	hasProviderBlock, err := cfg.HasProviderBlock(ctx)
+
+	if err != nil {
+		return fmt.Errorf("Error determining whether configuration contains provider block: %w", err)
+	}
+
+	upgradeCase := c.upgradeFromTestCase()
+
+	// The RPCs with the released providers are not part of the TestStep
+	// assertions, so they are not recorded for RPCChecks or EphemeralChecks
+	// and do not count towards ProviderFaults calls.
+	upgradeProviders := &providerFactories{
+		legacy:  upgradeCase.ProviderFactories,
+		protov5: upgradeCase.ProtoV5ProviderFactories,
+		protov6: upgradeCase.ProtoV6ProviderFactories,
+
+		interceptors: providers.interceptors,
+	}
+
+	upgradeConfig, err := step.mergedConfig(ctx, upgradeCase, false, hasProviderBlock, helper.TerraformVersion())
+
+	if err != nil {
+		return fmt.Errorf("Error generating UpgradeFrom configuration: %w", err)
+	}
+
+	err = upgradeWd.SetConfig(ctx, teststep.Configuration(teststep.ConfigurationRequest{Raw: &upgradeConfig}), step.ConfigVariables)
+
+	if err != nil {
+		return fmt.Errorf("Error setting UpgradeFrom configuration: %w", err)
+	}
+
+	// Earlier steps, such as ImportState, Destroy, or Forget, may have changed
+	// the TestCase state without the released providers, so start from the
+	// current TestCase state rather than the last UpgradeFrom apply.
+	if _, err := os.Stat(wd.StateFilePath()); err == nil {
+		err = upgradeWd.CopyState(ctx, wd.StateFilePath())
+
+		if err != nil {
+			return fmt.Errorf("Error copying state for UpgradeFrom: %w", err)
+		}
+	} else if errors.Is(err, os.ErrNotExist) {
+		err = upgradeWd.ClearState(ctx)
+
+		if err != nil {
+			return fmt.Errorf("Error clearing state for UpgradeFrom: %w", err)
+		}
+	} else {
+		return fmt.Errorf("Error reading state for UpgradeFrom: %w", err)
+	}
+
+	logging.HelperResourceDebug(ctx, "Running Terraform CLI init, plan, and apply with TestCase UpgradeFrom providers")
+
+	err = runProviderCommand(ctx, t, upgradeWd, upgradeProviders, func() error {
+		return upgradeWd.Init(ctx)
+	})
+
+	if err != nil {
+		return fmt.Errorf("Error running init with UpgradeFrom providers: %w", err)
+	}
+
+	err = runProviderCommand(ctx, t, upgradeWd, upgradeProviders, func() error {
+		return upgradeWd.CreatePlan(ctx)
+	})
+
+	if err != nil {
+		return fmt.Errorf("Error running plan with UpgradeFrom providers: %w", err)
+	}
+
+	err = runProviderCommand(ctx, t, upgradeWd, upgradeProviders, func() error {
+		return upgradeWd.Apply(ctx)
+	})
+
+	if err != nil {
+		return fmt.Errorf("Error running apply with UpgradeFrom providers: %w", err)
+	}
+
+	err = wd.CopyState(ctx, upgradeWd.StateFilePath())
+
+	if err != nil {
+		return fmt.Errorf("Error copying UpgradeFrom state: %w", err)
+	}
+
+	mergedConfig, err := step.mergedConfig(ctx, c, false, hasProviderBlock, helper.TerraformVersion())
+
+	if err != nil {
+		return fmt.Errorf("Error generating merged configuration: %w", err)
+	}
+
+	err = wd.SetConfig(ctx, teststep.Configuration(teststep.ConfigurationRequest{Raw: &mergedConfig}), step.ConfigVariables)
+
+	if err != nil {
+		return fmt.Errorf("Error setting config: %w", err)
+	}
+
+	logging.HelperResourceDebug(ctx, "Running Terraform CLI plan with in-process providers after TestCase UpgradeFrom")
+
+	// Planning without refresh only upgrades the prior state, so any
+	// differences are introduced by UpgradeResourceState.
+	plan, err := upgradeFromPlan(ctx, t, wd, providers, tfexec.Refresh(false))
+
+	if err != nil {
+		return fmt.Errorf("Error running plan without refresh after UpgradeFrom: %w", err)
+	}
+
+	// ExpectNonEmptyPlan only applies to the refreshed plan below, as an
+	// upgraded state must always match the state of the released providers.
+	if !planIsEmpty(plan, helper.TerraformVersion()) {
+		return fmt.Errorf("After upgrading from %s, the non-refresh plan was not empty, which indicates differences introduced by UpgradeResourceState.\nstdout\n\n%s", upgradeFromString(c.UpgradeFrom), savedPlanRawStdout(ctx, t, wd, providers))
+	}
+
+	plan, err = upgradeFromPlan(ctx, t, wd, providers)
+
+	if err != nil {
+		return fmt.Errorf("Error running plan after UpgradeFrom: %w", err)
+	}
+
+	if !planIsEmpty(plan, helper.TerraformVersion()) && !step.ExpectNonEmptyPlan {
+		return fmt.Errorf("After upgrading from %s, the plan was not empty.\nstdout\n\n%s", upgradeFromString(c.UpgradeFrom), savedPlanRawStdout(ctx, t, wd, providers))
+	}
+
+	return nil
+}
+
+// upgradeFromPlan creates and returns a saved plan in the working directory.
+func upgradeFromPlan(ctx context.Context, t testing.T, wd *plugintest.WorkingDir, providers *providerFactories, opts ...tfexec.PlanOption) (*tfjson.Plan, error) {
+	t.Helper()
+
+	var plan *tfjson.Plan
+
+	err := runProviderCommand(ctx, t, wd, providers, func() error {
+		if err := wd.CreatePlan(ctx, opts...); err != nil {
+			return err
+		}
+
+		var err error
+
+		plan, err = wd.SavedPlan(ctx)
+
+		return err
+	})
+
+	return plan, err
+}
+
+// upgradeFromString returns a human-readable description of the UpgradeFrom
+// provider versions.
+func upgradeFromString(upgradeFrom map[string]ExternalProvider) string {
+	var result []string
+
+	for _, name := range slices.Sorted(maps.Keys(upgradeFrom)) {
+		result = append(result, fmt.Sprintf("%s %s", name, upgradeFrom[name].VersionConstraint))
+	}
+
+	return strings.Join(result, ", ")
+}
@@ -0,0 +1,240 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestTest_TestCase_UpgradeFrom(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"null": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"null_resource": nullResource(0, nil),
+				},
+			}),
+		},
+		UpgradeFrom: map[string]ExternalProvider{
+			"null": {
+				Source:            "registry.terraform.io/hashicorp/null",
+				VersionConstraint: "3.2.2",
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "null_resource" "test" {
+					triggers = {
+						key = "value"
+					}
+				}`,
+			},
+		},
+	})
+}
+
+// The released provider must start from the current TestCase state, not
+// the state of its last apply, when another step changed the state.
+func TestTest_TestCase_UpgradeFrom_StateChangedByOtherStep(t *testing.T) {
+	t.Parallel()
+
+	config := `resource "null_resource" "test" {
+		triggers = {
+			key = "value"
+		}
+	}`
+
+	var firstID string
+
+	Test(t, TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"null": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"null_resource": nullResource(0, nil),
+				},
+			}),
+		},
+		UpgradeFrom: map[string]ExternalProvider{
+			"null": {
+				Source:            "registry.terraform.io/hashicorp/null",
+				VersionConstraint: "3.2.2",
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: config,
+				Check: func(s *terraform.State) error {
+					firstID = s.RootModule().Resources["null_resource.test"].Primary.ID
+
+					return nil
+				},
+			},
+			{
+				Config:  config,
+				Destroy: true,
+			},
+			{
+				Config: config,
+				Check: func(s *terraform.State) error {
+					if id := s.RootModule().Resources["null_resource.test"].Primary.ID; id == firstID {
+						return fmt.Errorf("expected null_resource.test to be recreated after destroy, got same ID: %s", id)
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestTest_TestCase_UpgradeFrom_UpgradeResourceStateDiff(t *testing.T) {
+	t.Parallel()
+
+	upgradeStateResponse := &resource.UpgradeStateResponse{
+		UpgradedState: tftypes.NewValue(
+			nullResourceType,
+			map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "test"),
+				"triggers": tftypes.NewValue(
+					tftypes.Map{ElementType: tftypes.String},
+					map[string]tftypes.Value{
+						"key": tftypes.NewValue(tftypes.String, "upgraded"),
+					},
+				),
+			},
+		),
+	}
+
+	plugintest.TestExpectTFatal(t, func() {
+		Test(&mockT{}, TestCase{
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+			},
+			ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+				"null": providerserver.NewProviderServer(testprovider.Provider{
+					Resources: map[string]testprovider.Resource{
+						"null_resource": nullResource(1, upgradeStateResponse),
+					},
+				}),
+			},
+			UpgradeFrom: map[string]ExternalProvider{
+				"null": {
+					VersionConstraint: "3.2.2",
+				},
+			},
+			Steps: []TestStep{
+				{
+					Config: `resource "null_resource" "test" {
+						triggers = {
+							key = "value"
+						}
+					}`,
+				},
+			},
+		})
+	})
+}
+
+func TestTest_TestCase_UpgradeFrom_UpgradeResourceStateDiff_ExpectNonEmptyPlan(t *testing.T) {
+	t.Parallel()
+
+	upgradeStateResponse := &resource.UpgradeStateResponse{
+		UpgradedState: tftypes.NewValue(
+			nullResourceType,
+			map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "test"),
+				"triggers": tftypes.NewValue(
+					tftypes.Map{ElementType: tftypes.String},
+					map[string]tftypes.Value{
+						"key": tftypes.NewValue(tftypes.String, "upgraded"),
+					},
+				),
+			},
+		),
+	}
+
+	// ExpectNonEmptyPlan does not waive the non-refresh plan after upgrading.
+	plugintest.TestExpectTFatal(t, func() {
+		Test(&mockT{}, TestCase{
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+			},
+			ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+				"null": providerserver.NewProviderServer(testprovider.Provider{
+					Resources: map[string]testprovider.Resource{
+						"null_resource": nullResource(1, upgradeStateResponse),
+					},
+				}),
+			},
+			UpgradeFrom: map[string]ExternalProvider{
+				"null": {
+					VersionConstraint: "3.2.2",
+				},
+			},
+			Steps: []TestStep{
+				{
+					Config: `resource "null_resource" "test" {
+						triggers = {
+							key = "value"
+						}
+					}`,
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		})
+	})
+}
+
+var nullResourceType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"id":       tftypes.String,
+		"triggers": tftypes.Map{ElementType: tftypes.String},
+	},
+}
+
+// nullResource returns an in-process resource with the same schema as the
+// null_resource in the released hashicorp/null provider.
+func nullResource(schemaVersion int64, upgradeStateResponse *resource.UpgradeStateResponse) testprovider.Resource {
+	return testprovider.Resource{
+		SchemaResponse: &resource.SchemaResponse{
+			Schema: &tfprotov6.Schema{
+				Version: schemaVersion,
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "id",
+							Type:     tftypes.String,
+							Computed: true,
+						},
+						{
+							Name:     "triggers",
+							Type:     tftypes.Map{ElementType: tftypes.String},
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+		UpgradeStateResponse: upgradeStateResponse,
+	}
+}
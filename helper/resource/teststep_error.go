@@ -0,0 +1,171 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"errors"
+	"slices"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+// TestStepMode is the mode of a TestStep, which is determined by the
+// TestStep fields, such as ImportState.
+type TestStepMode string
+
+const (
+	// TestStepModeConfig is a TestStep which applies Config, ConfigDirectory,
+	// or ConfigFile, including any UpgradeFrom apply.
+	TestStepModeConfig TestStepMode = "config"
+
+	// TestStepModeImportState is a TestStep with ImportState.
+	TestStepModeImportState TestStepMode = "import_state"
+
+	// TestStepModeRefreshState is a TestStep with RefreshState.
+	TestStepModeRefreshState TestStepMode = "refresh_state"
+
+	// TestStepModeDrift is a TestStep with Drift.
+	TestStepModeDrift TestStepMode = "drift"
+
+	// TestStepModeDisappears is a TestStep with Disappears.
+	TestStepModeDisappears TestStepMode = "disappears"
+
+	// TestStepModeMovedFrom is a TestStep with MovedFrom.
+	TestStepModeMovedFrom TestStepMode = "moved_from"
+
+	// TestStepModeForget is a TestStep with Forget.
+	TestStepModeForget TestStepMode = "forget"
+
+	// TestStepModeQuery is a TestStep with Query.
+	TestStepModeQuery TestStepMode = "query"
+
+	// TestStepModeStateStore is a TestStep with StateStore.
+	TestStepModeStateStore TestStepMode = "state_store"
+)
+
+// TestStepPhase is the phase of a TestStep which ran a Terraform command.
+type TestStepPhase string
+
+const (
+	// TestStepPhaseInit is a terraform init command.
+	TestStepPhaseInit TestStepPhase = plugintest.PhaseInit
+
+	// TestStepPhasePlan is a terraform plan command, other than a destroy
+	// or refresh-only plan.
+	TestStepPhasePlan TestStepPhase = plugintest.PhasePlan
+
+	// TestStepPhaseApply is a terraform apply command, other than applying
+	// a destroy or refresh-only plan.
+	TestStepPhaseApply TestStepPhase = plugintest.PhaseApply
+
+	// TestStepPhaseRefresh is a terraform refresh command, or a refresh-only
+	// terraform plan or apply command.
+	TestStepPhaseRefresh TestStepPhase = plugintest.PhaseRefresh
+
+	// TestStepPhaseDestroy is a terraform destroy command, or a destroy
+	// terraform plan or apply command.
+	TestStepPhaseDestroy TestStepPhase = plugintest.PhaseDestroy
+
+	// TestStepPhaseImport is a terraform import command.
+	TestStepPhaseImport TestStepPhase = plugintest.PhaseImport
+
+	// TestStepPhaseQuery is a terraform query command.
+	TestStepPhaseQuery TestStepPhase = plugintest.PhaseQuery
+)
+
+// TestStepError is the error passed to the TestCase ErrorCheck function when
+// a TestStep fails. It records where the failure occurred, so that an
+// ErrorCheck can handle errors without matching the error message, such as
+// skipping a test when a remote API returns a known error diagnostic:
+//
+//	ErrorCheckDiagnostics: true,
+//	ErrorCheck: func(err error) error {
+//		var stepErr *resource.TestStepError
+//
+//		if errors.As(err, &stepErr) && stepErr.Phase == resource.TestStepPhaseApply {
+//			for _, diag := range stepErr.ErrorDiagnostics() {
+//				if diag.Summary == "Service Not Available" {
+//					t.Skipf("skipping test: %s", diag.Detail)
+//				}
+//			}
+//		}
+//
+//		return err
+//	},
+//
+// The Error method returns the message of the underlying error, so existing
+// ErrorCheck functions which match the error message are not affected.
+type TestStepError struct {
+	// StepNumber is the 1-based number of the TestStep.
+	StepNumber int
+
+	// Mode is the mode of the TestStep.
+	Mode TestStepMode
+
+	// Phase is the phase of the TestStep which ran the failed Terraform
+	// command, or empty if the failure was not caused by a Terraform
+	// command, such as a failed Check function, or the command only reads
+	// the working directory, such as terraform show.
+	Phase TestStepPhase
+
+	// Command is the failed Terraform command, such as "plan" or "show", or
+	// empty if the failure was not caused by a Terraform command.
+	Command string
+
+	// Diagnostics are the diagnostics output by the Terraform commands run
+	// by the TestStep, including warnings. Diagnostics are only captured
+	// when the TestCase ErrorCheckDiagnostics is true or the TestStep sets
+	// ExpectDiagnostics, ExpectWarnings, ExpectNoWarnings, or Retry, with
+	// Terraform 0.15.3 and later, and only by the terraform init command
+	// with Terraform 1.9.0 and later.
+	Diagnostics []diagcheck.Diagnostic
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the message of the underlying error.
+func (e *TestStepError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *TestStepError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorDiagnostics returns the Diagnostics with error severity.
+func (e *TestStepError) ErrorDiagnostics() []diagcheck.Diagnostic {
+	var result []diagcheck.Diagnostic
+
+	for _, diag := range e.Diagnostics {
+		if diag.Severity == tfjson.DiagnosticSeverityError {
+			result = append(result, diag)
+		}
+	}
+
+	return result
+}
+
+// newTestStepError returns a *TestStepError for the error of a TestStep.
+func newTestStepError(err error, stepNumber int, mode TestStepMode, wd *plugintest.WorkingDir) *TestStepError {
+	result := &TestStepError{
+		StepNumber:  stepNumber,
+		Mode:        mode,
+		Diagnostics: slices.Clone(wd.Diagnostics()),
+		Err:         err,
+	}
+
+	var commandErr *plugintest.CommandError
+
+	if errors.As(err, &commandErr) {
+		result.Phase = TestStepPhase(commandErr.Phase)
+		result.Command = commandErr.Command
+	}
+
+	return result
+}
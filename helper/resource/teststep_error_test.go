@@ -0,0 +1,124 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+func TestNewTestStepError(t *testing.T) {
+	t.Parallel()
+
+	errorDiag := diagcheck.Diagnostic{
+		Diagnostic: tfjson.Diagnostic{
+			Severity: tfjson.DiagnosticSeverityError,
+			Summary:  "Service Not Available",
+		},
+	}
+	warningDiag := diagcheck.Diagnostic{
+		Diagnostic: tfjson.Diagnostic{
+			Severity: tfjson.DiagnosticSeverityWarning,
+			Summary:  "Deprecated Attribute",
+		},
+	}
+
+	testCases := map[string]struct {
+		err         error
+		diagnostics []diagcheck.Diagnostic
+		expected    *TestStepError
+	}{
+		"command": {
+			err: fmt.Errorf("running pre-apply plan: %w", &plugintest.CommandError{
+				Command: "plan",
+				Phase:   plugintest.PhaseDestroy,
+				Err:     errors.New("exit status 1"),
+			}),
+			diagnostics: []diagcheck.Diagnostic{warningDiag, errorDiag},
+			expected: &TestStepError{
+				StepNumber:  2,
+				Mode:        TestStepModeConfig,
+				Phase:       TestStepPhaseDestroy,
+				Command:     "plan",
+				Diagnostics: []diagcheck.Diagnostic{warningDiag, errorDiag},
+			},
+		},
+		"command-without-phase": {
+			err: &plugintest.CommandError{
+				Command: "show",
+				Err:     errors.New("exit status 1"),
+			},
+			expected: &TestStepError{
+				StepNumber: 2,
+				Mode:       TestStepModeConfig,
+				Command:    "show",
+			},
+		},
+		"not-command": {
+			err: errors.New("Check failed"),
+			expected: &TestStepError{
+				StepNumber: 2,
+				Mode:       TestStepModeConfig,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			wd := &plugintest.WorkingDir{}
+			wd.AppendDiagnostics(testCase.diagnostics...)
+
+			got := newTestStepError(testCase.err, 2, TestStepModeConfig, wd)
+
+			if got.Error() != testCase.err.Error() {
+				t.Errorf("expected error message %q, got %q", testCase.err.Error(), got.Error())
+			}
+
+			if !errors.Is(got, testCase.err) {
+				t.Errorf("expected error to wrap %q", testCase.err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got, cmpopts.IgnoreFields(TestStepError{}, "Err")); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTestStepError_ErrorDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	errorDiag := diagcheck.Diagnostic{
+		Diagnostic: tfjson.Diagnostic{
+			Severity: tfjson.DiagnosticSeverityError,
+			Summary:  "Service Not Available",
+		},
+	}
+
+	err := &TestStepError{
+		Diagnostics: []diagcheck.Diagnostic{
+			{
+				Diagnostic: tfjson.Diagnostic{
+					Severity: tfjson.DiagnosticSeverityWarning,
+					Summary:  "Deprecated Attribute",
+				},
+			},
+			errorDiag,
+		},
+	}
+
+	if diff := cmp.Diff([]diagcheck.Diagnostic{errorDiag}, err.ErrorDiagnostics()); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
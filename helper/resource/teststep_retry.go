@@ -0,0 +1,130 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"time"
+
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+// TestStepRetry is a policy for retrying a failed Config mode TestStep, such
+// as when a remote API is eventually consistent. A retried TestStep runs the
+// plan, apply, and checks again in the same working directory, so the state
+// and any resources created by the failed attempt are kept. The RPCChecks
+// and EphemeralChecks of the TestStep only include the calls of the last
+// attempt, while the calls matched by ProviderFaults are counted across all
+// attempts, so that a fault injected into the first attempt is not injected
+// again.
+//
+// Retries are logged and each one is reported in the test output, so that
+// flaky TestSteps remain visible.
+type TestStepRetry struct {
+	// MaxAttempts is the maximum number of times the TestStep is run,
+	// including the first attempt. It must be at least 1.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry, which is doubled before
+	// each further retry.
+	Backoff time.Duration
+
+	// MaxBackoff, if set, is the maximum delay between attempts.
+	MaxBackoff time.Duration
+
+	// Retryable returns true if the TestStep should be retried after the
+	// error. The error is classified in the same way as errors passed to
+	// the TestCase ErrorCheck, which is only called for the error of the
+	// last attempt. With Terraform 0.15.3 and later, the Terraform commands
+	// of the TestStep are run with machine-readable output, so that the
+	// TestStepError includes the diagnostics.
+	Retryable func(*TestStepError) bool
+}
+
+// delay returns the delay before the retry following the given 1-based
+// attempt.
+func (r TestStepRetry) delay(attempt int) time.Duration {
+	return retryDelay(r.Backoff, r.MaxBackoff, attempt)
+}
+
+// retryDelay returns the backoff doubled for each attempt after the first,
+// capped by maxBackoff if it is set.
+func retryDelay(backoff time.Duration, maxBackoff time.Duration, attempt int) time.Duration {
+	result := backoff
+
+	for i := 1; i < attempt; i++ {
+		if maxBackoff > 0 && result >= maxBackoff {
+			break
+		}
+
+		result *= 2
+	}
+
+	if maxBackoff > 0 && result > maxBackoff {
+		return maxBackoff
+	}
+
+	return result
+}
+
+// runTestStepWithRetry calls run until it succeeds, the TestStep Retry
+// Retryable function returns false for its error, or the maximum number of
+// attempts is reached, returning the error of the last attempt. The reset
+// function is called before each retry.
+func runTestStepWithRetry(ctx context.Context, t testing.T, c TestCase, step TestStep, stepNumber int, wd *plugintest.WorkingDir, run func() error, reset func()) error {
+	t.Helper()
+
+	if step.Retry == nil {
+		return run()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := run()
+
+		if err == nil {
+			if attempt > 1 {
+				logging.HelperResourceWarn(ctx, "TestStep passed after retrying", map[string]interface{}{logging.KeyTestStepAttempt: attempt})
+				t.Logf("Step %d/%d passed after %d attempts", stepNumber, len(c.Steps), attempt)
+			}
+
+			return nil
+		}
+
+		if attempt >= step.Retry.MaxAttempts {
+			return err
+		}
+
+		if !step.Retry.Retryable(newTestStepError(err, stepNumber, TestStepModeConfig, wd)) {
+			logging.HelperResourceDebug(ctx, "TestStep error is not retryable", map[string]interface{}{logging.KeyTestStepAttempt: attempt})
+
+			return err
+		}
+
+		delay := step.Retry.delay(attempt)
+
+		logging.HelperResourceWarn(ctx,
+			"Retrying TestStep after error",
+			map[string]interface{}{
+				logging.KeyError:              err,
+				logging.KeyTestStepAttempt:    attempt,
+				logging.KeyTestStepRetryDelay: delay.String(),
+			},
+		)
+		t.Logf("Step %d/%d attempt %d/%d failed, retrying in %s: %s", stepNumber, len(c.Steps), attempt, step.Retry.MaxAttempts, delay, err)
+
+		// Stop retrying when the TestStep times out, so that the backoff
+		// does not use the time reserved for the post-test destroy.
+		select {
+		case <-time.After(delay):
+		case <-wd.CommandContext().Done():
+			return err
+		}
+
+		wd.ClearDiagnostics()
+		reset()
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+func TestTestStepRetry_delay(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		retry    TestStepRetry
+		attempt  int
+		expected time.Duration
+	}{
+		"zero": {
+			attempt:  3,
+			expected: 0,
+		},
+		"first": {
+			retry:    TestStepRetry{Backoff: time.Second},
+			attempt:  1,
+			expected: time.Second,
+		},
+		"doubled": {
+			retry:    TestStepRetry{Backoff: time.Second},
+			attempt:  3,
+			expected: 4 * time.Second,
+		},
+		"max-backoff": {
+			retry:    TestStepRetry{Backoff: time.Second, MaxBackoff: 3 * time.Second},
+			attempt:  3,
+			expected: 3 * time.Second,
+		},
+		"max-backoff-many-attempts": {
+			retry:    TestStepRetry{Backoff: time.Second, MaxBackoff: time.Minute},
+			attempt:  100,
+			expected: time.Minute,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := testCase.retry.delay(testCase.attempt); got != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestRunTestStepWithRetry(t *testing.T) {
+	t.Parallel()
+
+	errTransient := errors.New("transient")
+	errPermanent := errors.New("permanent")
+	retryable := func(err *TestStepError) bool {
+		return errors.Is(err, errTransient)
+	}
+
+	testCases := map[string]struct {
+		retry            *TestStepRetry
+		errs             []error
+		expectedErr      error
+		expectedAttempts int
+	}{
+		"no-retry": {
+			errs:             []error{errTransient, nil},
+			expectedErr:      errTransient,
+			expectedAttempts: 1,
+		},
+		"success": {
+			retry:            &TestStepRetry{MaxAttempts: 3, Retryable: retryable},
+			errs:             []error{nil},
+			expectedAttempts: 1,
+		},
+		"success-after-retry": {
+			retry:            &TestStepRetry{MaxAttempts: 3, Retryable: retryable},
+			errs:             []error{errTransient, errTransient, nil},
+			expectedAttempts: 3,
+		},
+		"max-attempts": {
+			retry:            &TestStepRetry{MaxAttempts: 2, Retryable: retryable},
+			errs:             []error{errTransient, errTransient, nil},
+			expectedErr:      errTransient,
+			expectedAttempts: 2,
+		},
+		"not-retryable": {
+			retry:            &TestStepRetry{MaxAttempts: 3, Retryable: retryable},
+			errs:             []error{errTransient, errPermanent, nil},
+			expectedErr:      errPermanent,
+			expectedAttempts: 2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts, resets int
+
+			step := TestStep{Retry: testCase.retry}
+			c := TestCase{Steps: []TestStep{step}}
+
+			err := runTestStepWithRetry(context.Background(), t, c, step, 1, &plugintest.WorkingDir{},
+				func() error {
+					err := testCase.errs[attempts]
+					attempts++
+
+					return err
+				},
+				func() {
+					resets++
+				},
+			)
+
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Errorf("expected error %v, got: %v", testCase.expectedErr, err)
+			}
+
+			if attempts != testCase.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", testCase.expectedAttempts, attempts)
+			}
+
+			if resets != attempts-1 {
+				t.Errorf("expected %d resets, got %d", attempts-1, resets)
+			}
+		})
+	}
+}
+
+func TestRunTestStepWithRetry_CommandContextDone(t *testing.T) {
+	t.Parallel()
+
+	errTransient := errors.New("transient")
+
+	step := TestStep{
+		Retry: &TestStepRetry{
+			MaxAttempts: 3,
+			Backoff:     time.Hour,
+			Retryable:   func(*TestStepError) bool { return true },
+		},
+	}
+	c := TestCase{Steps: []TestStep{step}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	wd := &plugintest.WorkingDir{}
+	wd.SetCommandContext(ctx)
+
+	var attempts int
+
+	err := runTestStepWithRetry(context.Background(), t, c, step, 1, wd,
+		func() error {
+			attempts++
+
+			return errTransient
+		},
+		func() {},
+	)
+
+	if !errors.Is(err, errTransient) {
+		t.Errorf("expected error %v, got: %v", errTransient, err)
+	}
+
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
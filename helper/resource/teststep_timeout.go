@@ -0,0 +1,148 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+)
+
+const (
+	// defaultDestroyTimeout is the maximum time reserved for the post-test
+	// destroy when the TestCase DestroyTimeout is not set.
+	defaultDestroyTimeout = 5 * time.Minute
+
+	// destroyDeadlineGrace is the time before the test deadline at which the
+	// post-test destroy is cancelled, so that its error is reported before
+	// the go test timeout panic.
+	destroyDeadlineGrace = 10 * time.Second
+)
+
+// testDeadlines are the deadlines of a TestCase derived from the go test
+// -timeout flag. The zero value has no deadlines.
+type testDeadlines struct {
+	// test is the deadline of the test.
+	test time.Time
+
+	// steps is the deadline of the TestSteps, which is the test deadline
+	// less the time reserved for the post-test destroy.
+	steps time.Time
+}
+
+// newTestDeadlines returns the deadlines of the TestCase, if the test has a
+// deadline.
+func newTestDeadlines(t testing.T, c TestCase, now time.Time) testDeadlines {
+	// The go-testing-interface T does not include Deadline, which was added
+	// to the standard library testing.T in Go 1.15.
+	deadliner, ok := t.(interface{ Deadline() (time.Time, bool) })
+
+	if !ok {
+		return testDeadlines{}
+	}
+
+	deadline, ok := deadliner.Deadline()
+
+	if !ok {
+		return testDeadlines{}
+	}
+
+	return c.testDeadlines(deadline, now)
+}
+
+// testDeadlines returns the deadlines of the TestCase for the test deadline,
+// reserving the DestroyTimeout for the post-test destroy.
+func (c TestCase) testDeadlines(deadline time.Time, now time.Time) testDeadlines {
+	reserve := c.DestroyTimeout
+
+	if reserve <= 0 {
+		reserve = max(0, min(defaultDestroyTimeout, deadline.Sub(now)/4))
+	}
+
+	return testDeadlines{
+		test:  deadline,
+		steps: deadline.Add(-reserve),
+	}
+}
+
+// stepContext returns the context for the Terraform commands of the TestStep,
+// which is cancelled when the TestStep Timeout, or else the TestCase
+// StepTimeout, is reached or only the time reserved for the post-test destroy
+// remains. The cancellation is logged and reported in the test output. The
+// returned function must be called when the TestStep ends.
+func (d testDeadlines) stepContext(ctx context.Context, t testing.T, c TestCase, step TestStep, stepNumber int, now time.Time) (context.Context, context.CancelFunc) {
+	t.Helper()
+
+	timeout := step.Timeout
+
+	if timeout == 0 {
+		timeout = c.StepTimeout
+	}
+
+	var deadline time.Time
+
+	if timeout > 0 {
+		deadline = now.Add(timeout)
+	}
+
+	reachedTestDeadline := !d.steps.IsZero() && (deadline.IsZero() || d.steps.Before(deadline))
+
+	if reachedTestDeadline {
+		deadline = d.steps
+	}
+
+	if deadline.IsZero() {
+		return ctx, func() {}
+	}
+
+	commandCtx, cancel := context.WithDeadline(ctx, deadline)
+
+	reported := make(chan struct{})
+
+	stopReport := context.AfterFunc(commandCtx, func() {
+		defer close(reported)
+
+		if !errors.Is(commandCtx.Err(), context.DeadlineExceeded) {
+			return
+		}
+
+		elapsed := deadline.Sub(now).Round(time.Millisecond)
+
+		logging.HelperResourceWarn(ctx,
+			"TestStep timed out, interrupting Terraform CLI command",
+			map[string]interface{}{logging.KeyTestStepTimeout: elapsed.String()},
+		)
+
+		if reachedTestDeadline {
+			t.Logf("Step %d/%d cancelled after %s to reserve %s for the post-test destroy before the test deadline, interrupting Terraform command", stepNumber, len(c.Steps), elapsed, d.test.Sub(d.steps))
+
+			return
+		}
+
+		t.Logf("Step %d/%d timed out after %s, interrupting Terraform command", stepNumber, len(c.Steps), elapsed)
+	})
+
+	// Wait for a started report, so the test does not log after it ends.
+	return commandCtx, func() {
+		if !stopReport() {
+			<-reported
+		}
+
+		cancel()
+	}
+}
+
+// destroyContext returns the context for the Terraform commands of the
+// post-test destroy, which is cancelled shortly before the test deadline.
+func (d testDeadlines) destroyContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.test.IsZero() {
+		return ctx, func() {}
+	}
+
+	return context.WithDeadline(ctx, d.test.Add(-destroyDeadlineGrace))
+}
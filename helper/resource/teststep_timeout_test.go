@@ -0,0 +1,148 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTestCase_testDeadlines(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		testCase      TestCase
+		deadline      time.Time
+		expectedSteps time.Time
+	}{
+		"default-quarter": {
+			deadline:      now.Add(8 * time.Minute),
+			expectedSteps: now.Add(6 * time.Minute),
+		},
+		"default-maximum": {
+			deadline:      now.Add(time.Hour),
+			expectedSteps: now.Add(55 * time.Minute),
+		},
+		"default-passed": {
+			deadline:      now.Add(-time.Minute),
+			expectedSteps: now.Add(-time.Minute),
+		},
+		"destroytimeout": {
+			testCase:      TestCase{DestroyTimeout: 20 * time.Minute},
+			deadline:      now.Add(time.Hour),
+			expectedSteps: now.Add(40 * time.Minute),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.testCase.testDeadlines(testCase.deadline, now)
+
+			if !got.test.Equal(testCase.deadline) {
+				t.Errorf("expected test deadline %s, got: %s", testCase.deadline, got.test)
+			}
+
+			if !got.steps.Equal(testCase.expectedSteps) {
+				t.Errorf("expected steps deadline %s, got: %s", testCase.expectedSteps, got.steps)
+			}
+		})
+	}
+}
+
+func TestTestDeadlines_stepContext(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	testCases := map[string]struct {
+		deadlines        testDeadlines
+		testCase         TestCase
+		testStep         TestStep
+		expectedDeadline time.Time
+	}{
+		"none": {},
+		"teststep-timeout": {
+			testCase:         TestCase{StepTimeout: time.Hour},
+			testStep:         TestStep{Timeout: time.Minute},
+			expectedDeadline: now.Add(time.Minute),
+		},
+		"testcase-steptimeout": {
+			testCase:         TestCase{StepTimeout: time.Hour},
+			expectedDeadline: now.Add(time.Hour),
+		},
+		"steps-deadline": {
+			deadlines:        testDeadlines{test: now.Add(2 * time.Hour), steps: now.Add(time.Hour)},
+			expectedDeadline: now.Add(time.Hour),
+		},
+		"steps-deadline-before-timeout": {
+			deadlines:        testDeadlines{test: now.Add(2 * time.Hour), steps: now.Add(time.Hour)},
+			testStep:         TestStep{Timeout: 3 * time.Hour},
+			expectedDeadline: now.Add(time.Hour),
+		},
+		"timeout-before-steps-deadline": {
+			deadlines:        testDeadlines{test: now.Add(2 * time.Hour), steps: now.Add(time.Hour)},
+			testStep:         TestStep{Timeout: time.Minute},
+			expectedDeadline: now.Add(time.Minute),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testCase.testCase.Steps = []TestStep{testCase.testStep}
+
+			ctx, cancel := testCase.deadlines.stepContext(context.Background(), t, testCase.testCase, testCase.testStep, 1, now)
+			defer cancel()
+
+			got, ok := ctx.Deadline()
+
+			if ok != !testCase.expectedDeadline.IsZero() || !got.Equal(testCase.expectedDeadline) {
+				t.Errorf("expected deadline %s, got: %s", testCase.expectedDeadline, got)
+			}
+		})
+	}
+}
+
+func TestTestDeadlines_stepContext_cancelled(t *testing.T) {
+	t.Parallel()
+
+	c := TestCase{Steps: []TestStep{{Timeout: time.Millisecond}}}
+
+	ctx, cancel := testDeadlines{}.stepContext(context.Background(), t, c, c.Steps[0], 1, time.Now())
+	defer cancel()
+
+	<-ctx.Done()
+
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got: %v", ctx.Err())
+	}
+}
+
+func TestTestDeadlines_destroyContext(t *testing.T) {
+	t.Parallel()
+
+	deadline := time.Now().Add(time.Hour)
+
+	ctx, cancel := testDeadlines{test: deadline, steps: deadline.Add(-time.Minute)}.destroyContext(context.Background())
+	defer cancel()
+
+	got, ok := ctx.Deadline()
+
+	if !ok || !got.Equal(deadline.Add(-destroyDeadlineGrace)) {
+		t.Errorf("expected deadline %s, got: %s", deadline.Add(-destroyDeadlineGrace), got)
+	}
+
+	ctx, cancel = testDeadlines{}.destroyContext(context.Background())
+	defer cancel()
+
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline")
+	}
+}
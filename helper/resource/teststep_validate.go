@@ -6,6 +6,7 @@ package resource
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
@@ -93,7 +94,7 @@ func (s TestStep) hasProviders(ctx context.Context, stepIndex int, testName stri
 
 // validate ensures the TestStep is valid based on the following criteria:
 //
-//   - Config or ImportState or RefreshState is set.
+//   - Config or ImportState or RefreshState or Drift or Disappears is set.
 //   - Config and RefreshState are not both set.
 //   - RefreshState and Destroy are not both set.
 //   - RefreshState is not the first TestStep.
@@ -109,13 +110,55 @@ func (s TestStep) hasProviders(ctx context.Context, stepIndex int, testName stri
 //   - ConfigPlanChecks (PreApply, PostApplyPreRefresh, PostApplyPostRefresh) are only set when Config is set.
 //   - ConfigPlanChecks.PreApply are only set when PlanOnly is false.
 //   - RefreshPlanChecks (PostRefresh) are only set when RefreshState is set.
+//   - Drift is not set with Config, ImportState, or RefreshState.
+//   - Drift is not the first TestStep.
+//   - DriftPlanChecks (PostRefresh) are only set when Drift is set.
+//   - Disappears is not set with Config, ImportState, RefreshState, or Drift.
+//   - Disappears is not the first TestStep.
+//   - MovedFrom and MovedTo are set together.
+//   - MovedFrom is not set with ImportState, RefreshState, Drift, or
+//     Disappears.
+//   - MovedFrom is not the first TestStep.
+//   - MovedFrom is only set when Config is set.
+//   - MovedPlanChecks (PreApply) and MovedStateChecks are only set when
+//     MovedFrom is set.
+//   - ConfigPlanChecks and ConfigStateChecks are not set when MovedFrom is
+//     set.
+//   - ResourceName is not empty when Forget is true.
+//   - ResourceName is a root module managed resource address without an
+//     instance key, such as examplecloud_thing.test, when Forget is true.
+//   - Forget is not set with ImportState, RefreshState, Drift, Disappears,
+//     or MovedFrom.
+//   - Forget is not the first TestStep.
+//   - ForgetPlanChecks (PreApply) and ForgetCheck are only set when Forget
+//     is true.
+//   - ConfigPlanChecks and ConfigStateChecks are not set when Forget is
+//     true.
+//   - Targets and Replace are only set when Config is set, and not with
+//     ImportState, MovedFrom, Forget, or PlanOnly.
+//   - Replace and Destroy are not both set.
+//   - SkipTargetedPostApplyPlans is only set when Targets is set, and not
+//     with ConfigPlanChecks (PostApplyPreRefresh, PostApplyPostRefresh).
+//   - ExpectErrorPlanChecks (PostError) and ExpectErrorStateChecks are only
+//     set when Config and either ExpectError or ExpectDiagnostics are set.
+//   - ExpectDiagnostics and ExpectError are not both set.
+//   - AllowedWarnings are only set when ExpectNoWarnings is true.
+//   - ProviderFaults have an RPC and Action, and a Call which is not
+//     negative.
+//   - EphemeralChecks are only set when Config is set, and not with
+//     ImportState, RefreshState, MovedFrom, Forget, Query, or StateStore.
+//   - Retry is only set when Config is set, and not with ImportState,
+//     RefreshState, Drift, Disappears, MovedFrom, Forget, Query, StateStore,
+//     ExpectError, or ExpectDiagnostics. Retry MaxAttempts is at least 1 and
+//     Retryable is set.
+//   - Timeout is not negative.
 func (s TestStep) validate(ctx context.Context, req testStepValidateRequest) error {
 	ctx = logging.TestStepNumberContext(ctx, req.StepNumber)
 
 	logging.HelperResourceTrace(ctx, "Validating TestStep")
 
-	if req.StepConfiguration == nil && !s.ImportState && !s.RefreshState {
-		err := fmt.Errorf("TestStep missing Config or ConfigDirectory or ConfigFile or ImportState or RefreshState")
+	if req.StepConfiguration == nil && !s.ImportState && !s.RefreshState && s.Drift == nil && s.Disappears == "" && s.MovedFrom == "" && !s.Forget {
+		err := fmt.Errorf("TestStep missing Config or ConfigDirectory or ConfigFile or ImportState or RefreshState or Drift or Disappears or MovedFrom or Forget")
 		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
 		return err
 	}
@@ -241,17 +284,249 @@ func (s TestStep) validate(ctx context.Context, req testStepValidateRequest) err
 		return err
 	}
 
+	if len(s.EphemeralChecks) > 0 && (req.StepConfiguration == nil || s.ImportState || s.RefreshState || s.MovedFrom != "" || s.Forget || s.Query || s.StateStore) {
+		err := fmt.Errorf("TestStep EphemeralChecks must only be specified with Config, ConfigDirectory or ConfigFile, and not with ImportState, RefreshState, MovedFrom, Forget, Query, or StateStore")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.Retry != nil {
+		if req.StepConfiguration == nil || s.ImportState || s.RefreshState || s.Drift != nil || s.Disappears != "" || s.MovedFrom != "" || s.Forget || s.Query || s.StateStore {
+			err := fmt.Errorf("TestStep Retry must only be specified with Config, ConfigDirectory or ConfigFile, and not with ImportState, RefreshState, Drift, Disappears, MovedFrom, Forget, Query, or StateStore")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if s.ExpectError != nil || len(s.ExpectDiagnostics) > 0 {
+			err := fmt.Errorf("TestStep Retry must not be specified with ExpectError or ExpectDiagnostics")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if s.Retry.MaxAttempts < 1 || s.Retry.Retryable == nil {
+			err := fmt.Errorf("TestStep Retry must specify MaxAttempts of at least 1 and Retryable")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+	}
+
+	if s.Timeout < 0 {
+		err := fmt.Errorf("TestStep Timeout must not be negative")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
 	if len(s.RefreshPlanChecks.PostRefresh) > 0 && !s.RefreshState {
 		err := fmt.Errorf("TestStep RefreshPlanChecks.PostRefresh must only be specified with RefreshState")
 		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
 		return err
 	}
 
+	if s.Drift != nil {
+		if req.StepConfiguration != nil {
+			err := fmt.Errorf("TestStep cannot have Config or ConfigDirectory or ConfigFile and Drift")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if s.ImportState || s.RefreshState {
+			err := fmt.Errorf("TestStep cannot have Drift and ImportState or RefreshState in same step")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if req.StepNumber == 1 {
+			err := fmt.Errorf("TestStep cannot have Drift as first step")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+	}
+
+	if len(s.DriftPlanChecks.PostRefresh) > 0 && s.Drift == nil {
+		err := fmt.Errorf("TestStep DriftPlanChecks.PostRefresh must only be specified with Drift")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.Disappears != "" {
+		if req.StepConfiguration != nil {
+			err := fmt.Errorf("TestStep cannot have Config or ConfigDirectory or ConfigFile and Disappears")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if s.ImportState || s.RefreshState || s.Drift != nil {
+			err := fmt.Errorf("TestStep cannot have Disappears and ImportState, RefreshState, or Drift in same step")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if req.StepNumber == 1 {
+			err := fmt.Errorf("TestStep cannot have Disappears as first step")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+	}
+
+	if (s.MovedFrom == "") != (s.MovedTo == "") {
+		err := fmt.Errorf("TestStep MovedFrom and MovedTo must be specified together")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.MovedFrom != "" {
+		if s.ImportState || s.RefreshState || s.Drift != nil || s.Disappears != "" {
+			err := fmt.Errorf("TestStep cannot have MovedFrom and ImportState, RefreshState, Drift, or Disappears in same step")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if req.StepNumber == 1 {
+			err := fmt.Errorf("TestStep cannot have MovedFrom as first step")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if req.StepConfiguration == nil {
+			err := fmt.Errorf("TestStep MovedFrom must be specified with Config, ConfigDirectory or ConfigFile declaring the resource at the MovedTo address")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if len(s.ConfigPlanChecks.PreApply) > 0 || len(s.ConfigPlanChecks.PostApplyPreRefresh) > 0 || len(s.ConfigPlanChecks.PostApplyPostRefresh) > 0 || len(s.ConfigStateChecks) > 0 {
+			err := fmt.Errorf("TestStep ConfigPlanChecks and ConfigStateChecks cannot be specified with MovedFrom, use MovedPlanChecks and MovedStateChecks instead")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+	}
+
+	if (len(s.MovedPlanChecks.PreApply) > 0 || len(s.MovedStateChecks) > 0) && s.MovedFrom == "" {
+		err := fmt.Errorf("TestStep MovedPlanChecks and MovedStateChecks must only be specified with MovedFrom and MovedTo")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.Forget {
+		if s.ResourceName == "" {
+			err := fmt.Errorf("TestStep Forget must be specified with ResourceName")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		// Removed blocks cannot include instance keys and only the resource
+		// blocks of the root module configuration are removed.
+		resourceType, resourceName, ok := strings.Cut(s.ResourceName, ".")
+
+		if !ok || resourceType == "module" || resourceType == "data" || strings.ContainsAny(resourceName, ".[") {
+			err := fmt.Errorf("TestStep Forget ResourceName must be a root module managed resource address without an instance key, such as examplecloud_thing.test, got: %s", s.ResourceName)
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if s.ImportState || s.RefreshState || s.Drift != nil || s.Disappears != "" || s.MovedFrom != "" {
+			err := fmt.Errorf("TestStep cannot have Forget and ImportState, RefreshState, Drift, Disappears, or MovedFrom in same step")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if req.StepNumber == 1 {
+			err := fmt.Errorf("TestStep cannot have Forget as first step")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if len(s.ConfigPlanChecks.PreApply) > 0 || len(s.ConfigPlanChecks.PostApplyPreRefresh) > 0 || len(s.ConfigPlanChecks.PostApplyPostRefresh) > 0 || len(s.ConfigStateChecks) > 0 {
+			err := fmt.Errorf("TestStep ConfigPlanChecks and ConfigStateChecks cannot be specified with Forget, use ForgetPlanChecks and ForgetCheck instead")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+	}
+
+	if (len(s.ForgetPlanChecks.PreApply) > 0 || s.ForgetCheck != nil) && !s.Forget {
+		err := fmt.Errorf("TestStep ForgetPlanChecks and ForgetCheck must only be specified with Forget")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if len(s.Targets) > 0 || len(s.Replace) > 0 {
+		if req.StepConfiguration == nil || s.ImportState || s.MovedFrom != "" || s.Forget {
+			err := fmt.Errorf("TestStep Targets and Replace must only be specified with Config, ConfigDirectory or ConfigFile")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if s.PlanOnly {
+			err := fmt.Errorf("TestStep Targets and Replace cannot be run with PlanOnly")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+	}
+
+	if len(s.Replace) > 0 && s.Destroy {
+		err := fmt.Errorf("TestStep cannot have Replace and Destroy")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.SkipTargetedPostApplyPlans {
+		if len(s.Targets) == 0 {
+			err := fmt.Errorf("TestStep SkipTargetedPostApplyPlans must only be specified with Targets")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if len(s.ConfigPlanChecks.PostApplyPreRefresh) > 0 || len(s.ConfigPlanChecks.PostApplyPostRefresh) > 0 {
+			err := fmt.Errorf("TestStep ConfigPlanChecks.PostApplyPreRefresh and ConfigPlanChecks.PostApplyPostRefresh cannot be run with SkipTargetedPostApplyPlans")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+	}
+
+	if s.hasExpectErrorChecks() {
+		if req.StepConfiguration == nil || s.ImportState || s.MovedFrom != "" || s.Forget || s.Query || s.StateStore {
+			err := fmt.Errorf("TestStep ExpectErrorPlanChecks and ExpectErrorStateChecks must only be specified with Config, ConfigDirectory or ConfigFile")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if s.ExpectError == nil && len(s.ExpectDiagnostics) == 0 {
+			err := fmt.Errorf("TestStep ExpectErrorPlanChecks and ExpectErrorStateChecks must only be specified with ExpectError or ExpectDiagnostics")
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+	}
+
 	if len(s.ConfigStateChecks) > 0 && req.StepConfiguration == nil {
 		err := fmt.Errorf("TestStep ConfigStateChecks must only be specified with Config, ConfigDirectory or ConfigFile")
 		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
 		return err
 	}
 
+	if len(s.ExpectDiagnostics) > 0 && s.ExpectError != nil {
+		err := fmt.Errorf("TestStep cannot have ExpectDiagnostics and ExpectError")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if len(s.AllowedWarnings) > 0 && !s.ExpectNoWarnings {
+		err := fmt.Errorf("TestStep AllowedWarnings must only be specified with ExpectNoWarnings")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	for i, fault := range s.ProviderFaults {
+		if fault.RPC == "" || fault.Action == nil {
+			err := fmt.Errorf("TestStep ProviderFaults[%d] must specify RPC and Action", i)
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		if fault.Call < 0 {
+			err := fmt.Errorf("TestStep ProviderFaults[%d] Call must not be negative", i)
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+	}
+
 	return nil
 }
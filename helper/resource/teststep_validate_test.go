@@ -7,16 +7,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 
 	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+	"github.com/hashicorp/terraform-plugin-testing/ephemeralcheck"
 	"github.com/hashicorp/terraform-plugin-testing/internal/teststep"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/providerfault"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -135,7 +141,7 @@ func TestTestStepValidate(t *testing.T) {
 		"config-and-importstate-and-refreshstate-missing": {
 			testStep:                TestStep{},
 			testStepValidateRequest: testStepValidateRequest{},
-			expectedError:           fmt.Errorf("TestStep missing Config or ConfigDirectory or ConfigFile or ImportState or RefreshState"),
+			expectedError:           fmt.Errorf("TestStep missing Config or ConfigDirectory or ConfigFile or ImportState or RefreshState or Drift or Disappears or MovedFrom or Forget"),
 		},
 		"config-and-refreshstate-both-set": {
 			testStep: TestStep{
@@ -482,6 +488,76 @@ func TestTestStepValidate(t *testing.T) {
 			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
 			expectedError:           errors.New("TestStep ConfigStateChecks must only be specified with Config"),
 		},
+		"ephemeralchecks-not-config-mode": {
+			testStep: TestStep{
+				EphemeralChecks: []ephemeralcheck.EphemeralCheck{
+					ephemeralcheck.ExpectLifecycle("test_token"),
+				},
+				RefreshState: true,
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep EphemeralChecks must only be specified with Config, ConfigDirectory or ConfigFile, and not with ImportState, RefreshState, MovedFrom, Forget, Query, or StateStore"),
+		},
+		"ephemeralchecks": {
+			testStep: TestStep{
+				EphemeralChecks: []ephemeralcheck.EphemeralCheck{
+					ephemeralcheck.ExpectLifecycle("test_token"),
+				},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+		},
+		"retry-not-config-mode": {
+			testStep: TestStep{
+				RefreshState: true,
+				Retry: &TestStepRetry{
+					MaxAttempts: 2,
+					Retryable:   func(*TestStepError) bool { return true },
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep Retry must only be specified with Config, ConfigDirectory or ConfigFile, and not with ImportState, RefreshState, Drift, Disappears, MovedFrom, Forget, Query, or StateStore"),
+		},
+		"retry-expecterror": {
+			testStep: TestStep{
+				ExpectError: regexp.MustCompile("error"),
+				Retry: &TestStepRetry{
+					MaxAttempts: 2,
+					Retryable:   func(*TestStepError) bool { return true },
+				},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           errors.New("TestStep Retry must not be specified with ExpectError or ExpectDiagnostics"),
+		},
+		"retry-missing-retryable": {
+			testStep: TestStep{
+				Retry: &TestStepRetry{
+					MaxAttempts: 2,
+				},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           errors.New("TestStep Retry must specify MaxAttempts of at least 1 and Retryable"),
+		},
+		"retry": {
+			testStep: TestStep{
+				Retry: &TestStepRetry{
+					MaxAttempts: 2,
+					Retryable:   func(*TestStepError) bool { return true },
+				},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+		},
+		"timeout-negative": {
+			testStep: TestStep{
+				Timeout: -time.Second,
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           errors.New("TestStep Timeout must not be negative"),
+		},
 		"refreshplanchecks-postrefresh-not-refresh-mode": {
 			testStep: TestStep{
 				RefreshPlanChecks: RefreshPlanChecks{
@@ -517,7 +593,7 @@ func TestTestStepValidate(t *testing.T) {
 				StateStore: true,
 			},
 			testStepValidateRequest: testStepValidateRequest{},
-			expectedError:           fmt.Errorf("TestStep missing Config or ConfigDirectory or ConfigFile or ImportState or RefreshState"),
+			expectedError:           fmt.Errorf("TestStep missing Config or ConfigDirectory or ConfigFile or ImportState or RefreshState or Drift or Disappears or MovedFrom or Forget"),
 		},
 		"verify-state-store-without-state-store-mode": {
 			testStep: TestStep{
@@ -527,6 +603,386 @@ func TestTestStepValidate(t *testing.T) {
 			testStepValidateRequest: testStepValidateRequest{},
 			expectedError:           fmt.Errorf("TestStep StateStore field must be set to true when VerifyStateStoreLock is true"),
 		},
+		"drift-and-config-both-set": {
+			testStep: TestStep{
+				Drift: func(context.Context, DriftRequest) error { return nil },
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep cannot have Config or ConfigDirectory or ConfigFile and Drift"),
+		},
+		"drift-and-refreshstate-both-set": {
+			testStep: TestStep{
+				Drift:        func(context.Context, DriftRequest) error { return nil },
+				RefreshState: true,
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep cannot have Drift and ImportState or RefreshState in same step"),
+		},
+		"drift-first-step": {
+			testStep: TestStep{
+				Drift: func(context.Context, DriftRequest) error { return nil },
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 1},
+			expectedError:           errors.New("TestStep cannot have Drift as first step"),
+		},
+		"drift-valid": {
+			testStep: TestStep{
+				Drift: func(context.Context, DriftRequest) error { return nil },
+				DriftPlanChecks: DriftPlanChecks{
+					PostRefresh: []plancheck.PlanCheck{&planCheckSpy{}},
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+		},
+		"driftplanchecks-postrefresh-not-drift-mode": {
+			testStep: TestStep{
+				DriftPlanChecks: DriftPlanChecks{
+					PostRefresh: []plancheck.PlanCheck{&planCheckSpy{}},
+				},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           errors.New("TestStep DriftPlanChecks.PostRefresh must only be specified with Drift"),
+		},
+		"disappears-and-config-both-set": {
+			testStep: TestStep{
+				Disappears: "test_resource.test",
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep cannot have Config or ConfigDirectory or ConfigFile and Disappears"),
+		},
+		"disappears-and-drift-both-set": {
+			testStep: TestStep{
+				Disappears: "test_resource.test",
+				Drift:      func(context.Context, DriftRequest) error { return nil },
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep cannot have Disappears and ImportState, RefreshState, or Drift in same step"),
+		},
+		"disappears-first-step": {
+			testStep: TestStep{
+				Disappears: "test_resource.test",
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 1},
+			expectedError:           errors.New("TestStep cannot have Disappears as first step"),
+		},
+		"disappears-valid": {
+			testStep: TestStep{
+				Disappears: "test_resource.test",
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+		},
+		"movedfrom-without-movedto": {
+			testStep: TestStep{
+				MovedFrom: "test_resource.one",
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep MovedFrom and MovedTo must be specified together"),
+		},
+		"movedfrom-and-importstate-both-set": {
+			testStep: TestStep{
+				MovedFrom:    "test_resource.one",
+				MovedTo:      "test_resource.two",
+				ImportState:  true,
+				ResourceName: "test_resource.two",
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep cannot have MovedFrom and ImportState, RefreshState, Drift, or Disappears in same step"),
+		},
+		"movedfrom-first-step": {
+			testStep: TestStep{
+				MovedFrom: "test_resource.one",
+				MovedTo:   "test_resource.two",
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 1},
+			expectedError:           errors.New("TestStep cannot have MovedFrom as first step"),
+		},
+		"movedfrom-without-config": {
+			testStep: TestStep{
+				MovedFrom: "test_resource.one",
+				MovedTo:   "test_resource.two",
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep MovedFrom must be specified with Config, ConfigDirectory or ConfigFile declaring the resource at the MovedTo address"),
+		},
+		"movedfrom-and-configstatechecks-both-set": {
+			testStep: TestStep{
+				MovedFrom:         "test_resource.one",
+				MovedTo:           "test_resource.two",
+				ConfigStateChecks: []statecheck.StateCheck{&stateCheckSpy{}},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep ConfigPlanChecks and ConfigStateChecks cannot be specified with MovedFrom, use MovedPlanChecks and MovedStateChecks instead"),
+		},
+		"movedstatechecks-without-movedfrom": {
+			testStep: TestStep{
+				MovedStateChecks: []statecheck.StateCheck{&stateCheckSpy{}},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep MovedPlanChecks and MovedStateChecks must only be specified with MovedFrom and MovedTo"),
+		},
+		"movedfrom-valid": {
+			testStep: TestStep{
+				MovedFrom: "test_resource.one",
+				MovedTo:   "test_resource.two",
+				MovedPlanChecks: MovedPlanChecks{
+					PreApply: []plancheck.PlanCheck{&planCheckSpy{}},
+				},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+		},
+		"forget-without-resourcename": {
+			testStep: TestStep{
+				Forget: true,
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep Forget must be specified with ResourceName"),
+		},
+		"forget-resourcename-module": {
+			testStep: TestStep{
+				Forget:       true,
+				ResourceName: "module.test.test_resource.test",
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep Forget ResourceName must be a root module managed resource address without an instance key, such as examplecloud_thing.test, got: module.test.test_resource.test"),
+		},
+		"forget-resourcename-instance-key": {
+			testStep: TestStep{
+				Forget:       true,
+				ResourceName: "test_resource.test[0]",
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep Forget ResourceName must be a root module managed resource address without an instance key, such as examplecloud_thing.test, got: test_resource.test[0]"),
+		},
+		"forget-resourcename-data-source": {
+			testStep: TestStep{
+				Forget:       true,
+				ResourceName: "data.test_resource.test",
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep Forget ResourceName must be a root module managed resource address without an instance key, such as examplecloud_thing.test, got: data.test_resource.test"),
+		},
+		"forget-and-refreshstate-both-set": {
+			testStep: TestStep{
+				Forget:       true,
+				ResourceName: "test_resource.test",
+				RefreshState: true,
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep cannot have Forget and ImportState, RefreshState, Drift, Disappears, or MovedFrom in same step"),
+		},
+		"forget-first-step": {
+			testStep: TestStep{
+				Forget:       true,
+				ResourceName: "test_resource.test",
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 1},
+			expectedError:           errors.New("TestStep cannot have Forget as first step"),
+		},
+		"forget-and-configplanchecks-both-set": {
+			testStep: TestStep{
+				Forget:       true,
+				ResourceName: "test_resource.test",
+				ConfigPlanChecks: ConfigPlanChecks{
+					PostApplyPreRefresh: []plancheck.PlanCheck{&planCheckSpy{}},
+				},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep ConfigPlanChecks and ConfigStateChecks cannot be specified with Forget, use ForgetPlanChecks and ForgetCheck instead"),
+		},
+		"forgetcheck-without-forget": {
+			testStep: TestStep{
+				ForgetCheck: func(*terraform.State) error { return nil },
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep ForgetPlanChecks and ForgetCheck must only be specified with Forget"),
+		},
+		"forget-valid": {
+			testStep: TestStep{
+				Forget:       true,
+				ResourceName: "test_resource.test",
+				ForgetPlanChecks: ForgetPlanChecks{
+					PreApply: []plancheck.PlanCheck{&planCheckSpy{}},
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+		},
+		"targets-without-config": {
+			testStep: TestStep{
+				RefreshState: true,
+				Targets:      []string{"test_resource.test"},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep Targets and Replace must only be specified with Config, ConfigDirectory or ConfigFile"),
+		},
+		"replace-and-planonly-both-set": {
+			testStep: TestStep{
+				PlanOnly: true,
+				Replace:  []string{"test_resource.test"},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           errors.New("TestStep Targets and Replace cannot be run with PlanOnly"),
+		},
+		"replace-and-destroy-both-set": {
+			testStep: TestStep{
+				Destroy: true,
+				Replace: []string{"test_resource.test"},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           errors.New("TestStep cannot have Replace and Destroy"),
+		},
+		"skiptargetedpostapplyplans-without-targets": {
+			testStep: TestStep{
+				Replace:                    []string{"test_resource.test"},
+				SkipTargetedPostApplyPlans: true,
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           errors.New("TestStep SkipTargetedPostApplyPlans must only be specified with Targets"),
+		},
+		"skiptargetedpostapplyplans-and-postapply-planchecks-both-set": {
+			testStep: TestStep{
+				Targets:                    []string{"test_resource.test"},
+				SkipTargetedPostApplyPlans: true,
+				ConfigPlanChecks: ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{&planCheckSpy{}},
+				},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           errors.New("TestStep ConfigPlanChecks.PostApplyPreRefresh and ConfigPlanChecks.PostApplyPostRefresh cannot be run with SkipTargetedPostApplyPlans"),
+		},
+		"targets-valid": {
+			testStep: TestStep{
+				Targets:                    []string{"test_resource.test"},
+				Replace:                    []string{"test_resource.test"},
+				SkipTargetedPostApplyPlans: true,
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+		},
+		"expecterrorstatechecks-without-config": {
+			testStep: TestStep{
+				RefreshState:           true,
+				ExpectError:            regexp.MustCompile("error"),
+				ExpectErrorStateChecks: []statecheck.StateCheck{&stateCheckSpy{}},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true, StepNumber: 2},
+			expectedError:           errors.New("TestStep ExpectErrorPlanChecks and ExpectErrorStateChecks must only be specified with Config, ConfigDirectory or ConfigFile"),
+		},
+		"expecterrorplanchecks-without-expecterror": {
+			testStep: TestStep{
+				ExpectErrorPlanChecks: ExpectErrorPlanChecks{
+					PostError: []plancheck.PlanCheck{&planCheckSpy{}},
+				},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           errors.New("TestStep ExpectErrorPlanChecks and ExpectErrorStateChecks must only be specified with ExpectError or ExpectDiagnostics"),
+		},
+		"expecterrorchecks-valid": {
+			testStep: TestStep{
+				ExpectDiagnostics: []diagcheck.DiagnosticCheck{diagcheck.ExpectDiagnostic(diagcheck.DiagnosticMatcher{})},
+				ExpectErrorPlanChecks: ExpectErrorPlanChecks{
+					PostError: []plancheck.PlanCheck{&planCheckSpy{}},
+				},
+				ExpectErrorStateChecks: []statecheck.StateCheck{&stateCheckSpy{}},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+		},
+		"expectdiagnostics-and-expecterror-both-set": {
+			testStep: TestStep{
+				ExpectDiagnostics: []diagcheck.DiagnosticCheck{diagcheck.ExpectDiagnostic(diagcheck.DiagnosticMatcher{})},
+				ExpectError:       regexp.MustCompile("error"),
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           errors.New("TestStep cannot have ExpectDiagnostics and ExpectError"),
+		},
+		"expectdiagnostics-importstate-import-command": {
+			testStep: TestStep{
+				ExpectDiagnostics: []diagcheck.DiagnosticCheck{diagcheck.ExpectDiagnostic(diagcheck.DiagnosticMatcher{})},
+				ImportState:       true,
+				ResourceName:      "test_resource.test",
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+		},
+		"expectwarnings-importstate-import-command": {
+			testStep: TestStep{
+				ExpectWarnings: []diagcheck.DiagnosticMatcher{{}},
+				ImportState:    true,
+				ResourceName:   "test_resource.test",
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+		},
+		"allowedwarnings-without-expectnowarnings": {
+			testStep: TestStep{
+				AllowedWarnings: []diagcheck.DiagnosticMatcher{{}},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           errors.New("TestStep AllowedWarnings must only be specified with ExpectNoWarnings"),
+		},
+		"providerfaults-missing-action": {
+			testStep: TestStep{
+				ProviderFaults: []providerfault.Fault{
+					{
+						RPC: "ApplyResourceChange",
+					},
+				},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           errors.New("TestStep ProviderFaults[0] must specify RPC and Action"),
+		},
+		"providerfaults-negative-call": {
+			testStep: TestStep{
+				ProviderFaults: []providerfault.Fault{
+					{
+						RPC:    "ApplyResourceChange",
+						Call:   -1,
+						Action: providerfault.DropNewState(),
+					},
+				},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           errors.New("TestStep ProviderFaults[0] Call must not be negative"),
+		},
+		"providerfaults": {
+			testStep: TestStep{
+				ProviderFaults: []providerfault.Fault{
+					{
+						RPC:    "ApplyResourceChange",
+						Call:   2,
+						Action: providerfault.ErrorDiagnostic("summary", "detail"),
+					},
+				},
+			},
+			testStepConfig:          "# not empty",
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+		},
+		"expectdiagnostics-importstate-import-block": {
+			testStep: TestStep{
+				ExpectDiagnostics: []diagcheck.DiagnosticCheck{diagcheck.ExpectDiagnostic(diagcheck.DiagnosticMatcher{})},
+				ImportState:       true,
+				ImportStateKind:   ImportBlockWithID,
+				ResourceName:      "test_resource.test",
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+		},
 	}
 
 	for name, test := range tests {
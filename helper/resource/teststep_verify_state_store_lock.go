@@ -31,7 +31,7 @@ import (
 //
 // This method also indirectly tests that workspaces and reading/writing state work properly, but testStepNewStateStore should be run prior
 // to verify that logic.
-func testStepVerifyStateStoreLock(ctx context.Context, t testing.T, step TestStep, providers *providerFactories, stateStoreCfg teststep.Config, helper *plugintest.Helper) error {
+func testStepVerifyStateStoreLock(ctx context.Context, t testing.T, step TestStep, providers *providerFactories, stateStoreCfg teststep.Config, helper *plugintest.Helper, commandCtx context.Context) error {
 	t.Helper()
 
 	// ----- Initialize TF working directory with a single resource that will pause during the apply operation until we indicate it can complete.
@@ -41,6 +41,7 @@ func testStepVerifyStateStoreLock(ctx context.Context, t testing.T, step TestSte
 	pauseCfg := stateStoreCfg.Append(`resource "tfplugintesting_pause" "resource" {}`)
 
 	pauseWorkingDir := helper.RequireNewWorkingDir(ctx, t, "")
+	pauseWorkingDir.SetCommandContext(commandCtx)
 	defer pauseWorkingDir.Close()
 
 	err := pauseWorkingDir.SetConfig(ctx, pauseCfg, step.ConfigVariables)
@@ -71,7 +72,7 @@ func testStepVerifyStateStoreLock(ctx context.Context, t testing.T, step TestSte
 	}()
 
 	// Attempt to acquire lock with new client
-	err = assertClientCannotAcquireLock(ctx, t, step, providers, stateStoreCfg, helper)
+	err = assertClientCannotAcquireLock(ctx, t, step, providers, stateStoreCfg, helper, commandCtx)
 	if err != nil {
 		return fmt.Errorf("Failed client lock assertion: %w", err)
 	}
@@ -131,8 +132,9 @@ func testStepVerifyStateStoreLock(ctx context.Context, t testing.T, step TestSte
 // assertClientCannotAcquireLock will create a new client working directory, then attempt to apply
 // to the "default" workspace (i.e. indicating it could successfully acquire a lock). If the client is able to
 // successfully apply, or receives an error message that is not related to acquiring the lock, the assertion will fail.
-func assertClientCannotAcquireLock(ctx context.Context, t testing.T, step TestStep, providers *providerFactories, stateStoreCfg teststep.Config, helper *plugintest.Helper) error {
+func assertClientCannotAcquireLock(ctx context.Context, t testing.T, step TestStep, providers *providerFactories, stateStoreCfg teststep.Config, helper *plugintest.Helper, commandCtx context.Context) error {
 	clientWorkingDir := helper.RequireNewWorkingDir(ctx, t, "")
+	clientWorkingDir.SetCommandContext(commandCtx)
 	defer clientWorkingDir.Close()
 	err := clientWorkingDir.SetConfig(ctx, stateStoreCfg, step.ConfigVariables)
 	if err != nil {
@@ -0,0 +1,52 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package checkresult
+
+import (
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Find returns the status and instances of the check result with the given
+// address, which can be the address of a checkable object or one of its
+// instances. It returns false if there is no check result for the address.
+func Find(checks []tfjson.CheckResultStatic, address string) (tfjson.CheckStatus, []tfjson.CheckResultDynamic, bool) {
+	for _, result := range checks {
+		if result.Address.ToDisplay == address {
+			return result.Status, result.Instances, true
+		}
+
+		for _, instance := range result.Instances {
+			if instance.Address.ToDisplay == address {
+				return instance.Status, []tfjson.CheckResultDynamic{instance}, true
+			}
+		}
+	}
+
+	return "", nil, false
+}
+
+// StatusError returns an error including any problem messages if the check
+// status does not match the expected status.
+func StatusError(address string, expected tfjson.CheckStatus, got tfjson.CheckStatus, instances []tfjson.CheckResultDynamic) error {
+	if expected == got {
+		return nil
+	}
+
+	var problems []string
+
+	for _, instance := range instances {
+		for _, problem := range instance.Problems {
+			problems = append(problems, problem.Message)
+		}
+	}
+
+	if len(problems) == 0 {
+		return fmt.Errorf("%s - expected check status %s, got: %s", address, expected, got)
+	}
+
+	return fmt.Errorf("%s - expected check status %s, got: %s\n\n%s", address, expected, got, strings.Join(problems, "\n"))
+}
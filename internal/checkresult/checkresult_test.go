@@ -0,0 +1,132 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package checkresult_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/checkresult"
+)
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	instance := tfjson.CheckResultDynamic{
+		Address: tfjson.CheckDynamicAddress{
+			ToDisplay: "test_resource.test[0]",
+		},
+		Status: tfjson.CheckStatusFail,
+	}
+
+	checks := []tfjson.CheckResultStatic{
+		{
+			Address: tfjson.CheckStaticAddress{
+				ToDisplay: "test_resource.test",
+			},
+			Status:    tfjson.CheckStatusFail,
+			Instances: []tfjson.CheckResultDynamic{instance},
+		},
+	}
+
+	testCases := map[string]struct {
+		address           string
+		expectedStatus    tfjson.CheckStatus
+		expectedInstances []tfjson.CheckResultDynamic
+		expectedFound     bool
+	}{
+		"object": {
+			address:           "test_resource.test",
+			expectedStatus:    tfjson.CheckStatusFail,
+			expectedInstances: []tfjson.CheckResultDynamic{instance},
+			expectedFound:     true,
+		},
+		"instance": {
+			address:           "test_resource.test[0]",
+			expectedStatus:    tfjson.CheckStatusFail,
+			expectedInstances: []tfjson.CheckResultDynamic{instance},
+			expectedFound:     true,
+		},
+		"not-found": {
+			address: "test_resource.other",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			status, instances, found := checkresult.Find(checks, testCase.address)
+
+			if status != testCase.expectedStatus {
+				t.Errorf("expected status %q, got %q", testCase.expectedStatus, status)
+			}
+
+			if diff := cmp.Diff(testCase.expectedInstances, instances); diff != "" {
+				t.Errorf("unexpected instances difference: %s", diff)
+			}
+
+			if found != testCase.expectedFound {
+				t.Errorf("expected found %t, got %t", testCase.expectedFound, found)
+			}
+		})
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		expected      tfjson.CheckStatus
+		got           tfjson.CheckStatus
+		instances     []tfjson.CheckResultDynamic
+		expectedError error
+	}{
+		"match": {
+			expected: tfjson.CheckStatusPass,
+			got:      tfjson.CheckStatusPass,
+		},
+		"mismatch": {
+			expected:      tfjson.CheckStatusPass,
+			got:           tfjson.CheckStatusUnknown,
+			expectedError: errors.New("check.test - expected check status pass, got: unknown"),
+		},
+		"mismatch-problems": {
+			expected: tfjson.CheckStatusPass,
+			got:      tfjson.CheckStatusFail,
+			instances: []tfjson.CheckResultDynamic{
+				{
+					Problems: []tfjson.CheckResultProblem{
+						{Message: "first problem"},
+						{Message: "second problem"},
+					},
+				},
+			},
+			expectedError: errors.New("check.test - expected check status pass, got: fail\n\nfirst problem\nsecond problem"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkresult.StatusError("check.test", testCase.expected, testCase.got, testCase.instances)
+
+			if testCase.expectedError == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil || err.Error() != testCase.expectedError.Error() {
+				t.Fatalf("expected error %q, got: %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
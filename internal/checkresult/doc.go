@@ -0,0 +1,7 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package checkresult contains the logic shared by the plancheck and
+// statecheck ExpectCheckResult checks, which assert the status of the custom
+// conditions and check block assertions in a plan or state.
+package checkresult
@@ -28,12 +28,27 @@ const (
 	// The type of resource being operated on, such as "random_pet"
 	KeyResourceType = "tf_resource_type"
 
+	// The attempt number of a retried post-test destroy. Starts at 1.
+	KeyTestDestroyAttempt = "test_destroy_attempt"
+
+	// The delay before retrying a post-test destroy.
+	KeyTestDestroyRetryDelay = "test_destroy_retry_delay"
+
 	// The name of the test being executed.
 	KeyTestName = "test_name"
 
+	// The attempt number of a retried TestStep. Starts at 1.
+	KeyTestStepAttempt = "test_step_attempt"
+
 	// The TestStep number of the test being executed. Starts at 1.
 	KeyTestStepNumber = "test_step_number"
 
+	// The delay before retrying a TestStep.
+	KeyTestStepRetryDelay = "test_step_retry_delay"
+
+	// The duration after which a TestStep was cancelled.
+	KeyTestStepTimeout = "test_step_timeout"
+
 	// Terraform configuration used during acceptance testing Terraform operations.
 	KeyTestTerraformConfiguration = "test_terraform_configuration"
 
@@ -0,0 +1,74 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plugintest
+
+import (
+	"github.com/hashicorp/terraform-exec/tfexec"
+)
+
+// Phases of a test which run Terraform commands, as recorded in CommandError.
+const (
+	PhaseInit    = "init"
+	PhasePlan    = "plan"
+	PhaseApply   = "apply"
+	PhaseRefresh = "refresh"
+	PhaseDestroy = "destroy"
+	PhaseImport  = "import"
+	PhaseQuery   = "query"
+)
+
+// CommandError is returned by WorkingDir methods when a Terraform command
+// fails. The error message is the message of the underlying error.
+type CommandError struct {
+	// Command is the Terraform command, such as "plan" or "show".
+	Command string
+
+	// Phase is the phase of the test the command ran for, such as
+	// PhaseDestroy for a destroy plan, or empty for commands which only
+	// read the working directory, such as "show".
+	Phase string
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the message of the underlying error.
+func (e *CommandError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// commandError returns a *CommandError for the failed Terraform command, or
+// nil if err is nil.
+func commandError(command string, phase string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &CommandError{
+		Command: command,
+		Phase:   phase,
+		Err:     err,
+	}
+}
+
+// planPhase returns the phase of a plan or apply with the given options. The
+// destroy and refresh-only options are assumed to be enabled, as they are
+// never disabled by this module.
+func planPhase[T any](opts []T) string {
+	for _, opt := range opts {
+		switch any(opt).(type) {
+		case *tfexec.DestroyFlagOption:
+			return PhaseDestroy
+		case *tfexec.RefreshOnlyOption:
+			return PhaseRefresh
+		}
+	}
+
+	return PhasePlan
+}
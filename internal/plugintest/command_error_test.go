@@ -0,0 +1,75 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plugintest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+)
+
+func TestCommandError(t *testing.T) {
+	t.Parallel()
+
+	if err := commandError("plan", PhasePlan, nil); err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	underlying := errors.New("exit status 1")
+	err := commandError("apply", PhaseApply, underlying)
+
+	var commandErr *CommandError
+
+	if !errors.As(err, &commandErr) {
+		t.Fatalf("expected *CommandError, got: %T", err)
+	}
+
+	if commandErr.Command != "apply" || commandErr.Phase != PhaseApply {
+		t.Errorf("unexpected command error: %+v", commandErr)
+	}
+
+	if err.Error() != "exit status 1" {
+		t.Errorf("expected underlying error message, got: %s", err)
+	}
+
+	if !errors.Is(err, underlying) {
+		t.Errorf("expected error to wrap underlying error")
+	}
+}
+
+func TestPlanPhase(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		opts     []tfexec.PlanOption
+		expected string
+	}{
+		"none": {
+			expected: PhasePlan,
+		},
+		"other": {
+			opts:     []tfexec.PlanOption{tfexec.Out(PlanFileName)},
+			expected: PhasePlan,
+		},
+		"destroy": {
+			opts:     []tfexec.PlanOption{tfexec.Out(PlanFileName), tfexec.Destroy(true)},
+			expected: PhaseDestroy,
+		},
+		"refresh-only": {
+			opts:     []tfexec.PlanOption{tfexec.RefreshOnly(true), tfexec.Out(PlanFileName)},
+			expected: PhaseRefresh,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := planPhase(testCase.opts); got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,485 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plugintest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+)
+
+// MinTerraformVersionDiagnostics is the minimum Terraform CLI version which
+// supports the -json flag for the plan, apply, destroy, and refresh commands.
+var MinTerraformVersionDiagnostics = version.Must(version.NewVersion("0.15.3"))
+
+// minTerraformVersionInitJSON is the minimum Terraform CLI version which
+// supports the -json flag for the init command.
+var minTerraformVersionInitJSON = version.Must(version.NewVersion("1.9.0"))
+
+// SetCaptureDiagnostics enables or disables running Terraform commands with
+// machine-readable (-json) output so that the warning and error diagnostics
+// they report can be retrieved with Diagnostics.
+func (wd *WorkingDir) SetCaptureDiagnostics(capture bool) {
+	wd.captureDiagnostics = capture
+}
+
+// IsCapturingDiagnostics returns true if Terraform commands are run with
+// machine-readable (-json) output and their diagnostics are captured.
+func (wd *WorkingDir) IsCapturingDiagnostics() bool {
+	return wd.captureDiagnostics
+}
+
+// Diagnostics returns the diagnostics captured since the last call to
+// ClearDiagnostics.
+func (wd *WorkingDir) Diagnostics() []diagcheck.Diagnostic {
+	return wd.diagnostics
+}
+
+// AppendDiagnostics adds diagnostics captured elsewhere, such as in a
+// separate working directory, to the captured diagnostics.
+func (wd *WorkingDir) AppendDiagnostics(diags ...diagcheck.Diagnostic) {
+	wd.diagnostics = append(wd.diagnostics, diags...)
+}
+
+// ClearDiagnostics discards all captured diagnostics.
+func (wd *WorkingDir) ClearDiagnostics() {
+	wd.diagnostics = nil
+}
+
+// runJSON runs a Terraform command with machine-readable output written to
+// an in-memory buffer, captures the diagnostics from that output, and, if the
+// command failed, returns an error which includes the error diagnostics so
+// that error messages match those of the human-readable output.
+func (wd *WorkingDir) runJSON(ctx context.Context, run func(*bytes.Buffer) error) error {
+	var buf bytes.Buffer
+
+	err := run(&buf)
+
+	// The tfexec JSON methods set stdout on the Terraform instance, which
+	// must be reset so that later commands do not write into the buffer.
+	wd.tf.SetStdout(nil)
+
+	diags, parseErr := parseDiagnostics(&buf)
+
+	if parseErr != nil {
+		logging.HelperResourceWarn(ctx, "Unable to parse Terraform CLI machine-readable output", map[string]interface{}{logging.KeyError: parseErr})
+	}
+
+	for i := range diags {
+		diags[i].BlockAddress, diags[i].AttributePath = wd.diagnosticLocation(diags[i].Diagnostic)
+	}
+
+	wd.diagnostics = append(wd.diagnostics, diags...)
+
+	if err == nil {
+		return nil
+	}
+
+	var errDiags []string
+
+	for _, diag := range diags {
+		if diag.Severity != tfjson.DiagnosticSeverityError {
+			continue
+		}
+
+		errDiags = append(errDiags, formatDiagnostic(diag.Diagnostic))
+	}
+
+	if len(errDiags) == 0 {
+		return err
+	}
+
+	return fmt.Errorf("%w\n\n%s", err, strings.Join(errDiags, "\n\n"))
+}
+
+// runHumanReadable runs a Terraform command which does not support
+// machine-readable output, such as terraform import, and captures the
+// diagnostics parsed from its human-readable output and error. The error is
+// returned unchanged.
+func (wd *WorkingDir) runHumanReadable(run func() error) error {
+	var buf bytes.Buffer
+
+	wd.tf.SetStdout(&buf)
+
+	err := run()
+
+	wd.tf.SetStdout(nil)
+
+	diags := parseHumanDiagnostics(buf.String())
+
+	if err != nil {
+		diags = append(diags, parseHumanDiagnostics(err.Error())...)
+	}
+
+	for i := range diags {
+		diags[i].BlockAddress, diags[i].AttributePath = wd.diagnosticLocation(diags[i].Diagnostic)
+	}
+
+	wd.diagnostics = append(wd.diagnostics, diags...)
+
+	return err
+}
+
+// humanDiagnosticLocation matches the source location line of a diagnostic
+// in human-readable Terraform CLI output.
+var humanDiagnosticLocation = regexp.MustCompile(`^  on (.+) line (\d+)`)
+
+// parseHumanDiagnostics returns the diagnostics found in human-readable
+// Terraform CLI output, which Terraform 0.15 and later draw in boxes. The
+// detail may be wrapped differently than in machine-readable output, and
+// source ranges only include the line.
+func parseHumanDiagnostics(output string) []diagcheck.Diagnostic {
+	var result []diagcheck.Diagnostic
+	var lines []string
+	var inBox bool
+
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "╷"):
+			inBox = true
+			lines = nil
+		case strings.HasPrefix(line, "╵"):
+			if diag, ok := parseHumanDiagnostic(lines); ok {
+				result = append(result, diag)
+			}
+
+			inBox = false
+		case inBox:
+			line = strings.TrimPrefix(line, "│")
+			lines = append(lines, strings.TrimPrefix(line, " "))
+		}
+	}
+
+	return result
+}
+
+// parseHumanDiagnostic returns the diagnostic in the lines of a box in
+// human-readable Terraform CLI output.
+func parseHumanDiagnostic(lines []string) (diagcheck.Diagnostic, bool) {
+	var diag tfjson.Diagnostic
+
+	if len(lines) == 0 {
+		return diagcheck.Diagnostic{}, false
+	}
+
+	switch {
+	case strings.HasPrefix(lines[0], "Error: "):
+		diag.Severity = tfjson.DiagnosticSeverityError
+		diag.Summary = strings.TrimPrefix(lines[0], "Error: ")
+	case strings.HasPrefix(lines[0], "Warning: "):
+		diag.Severity = tfjson.DiagnosticSeverityWarning
+		diag.Summary = strings.TrimPrefix(lines[0], "Warning: ")
+	default:
+		return diagcheck.Diagnostic{}, false
+	}
+
+	i := 1
+
+	for i < len(lines) && strings.TrimSpace(lines[i]) == "" {
+		i++
+	}
+
+	if i < len(lines) && strings.HasPrefix(lines[i], "  with ") {
+		diag.Address = strings.TrimSuffix(strings.TrimPrefix(lines[i], "  with "), ",")
+		i++
+	}
+
+	if i < len(lines) {
+		if match := humanDiagnosticLocation.FindStringSubmatch(lines[i]); match != nil {
+			line, _ := strconv.Atoi(match[2])
+
+			diag.Range = &tfjson.Range{
+				Filename: match[1],
+				Start:    tfjson.Pos{Line: line},
+			}
+
+			// Skip the source snippet.
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" {
+				i++
+			}
+		}
+	}
+
+	diag.Detail = strings.TrimSpace(strings.Join(lines[i:], "\n"))
+
+	return diagcheck.Diagnostic{Diagnostic: diag}, true
+}
+
+// parseDiagnostics returns the diagnostics found in machine-readable
+// Terraform CLI output, which contains one JSON message per line.
+func parseDiagnostics(buf *bytes.Buffer) ([]diagcheck.Diagnostic, error) {
+	var diags []diagcheck.Diagnostic
+
+	scanner := bufio.NewScanner(buf)
+	scanner.Buffer(nil, 1024*1024*64)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		msg, err := tfjson.UnmarshalLogMessage(line)
+
+		if err != nil {
+			return diags, fmt.Errorf("error parsing machine-readable output line %q: %w", line, err)
+		}
+
+		diagMsg, ok := msg.(tfjson.DiagnosticLogMessage)
+
+		if !ok {
+			continue
+		}
+
+		diags = append(diags, diagcheck.Diagnostic{Diagnostic: diagMsg.Diagnostic})
+	}
+
+	return diags, scanner.Err()
+}
+
+// formatDiagnostic returns a string representation of the diagnostic similar
+// to the human-readable Terraform CLI output, including the address and
+// source snippet, so that error messages match those of that output.
+func formatDiagnostic(diag tfjson.Diagnostic) string {
+	var b strings.Builder
+
+	b.WriteString("Error: ")
+	b.WriteString(diag.Summary)
+
+	if diag.Address != "" || diag.Range != nil {
+		b.WriteString("\n")
+	}
+
+	if diag.Address != "" {
+		fmt.Fprintf(&b, "\n  with %s,", diag.Address)
+	}
+
+	if diag.Range != nil {
+		fmt.Fprintf(&b, "\n  on %s line %d", diag.Range.Filename, diag.Range.Start.Line)
+
+		if diag.Snippet != nil {
+			if diag.Snippet.Context != nil {
+				fmt.Fprintf(&b, ", in %s", *diag.Snippet.Context)
+			}
+
+			b.WriteString(":")
+
+			for i, line := range strings.Split(strings.TrimSuffix(diag.Snippet.Code, "\n"), "\n") {
+				fmt.Fprintf(&b, "\n%4d: %s", diag.Snippet.StartLine+i, line)
+			}
+		}
+	}
+
+	if diag.Detail != "" {
+		b.WriteString("\n\n")
+		b.WriteString(diag.Detail)
+	}
+
+	return b.String()
+}
+
+// diagnosticLocation returns the address of the top-level configuration block
+// and the path of the configuration attribute within it which the diagnostic
+// source range refers to, or empty strings if they cannot be determined.
+func (wd *WorkingDir) diagnosticLocation(diag tfjson.Diagnostic) (string, string) {
+	if diag.Range == nil || filepath.Ext(diag.Range.Filename) != ".tf" {
+		return "", ""
+	}
+
+	src, err := os.ReadFile(filepath.Join(wd.baseDir, diag.Range.Filename))
+
+	if err != nil {
+		return "", ""
+	}
+
+	offset := diag.Range.Start.Byte
+
+	// Diagnostics parsed from human-readable output only include the line,
+	// so use its first non-whitespace character.
+	if offset == 0 && diag.Range.Start.Line > 1 {
+		offset = lineOffset(src, diag.Range.Start.Line)
+	}
+
+	return blockAddress(src, diag.Range.Filename, offset), attributePath(src, diag.Range.Filename, offset)
+}
+
+// blockAddress parses the given HCL native syntax configuration and returns
+// the address of the top-level block containing the byte offset, such as
+// "examplecloud_thing.test", "data.examplecloud_thing.test", "check.example",
+// or "output.example", or an empty string for other blocks.
+func blockAddress(src []byte, filename string, offset int) string {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+
+	if diags.HasErrors() {
+		return ""
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+
+	if !ok {
+		return ""
+	}
+
+	for _, block := range body.Blocks {
+		if !rangeContains(block.Range(), offset) {
+			continue
+		}
+
+		switch {
+		case block.Type == "resource" && len(block.Labels) == 2:
+			return block.Labels[0] + "." + block.Labels[1]
+		case block.Type == "data" && len(block.Labels) == 2:
+			return "data." + block.Labels[0] + "." + block.Labels[1]
+		case (block.Type == "check" || block.Type == "output") && len(block.Labels) == 1:
+			return block.Type + "." + block.Labels[0]
+		}
+
+		return ""
+	}
+
+	return ""
+}
+
+// attributePath parses the given HCL native syntax configuration and returns
+// the path of the innermost attribute, nested block, or expression element
+// containing the byte offset, relative to the enclosing top-level block.
+func attributePath(src []byte, filename string, offset int) string {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+
+	if diags.HasErrors() {
+		return ""
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+
+	if !ok {
+		return ""
+	}
+
+	for _, block := range body.Blocks {
+		if !rangeContains(block.Range(), offset) {
+			continue
+		}
+
+		return strings.Join(bodyPath(block.Body, offset), ".")
+	}
+
+	return ""
+}
+
+func bodyPath(body *hclsyntax.Body, offset int) []string {
+	for name, attr := range body.Attributes {
+		if !rangeContains(attr.SrcRange, offset) {
+			continue
+		}
+
+		return append([]string{name}, expressionPath(attr.Expr, offset)...)
+	}
+
+	blockIndexes := make(map[string]int)
+
+	for _, block := range body.Blocks {
+		index := blockIndexes[block.Type]
+		blockIndexes[block.Type]++
+
+		if !rangeContains(block.Range(), offset) {
+			continue
+		}
+
+		path := []string{block.Type}
+
+		if len(block.Labels) > 0 {
+			path = append(path, block.Labels...)
+		} else {
+			path = append(path, strconv.Itoa(index))
+		}
+
+		return append(path, bodyPath(block.Body, offset)...)
+	}
+
+	return nil
+}
+
+func expressionPath(expr hclsyntax.Expression, offset int) []string {
+	switch expr := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		for _, item := range expr.Items {
+			if !rangeContains(hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range()), offset) {
+				continue
+			}
+
+			key, diags := item.KeyExpr.Value(nil)
+
+			if diags.HasErrors() || !key.Type().Equals(cty.String) || key.IsNull() {
+				return nil
+			}
+
+			return append([]string{key.AsString()}, expressionPath(item.ValueExpr, offset)...)
+		}
+	case *hclsyntax.TupleConsExpr:
+		for index, elem := range expr.Exprs {
+			if !rangeContains(elem.Range(), offset) {
+				continue
+			}
+
+			return append([]string{strconv.Itoa(index)}, expressionPath(elem, offset)...)
+		}
+	}
+
+	return nil
+}
+
+// lineOffset returns the byte offset of the first non-whitespace character of
+// the 1-based line, or -1 if there is no such line.
+func lineOffset(src []byte, line int) int {
+	offset := 0
+
+	for current := 1; current < line; current++ {
+		next := bytes.IndexByte(src[offset:], '\n')
+
+		if next < 0 {
+			return -1
+		}
+
+		offset += next + 1
+	}
+
+	for offset < len(src) && (src[offset] == ' ' || src[offset] == '\t') {
+		offset++
+	}
+
+	return offset
+}
+
+func rangeContains(rng hcl.Range, offset int) bool {
+	return offset >= rng.Start.Byte && offset < rng.End.Byte
+}
+
+// supportsInitJSON returns true if the Terraform CLI version supports the
+// -json flag for the init command.
+func (wd *WorkingDir) supportsInitJSON(ctx context.Context) bool {
+	tfVersion, _, err := wd.tf.Version(ctx, false)
+
+	if err != nil {
+		return false
+	}
+
+	return !tfVersion.LessThan(minTerraformVersionInitJSON)
+}
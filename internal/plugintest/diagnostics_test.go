@@ -0,0 +1,335 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plugintest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
+)
+
+func TestAttributePath(t *testing.T) {
+	t.Parallel()
+
+	config := `
+resource "test_resource" "test" {
+  name = "a"
+
+  nested_block {
+    value = "first"
+  }
+
+  nested_block {
+    value = "second"
+  }
+
+  object_attribute = {
+    key  = "value"
+    list = ["zero", "one"]
+  }
+}
+`
+
+	testCases := map[string]struct {
+		target   string
+		expected string
+	}{
+		"attribute": {
+			target:   `"a"`,
+			expected: "name",
+		},
+		"nested-block-attribute": {
+			target:   `"second"`,
+			expected: "nested_block.1.value",
+		},
+		"object-attribute-key": {
+			target:   `"value"`,
+			expected: "object_attribute.key",
+		},
+		"object-attribute-list-element": {
+			target:   `"one"`,
+			expected: "object_attribute.list.1",
+		},
+		"block-header": {
+			target:   `resource`,
+			expected: "",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			offset := strings.Index(config, testCase.target)
+
+			got := attributePath([]byte(config), "main.tf", offset)
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBlockAddress(t *testing.T) {
+	t.Parallel()
+
+	config := `
+resource "test_resource" "test" {
+  name = "resource"
+
+  lifecycle {
+    precondition {
+      condition     = true
+      error_message = "resource precondition"
+    }
+  }
+}
+
+data "test_data_source" "test" {
+  name = "data"
+}
+
+check "health" {
+  assert {
+    condition     = true
+    error_message = "check assertion"
+  }
+}
+
+output "test" {
+  value = "output"
+}
+
+provider "test" {
+  name = "provider"
+}
+`
+
+	testCases := map[string]struct {
+		target   string
+		expected string
+	}{
+		"resource": {
+			target:   `"resource precondition"`,
+			expected: "test_resource.test",
+		},
+		"data": {
+			target:   `"data"`,
+			expected: "data.test_data_source.test",
+		},
+		"check": {
+			target:   `"check assertion"`,
+			expected: "check.health",
+		},
+		"output": {
+			target:   `"output"`,
+			expected: "output.test",
+		},
+		"provider": {
+			target:   `"provider"`,
+			expected: "",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			offset := strings.Index(config, testCase.target)
+
+			got := blockAddress([]byte(config), "main.tf", offset)
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	output := `{"@level":"info","@message":"Terraform 1.9.0","type":"version","terraform":"1.9.0","ui":"1.2"}
+{"@level":"warn","@message":"Warning: Deprecated","type":"diagnostic","diagnostic":{"severity":"warning","summary":"Deprecated","detail":"Use something else."}}
+
+{"@level":"error","@message":"Error: Invalid value","type":"diagnostic","diagnostic":{"severity":"error","summary":"Invalid value","detail":"","address":"test_resource.test"}}
+`
+
+	got, err := parseDiagnostics(bytes.NewBufferString(output))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []diagcheck.Diagnostic{
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityWarning,
+				Summary:  "Deprecated",
+				Detail:   "Use something else.",
+			},
+		},
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityError,
+				Summary:  "Invalid value",
+				Address:  "test_resource.test",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestFormatDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	context := `resource "test_resource" "test"`
+
+	testCases := map[string]struct {
+		diag     tfjson.Diagnostic
+		expected string
+	}{
+		"summary": {
+			diag: tfjson.Diagnostic{
+				Summary: "Invalid value",
+			},
+			expected: "Error: Invalid value",
+		},
+		"detail": {
+			diag: tfjson.Diagnostic{
+				Summary: "Invalid value",
+				Detail:  "The value must be positive.",
+			},
+			expected: "Error: Invalid value\n\nThe value must be positive.",
+		},
+		"address-and-snippet": {
+			diag: tfjson.Diagnostic{
+				Address: "test_resource.test",
+				Summary: "Invalid value",
+				Detail:  "The value must be positive.",
+				Range: &tfjson.Range{
+					Filename: "terraform_plugin_test.tf",
+					Start:    tfjson.Pos{Line: 3},
+				},
+				Snippet: &tfjson.DiagnosticSnippet{
+					Context:   &context,
+					Code:      "  value = -1\n",
+					StartLine: 3,
+				},
+			},
+			expected: "Error: Invalid value\n\n" +
+				"  with test_resource.test,\n" +
+				"  on terraform_plugin_test.tf line 3, in resource \"test_resource\" \"test\":\n" +
+				"   3:   value = -1\n\n" +
+				"The value must be positive.",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := formatDiagnostic(testCase.diag)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseHumanDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	output := `test_resource.test: Importing from ID "test"...
+╷
+│ Warning: Deprecated Attribute
+│ 
+│   with test_resource.test,
+│   on terraform_plugin_test.tf line 2, in resource "test_resource" "test":
+│    2:   value = "test"
+│ 
+│ The value attribute is deprecated.
+│ Use name instead.
+╵
+
+Import successful!
+╷
+│ Error: Resource Not Found
+│ 
+│ The resource could not be imported.
+╵
+`
+
+	got := parseHumanDiagnostics(output)
+
+	expected := []diagcheck.Diagnostic{
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityWarning,
+				Summary:  "Deprecated Attribute",
+				Detail:   "The value attribute is deprecated.\nUse name instead.",
+				Address:  "test_resource.test",
+				Range: &tfjson.Range{
+					Filename: "terraform_plugin_test.tf",
+					Start:    tfjson.Pos{Line: 2},
+				},
+			},
+		},
+		{
+			Diagnostic: tfjson.Diagnostic{
+				Severity: tfjson.DiagnosticSeverityError,
+				Summary:  "Resource Not Found",
+				Detail:   "The resource could not be imported.",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestLineOffset(t *testing.T) {
+	t.Parallel()
+
+	src := []byte("resource \"test_resource\" \"test\" {\n\t  value = \"test\"\n}\n")
+
+	testCases := map[string]struct {
+		line     int
+		expected int
+	}{
+		"first": {
+			line:     1,
+			expected: 0,
+		},
+		"indented": {
+			line:     2,
+			expected: strings.Index(string(src), "value"),
+		},
+		"missing": {
+			line:     5,
+			expected: -1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := lineOffset(src, testCase.line); got != testCase.expected {
+				t.Errorf("expected %d, got %d", testCase.expected, got)
+			}
+		})
+	}
+}
@@ -287,6 +287,7 @@ func (h *Helper) NewWorkingDir(ctx context.Context, t TestControl, wd string) (*
 		tf:            tf,
 		baseDir:       dir,
 		terraformExec: h.terraformExec,
+		logPath:       logPath,
 	}, nil
 }
 
@@ -4,16 +4,22 @@
 package plugintest
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/hashicorp/terraform-exec/tfexec"
 	tfjson "github.com/hashicorp/terraform-json"
 
 	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/diagcheck"
 	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
 	"github.com/hashicorp/terraform-plugin-testing/internal/teststep"
 )
@@ -51,6 +57,73 @@ type WorkingDir struct {
 	// reattachInfo stores the gRPC socket info required for Terraform's
 	// plugin reattach functionality
 	reattachInfo tfexec.ReattachInfo
+
+	// captureDiagnostics determines whether Terraform commands are run with
+	// machine-readable (-json) output so their diagnostics can be captured
+	captureDiagnostics bool
+
+	// diagnostics stores the diagnostics captured from Terraform commands
+	// since the last call to ClearDiagnostics
+	diagnostics []diagcheck.Diagnostic
+
+	// command is the name of the running Terraform command, for the
+	// commands which call provider RPCs for the configuration. It is read
+	// concurrently by provider server RPCs.
+	command atomic.Pointer[string]
+
+	// logPath is the path of the Terraform CLI log file, set via the
+	// TF_ACC_LOG_PATH or TF_LOG_PATH_MASK environment variables, if any
+	logPath string
+
+	// savedPlanPhase is the phase of the saved plan, if any
+	savedPlanPhase string
+
+	// commandCtx is the context used to run Terraform commands, if set
+	commandCtx context.Context
+}
+
+// SetCommandContext sets the context used to run Terraform commands, so that
+// cancelling the context interrupts any running command. Terraform is sent
+// an interrupt signal, and is killed if it does not exit within a minute.
+// A nil context never cancels commands.
+func (wd *WorkingDir) SetCommandContext(ctx context.Context) {
+	wd.commandCtx = ctx
+}
+
+// CommandContext returns the context used to run Terraform commands.
+func (wd *WorkingDir) CommandContext() context.Context {
+	if wd.commandCtx == nil {
+		return context.Background()
+	}
+
+	return wd.commandCtx
+}
+
+// Command returns the name of the Terraform command being run, such as
+// "plan" or "apply", or an empty string if no plan, apply, destroy, refresh,
+// or import command is running. It is safe to call concurrently, such as from
+// provider server RPCs.
+func (wd *WorkingDir) Command() string {
+	command := wd.command.Load()
+
+	if command == nil {
+		return ""
+	}
+
+	return *command
+}
+
+// setCommand sets the name of the running Terraform command, or clears it
+// with an empty string.
+func (wd *WorkingDir) setCommand(name string) {
+	wd.command.Store(&name)
+}
+
+// LogPath returns the path of the Terraform CLI log file, or an empty string
+// if neither the TF_ACC_LOG_PATH nor the TF_LOG_PATH_MASK environment variable
+// is set.
+func (wd *WorkingDir) LogPath() string {
+	return wd.logPath
 }
 
 // BaseDir returns the path to the root of the working directory tree.
@@ -323,11 +396,21 @@ func (wd *WorkingDir) Init(ctx context.Context) error {
 
 	// -upgrade=true is required for per-TestStep provider version changes
 	// e.g. TestTest_TestStep_ExternalProviders_DifferentVersions
-	err := wd.tf.Init(context.Background(), tfexec.Reattach(wd.reattachInfo), tfexec.Upgrade(true))
+	opts := []tfexec.InitOption{tfexec.Reattach(wd.reattachInfo), tfexec.Upgrade(true)}
+
+	var err error
+
+	if wd.captureDiagnostics && wd.supportsInitJSON(ctx) {
+		err = wd.runJSON(ctx, func(w *bytes.Buffer) error {
+			return wd.tf.InitJSON(wd.CommandContext(), w, opts...)
+		})
+	} else {
+		err = wd.tf.Init(wd.CommandContext(), opts...)
+	}
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI init command")
 
-	return err
+	return commandError("init", PhaseInit, err)
 }
 
 func (wd *WorkingDir) planFilename() string {
@@ -342,14 +425,35 @@ func (wd *WorkingDir) CreatePlan(ctx context.Context, opts ...tfexec.PlanOption)
 	opts = append(opts, tfexec.Reattach(wd.reattachInfo))
 	opts = append(opts, tfexec.Out(PlanFileName))
 
-	hasChanges, err := wd.tf.Plan(context.Background(), opts...)
+	var hasChanges bool
+	var err error
+
+	wd.setCommand("plan")
+
+	if wd.captureDiagnostics {
+		err = wd.runJSON(ctx, func(w *bytes.Buffer) error {
+			var planErr error
+
+			hasChanges, planErr = wd.tf.PlanJSON(wd.CommandContext(), w, opts...)
+
+			return planErr
+		})
+	} else {
+		hasChanges, err = wd.tf.Plan(wd.CommandContext(), opts...)
+	}
+
+	wd.setCommand("")
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI plan command")
 
+	phase := planPhase(opts)
+
 	if err != nil {
-		return err
+		return commandError("plan", phase, err)
 	}
 
+	wd.savedPlanPhase = phase
+
 	if !hasChanges {
 		logging.HelperResourceTrace(ctx, "Created plan with no changes")
 
@@ -374,17 +478,33 @@ func (wd *WorkingDir) CreatePlan(ctx context.Context, opts ...tfexec.PlanOption)
 func (wd *WorkingDir) Apply(ctx context.Context, opts ...tfexec.ApplyOption) error {
 	args := []tfexec.ApplyOption{tfexec.Reattach(wd.reattachInfo), tfexec.Refresh(false)}
 	args = append(args, opts...)
+	phase := planPhase(opts)
 	if wd.HasSavedPlan() {
 		args = append(args, tfexec.DirOrPlan(PlanFileName))
+		phase = wd.savedPlanPhase
+	}
+	if phase == PhasePlan {
+		phase = PhaseApply
 	}
 
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI apply command")
 
-	err := wd.tf.Apply(context.Background(), args...)
+	wd.setCommand("apply")
+	defer wd.setCommand("")
+
+	var err error
+
+	if wd.captureDiagnostics {
+		err = wd.runJSON(ctx, func(w *bytes.Buffer) error {
+			return wd.tf.ApplyJSON(wd.CommandContext(), w, args...)
+		})
+	} else {
+		err = wd.tf.Apply(wd.CommandContext(), args...)
+	}
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI apply command")
 
-	return err
+	return commandError("apply", phase, err)
 }
 
 // Destroy runs "terraform destroy". It does not consider or modify any saved
@@ -395,11 +515,24 @@ func (wd *WorkingDir) Apply(ctx context.Context, opts ...tfexec.ApplyOption) err
 func (wd *WorkingDir) Destroy(ctx context.Context) error {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI destroy command")
 
-	err := wd.tf.Destroy(context.Background(), tfexec.Reattach(wd.reattachInfo), tfexec.Refresh(false))
+	opts := []tfexec.DestroyOption{tfexec.Reattach(wd.reattachInfo), tfexec.Refresh(false)}
+
+	wd.setCommand("destroy")
+	defer wd.setCommand("")
+
+	var err error
+
+	if wd.captureDiagnostics {
+		err = wd.runJSON(ctx, func(w *bytes.Buffer) error {
+			return wd.tf.DestroyJSON(wd.CommandContext(), w, opts...)
+		})
+	} else {
+		err = wd.tf.Destroy(wd.CommandContext(), opts...)
+	}
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI destroy command")
 
-	return err
+	return commandError("destroy", PhaseDestroy, err)
 }
 
 // HasSavedPlan returns true if there is a saved plan in the working directory. If
@@ -413,11 +546,82 @@ func (wd *WorkingDir) HasSavedPlan() bool {
 func (wd *WorkingDir) RemoveResource(ctx context.Context, address string) error {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI state rm command")
 
-	err := wd.tf.StateRm(context.Background(), address)
+	err := wd.tf.StateRm(wd.CommandContext(), address)
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI state rm command")
 
-	return err
+	return commandError("state rm", "", err)
+}
+
+// RemoveResourceConfig removes the resource blocks with the given resource
+// address, such as "examplecloud_thing.test", from the Terraform configuration
+// files in the working directory. It returns true if any block was removed.
+func (wd *WorkingDir) RemoveResourceConfig(ctx context.Context, address string) (bool, error) {
+	resourceType, resourceName, ok := strings.Cut(address, ".")
+
+	if !ok || strings.Contains(resourceName, ".") || strings.Contains(resourceName, "[") {
+		return false, fmt.Errorf("resource address %q must be in the form TYPE.NAME", address)
+	}
+
+	logging.HelperResourceTrace(ctx, "Removing resource from Terraform configuration", map[string]any{logging.KeyResourceType: resourceType})
+
+	filenames, err := filepath.Glob(filepath.Join(wd.baseDir, "*.tf"))
+
+	if err != nil {
+		return false, err
+	}
+
+	var removed bool
+
+	for _, filename := range filenames {
+		src, err := os.ReadFile(filename)
+
+		if err != nil {
+			return false, err
+		}
+
+		file, diags := hclwrite.ParseConfig(src, filepath.Base(filename), hcl.InitialPos)
+
+		if diags.HasErrors() {
+			return false, fmt.Errorf("error parsing %s: %w", filepath.Base(filename), diags)
+		}
+
+		var fileChanged bool
+
+		for _, block := range file.Body().Blocks() {
+			labels := block.Labels()
+
+			if block.Type() != "resource" || len(labels) != 2 || labels[0] != resourceType || labels[1] != resourceName {
+				continue
+			}
+
+			file.Body().RemoveBlock(block)
+			fileChanged = true
+		}
+
+		if !fileChanged {
+			continue
+		}
+
+		err = os.WriteFile(filename, file.Bytes(), 0700)
+
+		if err != nil {
+			return false, err
+		}
+
+		removed = true
+	}
+
+	if removed {
+		// Changing configuration invalidates any saved plan.
+		err = wd.ClearPlan(ctx)
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return removed, nil
 }
 
 // SavedPlan returns an object describing the current saved plan file, if any.
@@ -431,11 +635,11 @@ func (wd *WorkingDir) SavedPlan(ctx context.Context) (*tfjson.Plan, error) {
 
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI show command for JSON plan")
 
-	plan, err := wd.tf.ShowPlanFile(context.Background(), wd.planFilename(), tfexec.Reattach(wd.reattachInfo), tfexec.JSONNumber(true))
+	plan, err := wd.tf.ShowPlanFile(wd.CommandContext(), wd.planFilename(), tfexec.Reattach(wd.reattachInfo), tfexec.JSONNumber(true))
 
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI show command for JSON plan")
 
-	return plan, err
+	return plan, commandError("show", "", err)
 }
 
 // SavedPlanRawStdout returns a human readable stdout capture of the current saved plan file, if any.
@@ -449,12 +653,12 @@ func (wd *WorkingDir) SavedPlanRawStdout(ctx context.Context) (string, error) {
 
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI show command for stdout plan")
 
-	stdout, err := wd.tf.ShowPlanFileRaw(context.Background(), wd.planFilename(), tfexec.Reattach(wd.reattachInfo))
+	stdout, err := wd.tf.ShowPlanFileRaw(wd.CommandContext(), wd.planFilename(), tfexec.Reattach(wd.reattachInfo))
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI show command for stdout plan")
 
 	if err != nil {
-		return "", err
+		return "", commandError("show", "", err)
 	}
 
 	return stdout, nil
@@ -467,11 +671,28 @@ func (wd *WorkingDir) SavedPlanRawStdout(ctx context.Context) (string, error) {
 func (wd *WorkingDir) State(ctx context.Context) (*tfjson.State, error) {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI show command for JSON state")
 
-	state, err := wd.tf.Show(context.Background(), tfexec.Reattach(wd.reattachInfo))
+	state, err := wd.tf.Show(wd.CommandContext(), tfexec.Reattach(wd.reattachInfo))
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI show command for JSON state")
 
-	return state, err
+	return state, commandError("show", "", err)
+}
+
+// RawState returns the raw state, as written to the state file, including
+// the resource instance private data and schema versions which are not
+// included in State. The in-process providers are not reattached, as
+// terraform-exec does not support it for state pull, so the state cannot be
+// read from a provider state store.
+//
+// If the state cannot be read, RawState returns an error.
+func (wd *WorkingDir) RawState(ctx context.Context) ([]byte, error) {
+	logging.HelperResourceTrace(ctx, "Calling Terraform CLI state pull command")
+
+	state, err := wd.tf.StatePull(wd.CommandContext())
+
+	logging.HelperResourceTrace(ctx, "Called Terraform CLI state pull command")
+
+	return []byte(state), commandError("state pull", "", err)
 }
 
 func (wd *WorkingDir) StateFilePath() string {
@@ -482,33 +703,58 @@ func (wd *WorkingDir) StateFilePath() string {
 func (wd *WorkingDir) Import(ctx context.Context, resource, id string) error {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI import command")
 
-	err := wd.tf.Import(context.Background(), resource, id, tfexec.Config(wd.baseDir), tfexec.Reattach(wd.reattachInfo))
+	wd.setCommand("import")
+	defer wd.setCommand("")
+
+	run := func() error {
+		return wd.tf.Import(wd.CommandContext(), resource, id, tfexec.Config(wd.baseDir), tfexec.Reattach(wd.reattachInfo))
+	}
+
+	var err error
+
+	// terraform import does not support machine-readable output.
+	if wd.captureDiagnostics {
+		err = wd.runHumanReadable(run)
+	} else {
+		err = run()
+	}
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI import command")
 
-	return err
+	return commandError("import", PhaseImport, err)
 }
 
 // Taint runs terraform taint
 func (wd *WorkingDir) Taint(ctx context.Context, address string) error {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI taint command")
 
-	err := wd.tf.Taint(context.Background(), address)
+	err := wd.tf.Taint(wd.CommandContext(), address)
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI taint command")
 
-	return err
+	return commandError("taint", "", err)
 }
 
 // Refresh runs terraform refresh
 func (wd *WorkingDir) Refresh(ctx context.Context) error {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI refresh command")
 
-	err := wd.tf.Refresh(context.Background(), tfexec.Reattach(wd.reattachInfo))
+	wd.setCommand("refresh")
+	defer wd.setCommand("")
+
+	var err error
+
+	if wd.captureDiagnostics {
+		err = wd.runJSON(ctx, func(w *bytes.Buffer) error {
+			return wd.tf.RefreshJSON(wd.CommandContext(), w, tfexec.Reattach(wd.reattachInfo))
+		})
+	} else {
+		err = wd.tf.Refresh(wd.CommandContext(), tfexec.Reattach(wd.reattachInfo))
+	}
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI refresh command")
 
-	return err
+	return commandError("refresh", PhaseRefresh, err)
 }
 
 // Schemas returns an object describing the provider schemas.
@@ -517,7 +763,7 @@ func (wd *WorkingDir) Refresh(ctx context.Context) error {
 func (wd *WorkingDir) Schemas(ctx context.Context) (*tfjson.ProviderSchemas, error) {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI providers schema command")
 
-	providerSchemas, err := wd.tf.ProvidersSchema(context.Background())
+	providerSchemas, err := wd.tf.ProvidersSchema(wd.CommandContext())
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI providers schema command")
 
@@ -532,10 +778,10 @@ func (wd *WorkingDir) Query(ctx context.Context) ([]tfjson.LogMsg, error) {
 
 	args := []tfexec.QueryOption{tfexec.Reattach(wd.reattachInfo)}
 
-	logs, err := wd.tf.QueryJSON(context.Background(), args...)
+	logs, err := wd.tf.QueryJSON(wd.CommandContext(), args...)
 
 	if err != nil {
-		return nil, fmt.Errorf("running terraform query command: %w", err)
+		return nil, commandError("query", PhaseQuery, fmt.Errorf("running terraform query command: %w", err))
 	}
 
 	for msg := range logs {
@@ -544,7 +790,14 @@ func (wd *WorkingDir) Query(ctx context.Context) ([]tfjson.LogMsg, error) {
 		}
 
 		if msg.Err != nil {
-			return nil, fmt.Errorf("retrieving message: %w", msg.Err)
+			return nil, commandError("query", PhaseQuery, fmt.Errorf("retrieving message: %w", msg.Err))
+		}
+
+		if diagMsg, ok := msg.Msg.(tfjson.DiagnosticLogMessage); ok && wd.captureDiagnostics {
+			diag := diagcheck.Diagnostic{Diagnostic: diagMsg.Diagnostic}
+			diag.BlockAddress, diag.AttributePath = wd.diagnosticLocation(diagMsg.Diagnostic)
+
+			wd.diagnostics = append(wd.diagnostics, diag)
 		}
 
 		if msg.Msg.Level() == tfjson.Error {
@@ -556,7 +809,7 @@ func (wd *WorkingDir) Query(ctx context.Context) ([]tfjson.LogMsg, error) {
 	}
 
 	if len(diags) > 0 {
-		return nil, fmt.Errorf("running terraform query command returned diagnostics: %+v", diags)
+		return nil, commandError("query", PhaseQuery, fmt.Errorf("running terraform query command returned diagnostics: %+v", diags))
 	}
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI providers query command")
@@ -567,7 +820,7 @@ func (wd *WorkingDir) Query(ctx context.Context) ([]tfjson.LogMsg, error) {
 func (wd *WorkingDir) Workspaces(ctx context.Context) ([]string, error) {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI workspace list command")
 
-	workspaces, _, err := wd.tf.WorkspaceList(context.Background(), tfexec.Reattach(wd.reattachInfo))
+	workspaces, _, err := wd.tf.WorkspaceList(wd.CommandContext(), tfexec.Reattach(wd.reattachInfo))
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI workspace list command")
 
@@ -577,7 +830,7 @@ func (wd *WorkingDir) Workspaces(ctx context.Context) ([]string, error) {
 func (wd *WorkingDir) CreateWorkspace(ctx context.Context, workspace string) error {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI workspace new command")
 
-	err := wd.tf.WorkspaceNew(context.Background(), workspace, tfexec.Reattach(wd.reattachInfo))
+	err := wd.tf.WorkspaceNew(wd.CommandContext(), workspace, tfexec.Reattach(wd.reattachInfo))
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI workspace new command")
 
@@ -587,7 +840,7 @@ func (wd *WorkingDir) CreateWorkspace(ctx context.Context, workspace string) err
 func (wd *WorkingDir) SelectWorkspace(ctx context.Context, workspace string) error {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI workspace select command")
 
-	err := wd.tf.WorkspaceSelect(context.Background(), workspace, tfexec.Reattach(wd.reattachInfo))
+	err := wd.tf.WorkspaceSelect(wd.CommandContext(), workspace, tfexec.Reattach(wd.reattachInfo))
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI workspace select command")
 
@@ -599,7 +852,7 @@ func (wd *WorkingDir) DeleteWorkspace(ctx context.Context, workspace string, opt
 
 	opts = append(opts, tfexec.Reattach(wd.reattachInfo))
 
-	err := wd.tf.WorkspaceDelete(context.Background(), workspace, opts...)
+	err := wd.tf.WorkspaceDelete(wd.CommandContext(), workspace, opts...)
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI workspace delete command")
 
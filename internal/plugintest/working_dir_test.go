@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plugintest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorkingDirRemoveResourceConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config          string
+		address         string
+		expectedConfig  string
+		expectedRemoved bool
+		expectedError   string
+	}{
+		"removed": {
+			config: `resource "test_resource" "one" {
+  name = "one"
+}
+
+resource "test_resource" "two" {
+  name = "two"
+}
+`,
+			address: "test_resource.one",
+			expectedConfig: `
+resource "test_resource" "two" {
+  name = "two"
+}
+`,
+			expectedRemoved: true,
+		},
+		"not-found": {
+			config: `resource "test_resource" "two" {
+  name = "two"
+}
+`,
+			address: "test_resource.one",
+			expectedConfig: `resource "test_resource" "two" {
+  name = "two"
+}
+`,
+		},
+		"data-source-not-removed": {
+			config: `data "test_resource" "one" {}
+`,
+			address: "test_resource.one",
+			expectedConfig: `data "test_resource" "one" {}
+`,
+		},
+		"invalid-address": {
+			config:        `resource "test_resource" "one" {}`,
+			address:       "test_resource.one[0]",
+			expectedError: `resource address "test_resource.one[0]" must be in the form TYPE.NAME`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			wd := &WorkingDir{
+				baseDir: t.TempDir(),
+			}
+
+			filename := filepath.Join(wd.baseDir, ConfigFileName)
+
+			if err := os.WriteFile(filename, []byte(testCase.config), 0700); err != nil {
+				t.Fatalf("unexpected error writing config: %s", err)
+			}
+
+			removed, err := wd.RemoveResourceConfig(context.Background(), testCase.address)
+
+			if testCase.expectedError != "" {
+				if err == nil || err.Error() != testCase.expectedError {
+					t.Fatalf("expected error %q, got: %v", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if removed != testCase.expectedRemoved {
+				t.Errorf("expected removed %t, got %t", testCase.expectedRemoved, removed)
+			}
+
+			got, err := os.ReadFile(filename)
+
+			if err != nil {
+				t.Fatalf("unexpected error reading config: %s", err)
+			}
+
+			if string(got) != testCase.expectedConfig {
+				t.Errorf("expected config:\n%s\ngot:\n%s", testCase.expectedConfig, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,435 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providercassette
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/providerwrap"
+)
+
+// Mode determines whether a Cassette records or replays RPCs.
+type Mode string
+
+const (
+	// ModeRecord calls the provider server for every RPC and records the
+	// requests and responses.
+	ModeRecord Mode = "record"
+
+	// ModeReplay returns the recorded response for every RPC with a matching
+	// recorded request, without calling the provider server.
+	ModeReplay Mode = "replay"
+)
+
+// passthroughRPCs are always sent to the provider server and are not
+// recorded. The schema and metadata RPCs do not require access to the remote
+// system, while the streaming RPCs cannot be recorded.
+var passthroughRPCs = map[string]bool{
+	"GetFunctions":               true,
+	"GetMetadata":                true,
+	"GetProviderSchema":          true,
+	"GetResourceIdentitySchemas": true,
+	"StopProvider":               true,
+	"InvokeAction":               true,
+	"ListResource":               true,
+	"ReadStateBytes":             true,
+	"WriteStateBytes":            true,
+}
+
+// streamingRPCs cannot be recorded, so cannot be replayed.
+var streamingRPCs = map[string]bool{
+	"InvokeAction":    true,
+	"ListResource":    true,
+	"ReadStateBytes":  true,
+	"WriteStateBytes": true,
+}
+
+// Interaction is a single recorded RPC.
+type Interaction struct {
+	// Provider is the name of the provider, such as "examplecloud".
+	Provider string `json:"provider"`
+
+	// ProtocolVersion is the protocol version of the provider server.
+	ProtocolVersion int `json:"protocol_version"`
+
+	// RPC is the name of the RPC, such as "ReadResource".
+	RPC string `json:"rpc"`
+
+	// Request is the encoded RPC request.
+	Request json.RawMessage `json:"request"`
+
+	// Response is the encoded RPC response, if any.
+	Response json.RawMessage `json:"response,omitempty"`
+
+	// Error is the error returned by the RPC, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// cassetteFile is the cassette file contents.
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Cassette records or replays the RPCs to provider servers.
+type Cassette struct {
+	mode Mode
+	path string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+// New returns a Cassette for the given file. In ModeReplay, the recorded
+// interactions are read from the file, which must exist. In ModeRecord, the
+// interactions are written to the file by Save.
+func New(path string, mode Mode) (*Cassette, error) {
+	c := &Cassette{
+		mode: mode,
+		path: path,
+	}
+
+	switch mode {
+	case ModeRecord:
+		return c, nil
+	case ModeReplay:
+		b, err := os.ReadFile(path)
+
+		if err != nil {
+			return nil, fmt.Errorf("reading provider cassette: %w", err)
+		}
+
+		var f cassetteFile
+
+		if err := json.Unmarshal(b, &f); err != nil {
+			return nil, fmt.Errorf("parsing provider cassette %s: %w", path, err)
+		}
+
+		// The requests are compacted, as the cassette file is indented.
+		for i, interaction := range f.Interactions {
+			var buf bytes.Buffer
+
+			if err := json.Compact(&buf, interaction.Request); err != nil {
+				return nil, fmt.Errorf("parsing provider cassette %s: interaction %d request: %w", path, i, err)
+			}
+
+			f.Interactions[i].Request = buf.Bytes()
+		}
+
+		c.interactions = f.Interactions
+		c.replayed = make([]bool, len(f.Interactions))
+
+		return c, nil
+	}
+
+	return nil, fmt.Errorf("unknown provider cassette mode %q, expected %q or %q", mode, ModeRecord, ModeReplay)
+}
+
+// Mode returns the cassette mode.
+func (c *Cassette) Mode() Mode {
+	return c.mode
+}
+
+// Path returns the cassette file path.
+func (c *Cassette) Path() string {
+	return c.path
+}
+
+// Save writes the recorded interactions to the cassette file, creating any
+// parent directories. It does nothing in ModeReplay.
+func (c *Cassette) Save() error {
+	if c.mode != ModeRecord {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("encoding provider cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("creating provider cassette directory: %w", err)
+	}
+
+	if err := os.WriteFile(c.path, append(b, '\n'), 0o644); err != nil { //nolint:gosec // cassettes are committed alongside tests
+		return fmt.Errorf("writing provider cassette: %w", err)
+	}
+
+	return nil
+}
+
+// Interceptor returns a providerwrap.Interceptor which records or replays the
+// RPCs to the provider server with the given name.
+func (c *Cassette) Interceptor(provider string) providerwrap.Interceptor {
+	return func(ctx context.Context, call providerwrap.Call, next providerwrap.Handler) (any, error) {
+		if c.mode == ModeReplay && streamingRPCs[call.RPC] {
+			return nil, fmt.Errorf("provider cassette %s: %s RPCs cannot be replayed", c.path, call.RPC)
+		}
+
+		if passthroughRPCs[call.RPC] {
+			return next(ctx, call)
+		}
+
+		request, err := encodeJSON(call.Request)
+
+		if err != nil {
+			return nil, fmt.Errorf("provider cassette %s: encoding %s request: %w", c.path, call.RPC, err)
+		}
+
+		if c.mode == ModeReplay {
+			return c.replay(provider, call, request)
+		}
+
+		return c.record(ctx, provider, call, request, next)
+	}
+}
+
+func (c *Cassette) record(ctx context.Context, provider string, call providerwrap.Call, request json.RawMessage, next providerwrap.Handler) (any, error) {
+	resp, rpcErr := next(ctx, call)
+
+	interaction := Interaction{
+		Provider:        provider,
+		ProtocolVersion: call.ProtocolVersion,
+		RPC:             call.RPC,
+		Request:         request,
+	}
+
+	if rpcErr != nil {
+		interaction.Error = rpcErr.Error()
+	}
+
+	response, err := encodeJSON(resp)
+
+	if err != nil {
+		return nil, fmt.Errorf("provider cassette %s: encoding %s response: %w", c.path, call.RPC, err)
+	}
+
+	if !bytes.Equal(response, []byte("null")) {
+		interaction.Response = response
+	}
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, interaction)
+	c.mu.Unlock()
+
+	return resp, rpcErr
+}
+
+func (c *Cassette) replay(provider string, call providerwrap.Call, request json.RawMessage) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	closest := -1
+
+	for i, interaction := range c.interactions {
+		if c.replayed[i] || interaction.Provider != provider || interaction.RPC != call.RPC {
+			continue
+		}
+
+		if bytes.Equal(interaction.Request, request) {
+			c.replayed[i] = true
+
+			return c.replayResponse(call, interaction)
+		}
+
+		if closest == -1 || (!sameTypeName(c.interactions[closest].Request, request) && sameTypeName(interaction.Request, request)) {
+			closest = i
+		}
+	}
+
+	if closest == -1 {
+		return nil, fmt.Errorf("provider cassette %s: no unreplayed %s requests recorded for provider %q", c.path, call.RPC, provider)
+	}
+
+	return nil, fmt.Errorf("provider cassette %s: %s request for provider %q does not match any unreplayed recorded request, "+
+		"difference from closest recorded request (-recorded +got):\n%s",
+		c.path, call.RPC, provider, cmp.Diff(readable(decodeJSON(c.interactions[closest].Request)), readable(decodeJSON(request))))
+}
+
+func (c *Cassette) replayResponse(call providerwrap.Call, interaction Interaction) (any, error) {
+	var rpcErr error
+
+	if interaction.Error != "" {
+		rpcErr = errors.New(interaction.Error)
+	}
+
+	if len(interaction.Response) == 0 {
+		return nil, rpcErr
+	}
+
+	resp := call.NewResponse()
+
+	if err := decode(decodeJSON(interaction.Response), resp); err != nil {
+		return nil, fmt.Errorf("provider cassette %s: decoding %s response: %w", c.path, call.RPC, err)
+	}
+
+	return resp, rpcErr
+}
+
+// Unreplayed returns the recorded interactions which have not been replayed.
+func (c *Cassette) Unreplayed() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var result []Interaction
+
+	for i, interaction := range c.interactions {
+		if !c.replayed[i] {
+			result = append(result, interaction)
+		}
+	}
+
+	return result
+}
+
+// UnreplayedError returns an error listing the recorded interactions which
+// have not been replayed, such as when the provider no longer calls an RPC
+// and the cassette must be recorded again. It returns nil in ModeRecord.
+func (c *Cassette) UnreplayedError() error {
+	if c.mode != ModeReplay {
+		return nil
+	}
+
+	unreplayed := c.Unreplayed()
+
+	if len(unreplayed) == 0 {
+		return nil
+	}
+
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, "provider cassette %s: %d recorded interaction(s) were not replayed:", c.path, len(unreplayed))
+
+	for _, interaction := range unreplayed {
+		fmt.Fprintf(&buf, "\n  provider %q %s", interaction.Provider, interaction.RPC)
+
+		if typeName := requestTypeName(interaction.Request); typeName != "" {
+			fmt.Fprintf(&buf, " %s", typeName)
+		}
+	}
+
+	return errors.New(buf.String())
+}
+
+// encodeJSON returns the JSON encoding of an RPC request or response, which is
+// deterministic, so that equal requests have equal encodings.
+func encodeJSON(v any) (json.RawMessage, error) {
+	encoded, err := encode(v)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(encoded)
+}
+
+// decodeJSON returns the representation of an RPC request or response
+// returned by encode from its JSON encoding.
+func decodeJSON(b json.RawMessage) any {
+	var result any
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&result); err != nil {
+		return string(b)
+	}
+
+	return result
+}
+
+// readable returns the representation of an encoded RPC request with the
+// JSON and MessagePack encoded dynamic values decoded, so that differences
+// between requests are human-readable. Values which cannot be decoded, such as
+// MessagePack encoded unknown values, are left as-is.
+func readable(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+
+		for key, value := range v {
+			result[key] = readable(value)
+
+			encoded, ok := value.(string)
+
+			if !ok || (key != "JSON" && key != "MsgPack") {
+				continue
+			}
+
+			b, err := base64.StdEncoding.DecodeString(encoded)
+
+			if err != nil {
+				continue
+			}
+
+			var decoded any
+
+			if key == "JSON" {
+				err = json.Unmarshal(b, &decoded)
+			} else {
+				err = msgpack.Unmarshal(b, &decoded)
+			}
+
+			if err == nil {
+				result[key] = decoded
+			}
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(v))
+
+		for i, value := range v {
+			result[i] = readable(value)
+		}
+
+		return result
+	}
+
+	return v
+}
+
+// sameTypeName returns true if both encoded requests have the same resource,
+// data source, or ephemeral resource type name.
+func sameTypeName(a, b json.RawMessage) bool {
+	var aFields, bFields struct {
+		TypeName string
+	}
+
+	if json.Unmarshal(a, &aFields) != nil || json.Unmarshal(b, &bFields) != nil {
+		return false
+	}
+
+	return aFields.TypeName == bFields.TypeName
+}
+
+// requestTypeName returns the resource, data source, or ephemeral resource
+// type name of the encoded request, if any.
+func requestTypeName(request json.RawMessage) string {
+	var fields struct {
+		TypeName string
+	}
+
+	if json.Unmarshal(request, &fields) != nil {
+		return ""
+	}
+
+	return fields.TypeName
+}
@@ -0,0 +1,186 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providercassette_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/providercassette"
+	"github.com/hashicorp/terraform-plugin-testing/internal/providerwrap"
+)
+
+func readResourceCall(id string) providerwrap.Call {
+	return providerwrap.Call{
+		ProtocolVersion: 6,
+		RPC:             "ReadResource",
+		Request: &tfprotov6.ReadResourceRequest{
+			TypeName: "test_resource",
+			CurrentState: &tfprotov6.DynamicValue{
+				JSON: []byte(`{"id":"` + id + `"}`),
+			},
+		},
+		NewResponse: func() any {
+			return new(tfprotov6.ReadResourceResponse)
+		},
+	}
+}
+
+func readResourceHandler(ctx context.Context, call providerwrap.Call) (any, error) {
+	req := call.Request.(*tfprotov6.ReadResourceRequest) //nolint:forcetypeassert // test handler
+
+	if string(req.CurrentState.JSON) == `{"id":"error"}` {
+		return nil, errors.New("test error")
+	}
+
+	return &tfprotov6.ReadResourceResponse{
+		NewState: req.CurrentState,
+	}, nil
+}
+
+func unexpectedHandler(t *testing.T) providerwrap.Handler {
+	return func(ctx context.Context, call providerwrap.Call) (any, error) {
+		t.Errorf("unexpected call to provider server: %s", call.RPC)
+
+		return nil, nil
+	}
+}
+
+func recordCassette(t *testing.T, path string, ids ...string) {
+	t.Helper()
+
+	recorder, err := providercassette.New(path, providercassette.ModeRecord)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	interceptor := recorder.Interceptor("test")
+
+	for _, id := range ids {
+		_, _ = interceptor(context.Background(), readResourceCall(id), readResourceHandler)
+	}
+
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestCassette_RecordReplay(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+
+	recordCassette(t, path, "one", "error")
+
+	player, err := providercassette.New(path, providercassette.ModeReplay)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	interceptor := player.Interceptor("test")
+
+	resp, err := interceptor(context.Background(), readResourceCall("one"), unexpectedHandler(t))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov6.ReadResourceResponse{
+		NewState: &tfprotov6.DynamicValue{
+			JSON: []byte(`{"id":"one"}`),
+		},
+	}
+
+	if diff := cmp.Diff(resp, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+
+	_, err = interceptor(context.Background(), readResourceCall("error"), unexpectedHandler(t))
+
+	if err == nil || err.Error() != "test error" {
+		t.Errorf("expected recorded error, got: %v", err)
+	}
+
+	if unreplayed := player.Unreplayed(); len(unreplayed) != 0 {
+		t.Errorf("expected all interactions to be replayed, got: %v", unreplayed)
+	}
+}
+
+func TestCassette_UnreplayedError(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "test.json")
+
+	recordCassette(t, path, "one", "two")
+
+	player, err := providercassette.New(path, providercassette.ModeReplay)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, err = player.Interceptor("test")(context.Background(), readResourceCall("one"), unexpectedHandler(t))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err = player.UnreplayedError()
+
+	expected := "provider cassette " + path + ": 1 recorded interaction(s) were not replayed:\n  provider \"test\" ReadResource test_resource"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got: %v", expected, err)
+	}
+
+	_, err = player.Interceptor("test")(context.Background(), readResourceCall("two"), unexpectedHandler(t))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := player.UnreplayedError(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestCassette_ReplayMismatch(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "test.json")
+
+	recordCassette(t, path, "one")
+
+	player, err := providercassette.New(path, providercassette.ModeReplay)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, err = player.Interceptor("test")(context.Background(), readResourceCall("two"), unexpectedHandler(t))
+
+	expectedErr := regexp.MustCompile(`ReadResource request for provider "test" does not match any unreplayed recorded request, difference from closest recorded request \(-recorded \+got\):\n(.|\n)*-.*"id": string\("one"\).*\n\+.*"id": string\("two"\)`)
+
+	if err == nil || !expectedErr.MatchString(err.Error()) {
+		t.Errorf("expected error matching %q, got: %v", expectedErr, err)
+	}
+}
+
+func TestCassette_ReplayMissing(t *testing.T) {
+	t.Parallel()
+
+	_, err := providercassette.New(filepath.Join(t.TempDir(), "missing.json"), providercassette.ModeReplay)
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
@@ -0,0 +1,398 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providercassette
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	attributePathType = reflect.TypeOf(&tftypes.AttributePath{})
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// encode returns a JSON compatible representation of an RPC request or
+// response. The protocol types are plain structs, except for attribute paths,
+// which are encoded as a list of steps.
+func encode(v any) (any, error) {
+	return encodeValue(reflect.ValueOf(v))
+}
+
+func encodeValue(v reflect.Value) (any, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	switch v.Type() {
+	case attributePathType:
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		path, _ := v.Interface().(*tftypes.AttributePath)
+
+		return encodeAttributePath(path), nil
+	case timeType:
+		t, _ := v.Interface().(time.Time)
+
+		return t.Format(time.RFC3339Nano), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		return encodeValue(v.Elem())
+	case reflect.Struct:
+		result := make(map[string]any)
+
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+
+			if !field.IsExported() {
+				continue
+			}
+
+			value, err := encodeValue(v.Field(i))
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", field.Name, err)
+			}
+
+			if value != nil {
+				result[field.Name] = value
+			}
+		}
+
+		return result, nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(v.Bytes()), nil
+		}
+
+		result := make([]any, v.Len())
+
+		for i := range v.Len() {
+			value, err := encodeValue(v.Index(i))
+
+			if err != nil {
+				return nil, fmt.Errorf("%d: %w", i, err)
+			}
+
+			result[i] = value
+		}
+
+		return result, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", v.Type().Key())
+		}
+
+		result := make(map[string]any, v.Len())
+
+		for _, key := range v.MapKeys() {
+			value, err := encodeValue(v.MapIndex(key))
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key.String(), err)
+			}
+
+			result[key.String()] = value
+		}
+
+		return result, nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Interface, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return nil, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// encodeAttributePath returns the steps of the attribute path. Element key
+// value steps, which are used for set elements, cannot be recorded, so the
+// path is truncated at the first of them.
+func encodeAttributePath(path *tftypes.AttributePath) []any {
+	result := make([]any, 0, len(path.Steps()))
+
+	for _, step := range path.Steps() {
+		switch step := step.(type) {
+		case tftypes.AttributeName:
+			result = append(result, map[string]any{"attribute_name": string(step)})
+		case tftypes.ElementKeyString:
+			result = append(result, map[string]any{"element_key_string": string(step)})
+		case tftypes.ElementKeyInt:
+			result = append(result, map[string]any{"element_key_int": int64(step)})
+		default:
+			return result
+		}
+	}
+
+	return result
+}
+
+// decode sets the RPC request or response pointed to by target from a
+// representation returned by encode which was round-tripped through JSON
+// with numbers decoded as json.Number.
+func decode(data any, target any) error {
+	v := reflect.ValueOf(target)
+
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("decode target must be a non-nil pointer, got %T", target)
+	}
+
+	return decodeValue(data, v.Elem())
+}
+
+func decodeValue(data any, v reflect.Value) error {
+	if data == nil {
+		return nil
+	}
+
+	switch v.Type() {
+	case attributePathType:
+		steps, ok := data.([]any)
+
+		if !ok {
+			return fmt.Errorf("expected attribute path steps, got %T", data)
+		}
+
+		path, err := decodeAttributePath(steps)
+
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(path))
+
+		return nil
+	case timeType:
+		s, ok := data.(string)
+
+		if !ok {
+			return fmt.Errorf("expected time string, got %T", data)
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, s)
+
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(t))
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+
+		if err := decodeValue(data, elem.Elem()); err != nil {
+			return err
+		}
+
+		v.Set(elem)
+
+		return nil
+	case reflect.Struct:
+		fields, ok := data.(map[string]any)
+
+		if !ok {
+			return fmt.Errorf("expected object for %s, got %T", v.Type(), data)
+		}
+
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+
+			if !field.IsExported() {
+				continue
+			}
+
+			if err := decodeValue(fields[field.Name], v.Field(i)); err != nil {
+				return fmt.Errorf("%s: %w", field.Name, err)
+			}
+		}
+
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			s, ok := data.(string)
+
+			if !ok {
+				return fmt.Errorf("expected base64 string, got %T", data)
+			}
+
+			b, err := base64.StdEncoding.DecodeString(s)
+
+			if err != nil {
+				return err
+			}
+
+			v.SetBytes(b)
+
+			return nil
+		}
+
+		elems, ok := data.([]any)
+
+		if !ok {
+			return fmt.Errorf("expected list for %s, got %T", v.Type(), data)
+		}
+
+		result := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+
+		for i, elem := range elems {
+			if err := decodeValue(elem, result.Index(i)); err != nil {
+				return fmt.Errorf("%d: %w", i, err)
+			}
+		}
+
+		v.Set(result)
+
+		return nil
+	case reflect.Map:
+		elems, ok := data.(map[string]any)
+
+		if !ok {
+			return fmt.Errorf("expected object for %s, got %T", v.Type(), data)
+		}
+
+		result := reflect.MakeMapWithSize(v.Type(), len(elems))
+
+		for key, elem := range elems {
+			value := reflect.New(v.Type().Elem()).Elem()
+
+			if err := decodeValue(elem, value); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+
+			result.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), value)
+		}
+
+		v.Set(result)
+
+		return nil
+	case reflect.String:
+		s, ok := data.(string)
+
+		if !ok {
+			return fmt.Errorf("expected string, got %T", data)
+		}
+
+		v.SetString(s)
+
+		return nil
+	case reflect.Bool:
+		b, ok := data.(bool)
+
+		if !ok {
+			return fmt.Errorf("expected bool, got %T", data)
+		}
+
+		v.SetBool(b)
+
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := decodeNumber(data).Int64()
+
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := decodeNumber(data).Int64()
+
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(uint64(n)) //nolint:gosec // recorded from a uint value
+
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, err := decodeNumber(data).Float64()
+
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(n)
+
+		return nil
+	}
+
+	return fmt.Errorf("unsupported type %s", v.Type())
+}
+
+func decodeNumber(data any) json.Number {
+	if n, ok := data.(json.Number); ok {
+		return n
+	}
+
+	return json.Number(fmt.Sprint(data))
+}
+
+func decodeAttributePath(steps []any) (*tftypes.AttributePath, error) {
+	path := tftypes.NewAttributePath()
+
+	for _, step := range steps {
+		m, ok := step.(map[string]any)
+
+		if !ok {
+			return nil, fmt.Errorf("expected attribute path step, got %T", step)
+		}
+
+		switch {
+		case m["attribute_name"] != nil:
+			name, _ := m["attribute_name"].(string)
+			path = path.WithAttributeName(name)
+		case m["element_key_string"] != nil:
+			key, _ := m["element_key_string"].(string)
+			path = path.WithElementKeyString(key)
+		case m["element_key_int"] != nil:
+			key, err := decodeNumber(m["element_key_int"]).Int64()
+
+			if err != nil {
+				return nil, err
+			}
+
+			path = path.WithElementKeyInt(int(key))
+		default:
+			return nil, fmt.Errorf("unknown attribute path step %v", m)
+		}
+	}
+
+	return path, nil
+}
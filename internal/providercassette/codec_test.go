@@ -0,0 +1,79 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providercassette
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value  any
+		target func() any
+	}{
+		"diagnostics": {
+			value: &tfprotov6.ReadResourceResponse{
+				NewState: &tfprotov6.DynamicValue{
+					JSON: []byte(`{"id":"test"}`),
+				},
+				Diagnostics: []*tfprotov6.Diagnostic{
+					{
+						Severity:  tfprotov6.DiagnosticSeverityWarning,
+						Summary:   "test summary",
+						Detail:    "test detail",
+						Attribute: tftypes.NewAttributePath().WithAttributeName("list").WithElementKeyInt(1).WithElementKeyString("key"),
+					},
+				},
+				Private: []byte("private"),
+			},
+			target: func() any { return new(tfprotov6.ReadResourceResponse) },
+		},
+		"requires-replace": {
+			value: &tfprotov6.PlanResourceChangeResponse{
+				PlannedState: &tfprotov6.DynamicValue{
+					MsgPack: []byte{0x81, 0xa2, 0x69, 0x64},
+				},
+				RequiresReplace: []*tftypes.AttributePath{
+					tftypes.NewAttributePath().WithAttributeName("name"),
+				},
+			},
+			target: func() any { return new(tfprotov6.PlanResourceChangeResponse) },
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := encode(testCase.value)
+
+			if err != nil {
+				t.Fatalf("unexpected encode error: %s", err)
+			}
+
+			b, err := json.Marshal(encoded)
+
+			if err != nil {
+				t.Fatalf("unexpected marshal error: %s", err)
+			}
+
+			got := testCase.target()
+
+			if err := decode(decodeJSON(b), got); err != nil {
+				t.Fatalf("unexpected decode error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.value, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package providercassette records the RPCs between Terraform and the provider
+// servers under test to a cassette file, and replays the recorded responses,
+// so that acceptance tests can be rerun without access to the remote system.
+package providercassette
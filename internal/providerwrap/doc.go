@@ -0,0 +1,7 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package providerwrap wraps protocol version 5 and 6 provider servers so that
+// every RPC can be intercepted, such as to record, replay, or modify the RPC
+// requests and responses between Terraform and a provider under test.
+package providerwrap
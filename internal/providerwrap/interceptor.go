@@ -0,0 +1,102 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerwrap
+
+import (
+	"context"
+	"reflect"
+)
+
+// Call is a single RPC to a wrapped provider server.
+type Call struct {
+	// ProtocolVersion is the protocol version of the provider server, either
+	// 5 or 6.
+	ProtocolVersion int
+
+	// RPC is the name of the RPC, such as "ReadResource".
+	RPC string
+
+	// Request is the RPC request, such as *tfprotov5.ReadResourceRequest.
+	Request any
+
+	// NewResponse returns a new, empty RPC response of the type returned by
+	// the RPC, such as *tfprotov5.ReadResourceResponse.
+	NewResponse func() any
+
+	// Server is the wrapped provider server, either a
+	// tfprotov5.ProviderServer or tfprotov6.ProviderServer, which can be
+	// called directly such as to retrieve the provider schema.
+	Server any
+}
+
+// TypeName returns the resource, data source, ephemeral resource, list
+// resource, or action type name of the RPC request, or an empty string if the
+// request has no type name.
+func (c Call) TypeName() string {
+	value := reflect.ValueOf(c.Request)
+
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return ""
+	}
+
+	field := value.Elem().FieldByName("TypeName")
+
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+
+	return field.String()
+}
+
+// Handler calls the next Interceptor, or the wrapped provider server if there
+// are no further interceptors, and returns the RPC response.
+type Handler func(ctx context.Context, call Call) (any, error)
+
+// Interceptor is called for every RPC to a wrapped provider server. It can
+// call next to continue with the RPC, or return its own response or error.
+// The response must be of the same type as the RPC response or nil.
+type Interceptor func(ctx context.Context, call Call, next Handler) (any, error)
+
+// Chain returns an Interceptor which calls each of the given interceptors in
+// order, so the first interceptor is the outermost.
+func Chain(interceptors ...Interceptor) Interceptor {
+	return func(ctx context.Context, call Call, next Handler) (any, error) {
+		handler := next
+
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor := interceptors[i]
+			inner := handler
+
+			handler = func(ctx context.Context, call Call) (any, error) {
+				return interceptor(ctx, call, inner)
+			}
+		}
+
+		return handler(ctx, call)
+	}
+}
+
+// intercept calls the interceptor for an RPC, where f calls the wrapped
+// provider server.
+func intercept[Req any, Resp any](ctx context.Context, interceptor Interceptor, server any, protocolVersion int, rpc string, req *Req, f func(context.Context, *Req) (*Resp, error)) (*Resp, error) {
+	call := Call{
+		ProtocolVersion: protocolVersion,
+		RPC:             rpc,
+		Request:         req,
+		NewResponse: func() any {
+			return new(Resp)
+		},
+		Server: server,
+	}
+
+	resp, err := interceptor(ctx, call, func(ctx context.Context, call Call) (any, error) {
+		typedReq, _ := call.Request.(*Req)
+
+		return f(ctx, typedReq)
+	})
+
+	typedResp, _ := resp.(*Resp)
+
+	return typedResp, err
+}
@@ -0,0 +1,163 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerwrap_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/providerwrap"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+)
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+
+	recordCall := func(name string) providerwrap.Interceptor {
+		return func(ctx context.Context, call providerwrap.Call, next providerwrap.Handler) (any, error) {
+			calls = append(calls, name+" "+call.RPC)
+
+			return next(ctx, call)
+		}
+	}
+
+	server, err := providerserver.NewProviderServer(testprovider.Provider{})()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	wrapped := providerwrap.ProtoV6ProviderServer(server, providerwrap.Chain(recordCall("first"), recordCall("second")))
+
+	resp, err := wrapped.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resp == nil {
+		t.Fatal("expected response, got none")
+	}
+
+	expected := []string{
+		"first GetProviderSchema",
+		"second GetProviderSchema",
+	}
+
+	if diff := cmp.Diff(calls, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestProtoV6ProviderServer_Response(t *testing.T) {
+	t.Parallel()
+
+	server, err := providerserver.NewProviderServer(testprovider.Provider{})()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedResp := &tfprotov6.StopProviderResponse{
+		Error: "intercepted",
+	}
+
+	wrapped := providerwrap.ProtoV6ProviderServer(server, func(ctx context.Context, call providerwrap.Call, next providerwrap.Handler) (any, error) {
+		if _, ok := call.NewResponse().(*tfprotov6.StopProviderResponse); !ok {
+			t.Errorf("unexpected response type: %T", call.NewResponse())
+		}
+
+		return expectedResp, nil
+	})
+
+	resp, err := wrapped.StopProvider(context.Background(), &tfprotov6.StopProviderRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resp != expectedResp {
+		t.Errorf("expected intercepted response, got: %#v", resp)
+	}
+}
+
+func TestProtoV6ProviderServer_Error(t *testing.T) {
+	t.Parallel()
+
+	server, err := providerserver.NewProviderServer(testprovider.Provider{})()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedErr := errors.New("intercepted")
+
+	wrapped := providerwrap.ProtoV6ProviderServer(server, func(ctx context.Context, call providerwrap.Call, next providerwrap.Handler) (any, error) {
+		return nil, expectedErr
+	})
+
+	resp, err := wrapped.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{})
+
+	if !errors.Is(err, expectedErr) {
+		t.Errorf("expected intercepted error, got: %s", err)
+	}
+
+	if resp != nil {
+		t.Errorf("expected no response, got: %#v", resp)
+	}
+}
+
+func TestProtoV6ProviderServer_Capabilities(t *testing.T) {
+	t.Parallel()
+
+	server, err := providerserver.NewProviderServer(testprovider.Provider{})()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := map[string]struct {
+		server               tfprotov6.ProviderServer
+		expectedActions      bool
+		expectedListResource bool
+		expectedStateStores  bool
+	}{
+		"none": {
+			// Only the methods of the ProviderServer interface are promoted.
+			server: struct{ tfprotov6.ProviderServer }{server},
+		},
+		"list-resource-state-stores": {
+			server:               server,
+			expectedListResource: true,
+			expectedStateStores:  true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			wrapped := providerwrap.ProtoV6ProviderServer(testCase.server, providerwrap.Chain())
+
+			if _, got := wrapped.(tfprotov6.ProviderServerWithActions); got != testCase.expectedActions {
+				t.Errorf("expected actions %t, got %t", testCase.expectedActions, got)
+			}
+
+			if _, got := wrapped.(tfprotov6.ProviderServerWithListResource); got != testCase.expectedListResource {
+				t.Errorf("expected list resources %t, got %t", testCase.expectedListResource, got)
+			}
+
+			if _, got := wrapped.(tfprotov6.ProviderServerWithStateStores); got != testCase.expectedStateStores {
+				t.Errorf("expected state stores %t, got %t", testCase.expectedStateStores, got)
+			}
+		})
+	}
+}
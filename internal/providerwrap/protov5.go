@@ -0,0 +1,199 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerwrap
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+var (
+	_ tfprotov5.ProviderServer            = protoV5ProviderServer{}
+	_ tfprotov5.ProviderServerWithActions = struct {
+		protoV5ProviderServer
+		protoV5ActionServer
+	}{}
+	_ tfprotov5.ProviderServerWithListResource = struct {
+		protoV5ProviderServer
+		protoV5ListResourceServer
+	}{}
+)
+
+// ProtoV5ProviderServer returns a protocol version 5 provider server which calls
+// the interceptor for every RPC to the given provider server. The returned
+// server only implements the optional actions and list resources interfaces
+// which the given provider server implements, so that the same capabilities
+// are detected as for the given provider server.
+func ProtoV5ProviderServer(server tfprotov5.ProviderServer, interceptor Interceptor) tfprotov5.ProviderServer {
+	wrapped := protoV5ProviderServer{
+		interceptor: interceptor,
+		server:      server,
+	}
+
+	actionsServer, hasActions := server.(tfprotov5.ProviderServerWithActions)
+	listResourceServer, hasListResource := server.(tfprotov5.ProviderServerWithListResource)
+
+	actions := protoV5ActionServer{
+		interceptor: interceptor,
+		server:      actionsServer,
+	}
+
+	listResource := protoV5ListResourceServer{
+		interceptor: interceptor,
+		server:      listResourceServer,
+	}
+
+	switch {
+	case hasActions && hasListResource:
+		return struct {
+			protoV5ProviderServer
+			protoV5ActionServer
+			protoV5ListResourceServer
+		}{wrapped, actions, listResource}
+	case hasActions && !hasListResource:
+		return struct {
+			protoV5ProviderServer
+			protoV5ActionServer
+		}{wrapped, actions}
+	case !hasActions && hasListResource:
+		return struct {
+			protoV5ProviderServer
+			protoV5ListResourceServer
+		}{wrapped, listResource}
+	}
+
+	return wrapped
+}
+
+type protoV5ProviderServer struct {
+	interceptor Interceptor
+	server      tfprotov5.ProviderServer
+}
+
+func (s protoV5ProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "ApplyResourceChange", req, s.server.ApplyResourceChange)
+}
+
+func (s protoV5ProviderServer) CallFunction(ctx context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "CallFunction", req, s.server.CallFunction)
+}
+
+func (s protoV5ProviderServer) CloseEphemeralResource(ctx context.Context, req *tfprotov5.CloseEphemeralResourceRequest) (*tfprotov5.CloseEphemeralResourceResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "CloseEphemeralResource", req, s.server.CloseEphemeralResource)
+}
+
+func (s protoV5ProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "ConfigureProvider", req, s.server.ConfigureProvider)
+}
+
+func (s protoV5ProviderServer) GenerateResourceConfig(ctx context.Context, req *tfprotov5.GenerateResourceConfigRequest) (*tfprotov5.GenerateResourceConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "GenerateResourceConfig", req, s.server.GenerateResourceConfig)
+}
+
+func (s protoV5ProviderServer) GetFunctions(ctx context.Context, req *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "GetFunctions", req, s.server.GetFunctions)
+}
+
+func (s protoV5ProviderServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "GetMetadata", req, s.server.GetMetadata)
+}
+
+func (s protoV5ProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "GetProviderSchema", req, s.server.GetProviderSchema)
+}
+
+func (s protoV5ProviderServer) GetResourceIdentitySchemas(ctx context.Context, req *tfprotov5.GetResourceIdentitySchemasRequest) (*tfprotov5.GetResourceIdentitySchemasResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "GetResourceIdentitySchemas", req, s.server.GetResourceIdentitySchemas)
+}
+
+func (s protoV5ProviderServer) ImportResourceState(ctx context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "ImportResourceState", req, s.server.ImportResourceState)
+}
+
+func (s protoV5ProviderServer) MoveResourceState(ctx context.Context, req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "MoveResourceState", req, s.server.MoveResourceState)
+}
+
+func (s protoV5ProviderServer) OpenEphemeralResource(ctx context.Context, req *tfprotov5.OpenEphemeralResourceRequest) (*tfprotov5.OpenEphemeralResourceResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "OpenEphemeralResource", req, s.server.OpenEphemeralResource)
+}
+
+func (s protoV5ProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "PlanResourceChange", req, s.server.PlanResourceChange)
+}
+
+func (s protoV5ProviderServer) PrepareProviderConfig(ctx context.Context, req *tfprotov5.PrepareProviderConfigRequest) (*tfprotov5.PrepareProviderConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "PrepareProviderConfig", req, s.server.PrepareProviderConfig)
+}
+
+func (s protoV5ProviderServer) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "ReadDataSource", req, s.server.ReadDataSource)
+}
+
+func (s protoV5ProviderServer) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "ReadResource", req, s.server.ReadResource)
+}
+
+func (s protoV5ProviderServer) RenewEphemeralResource(ctx context.Context, req *tfprotov5.RenewEphemeralResourceRequest) (*tfprotov5.RenewEphemeralResourceResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "RenewEphemeralResource", req, s.server.RenewEphemeralResource)
+}
+
+func (s protoV5ProviderServer) StopProvider(ctx context.Context, req *tfprotov5.StopProviderRequest) (*tfprotov5.StopProviderResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "StopProvider", req, s.server.StopProvider)
+}
+
+func (s protoV5ProviderServer) UpgradeResourceIdentity(ctx context.Context, req *tfprotov5.UpgradeResourceIdentityRequest) (*tfprotov5.UpgradeResourceIdentityResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "UpgradeResourceIdentity", req, s.server.UpgradeResourceIdentity)
+}
+
+func (s protoV5ProviderServer) UpgradeResourceState(ctx context.Context, req *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "UpgradeResourceState", req, s.server.UpgradeResourceState)
+}
+
+func (s protoV5ProviderServer) ValidateDataSourceConfig(ctx context.Context, req *tfprotov5.ValidateDataSourceConfigRequest) (*tfprotov5.ValidateDataSourceConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "ValidateDataSourceConfig", req, s.server.ValidateDataSourceConfig)
+}
+
+func (s protoV5ProviderServer) ValidateEphemeralResourceConfig(ctx context.Context, req *tfprotov5.ValidateEphemeralResourceConfigRequest) (*tfprotov5.ValidateEphemeralResourceConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "ValidateEphemeralResourceConfig", req, s.server.ValidateEphemeralResourceConfig)
+}
+
+func (s protoV5ProviderServer) ValidateResourceTypeConfig(ctx context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "ValidateResourceTypeConfig", req, s.server.ValidateResourceTypeConfig)
+}
+
+// protoV5ActionServer calls the interceptor for the actions RPCs
+// of a provider server which implements them.
+type protoV5ActionServer struct {
+	interceptor Interceptor
+	server      tfprotov5.ProviderServerWithActions
+}
+
+func (s protoV5ActionServer) InvokeAction(ctx context.Context, req *tfprotov5.InvokeActionRequest) (*tfprotov5.InvokeActionServerStream, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "InvokeAction", req, s.server.InvokeAction)
+}
+
+func (s protoV5ActionServer) PlanAction(ctx context.Context, req *tfprotov5.PlanActionRequest) (*tfprotov5.PlanActionResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "PlanAction", req, s.server.PlanAction)
+}
+
+func (s protoV5ActionServer) ValidateActionConfig(ctx context.Context, req *tfprotov5.ValidateActionConfigRequest) (*tfprotov5.ValidateActionConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "ValidateActionConfig", req, s.server.ValidateActionConfig)
+}
+
+// protoV5ListResourceServer calls the interceptor for the list resources RPCs
+// of a provider server which implements them.
+type protoV5ListResourceServer struct {
+	interceptor Interceptor
+	server      tfprotov5.ProviderServerWithListResource
+}
+
+func (s protoV5ListResourceServer) ListResource(ctx context.Context, req *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "ListResource", req, s.server.ListResource)
+}
+
+func (s protoV5ListResourceServer) ValidateListResourceConfig(ctx context.Context, req *tfprotov5.ValidateListResourceConfigRequest) (*tfprotov5.ValidateListResourceConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 5, "ValidateListResourceConfig", req, s.server.ValidateListResourceConfig)
+}
@@ -0,0 +1,272 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerwrap
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+var (
+	_ tfprotov6.ProviderServer            = protoV6ProviderServer{}
+	_ tfprotov6.ProviderServerWithActions = struct {
+		protoV6ProviderServer
+		protoV6ActionServer
+	}{}
+	_ tfprotov6.ProviderServerWithListResource = struct {
+		protoV6ProviderServer
+		protoV6ListResourceServer
+	}{}
+	_ tfprotov6.ProviderServerWithStateStores = struct {
+		protoV6ProviderServer
+		protoV6StateStoreServer
+	}{}
+)
+
+// ProtoV6ProviderServer returns a protocol version 6 provider server which calls
+// the interceptor for every RPC to the given provider server. The returned
+// server only implements the optional actions, list resources, and state
+// stores interfaces which the given provider server implements, so that the
+// same capabilities are detected as for the given provider server.
+func ProtoV6ProviderServer(server tfprotov6.ProviderServer, interceptor Interceptor) tfprotov6.ProviderServer {
+	wrapped := protoV6ProviderServer{
+		interceptor: interceptor,
+		server:      server,
+	}
+
+	actionsServer, hasActions := server.(tfprotov6.ProviderServerWithActions)
+	listResourceServer, hasListResource := server.(tfprotov6.ProviderServerWithListResource)
+	stateStoresServer, hasStateStores := server.(tfprotov6.ProviderServerWithStateStores)
+
+	actions := protoV6ActionServer{
+		interceptor: interceptor,
+		server:      actionsServer,
+	}
+
+	listResource := protoV6ListResourceServer{
+		interceptor: interceptor,
+		server:      listResourceServer,
+	}
+
+	stateStores := protoV6StateStoreServer{
+		interceptor: interceptor,
+		server:      stateStoresServer,
+	}
+
+	switch {
+	case hasActions && hasListResource && hasStateStores:
+		return struct {
+			protoV6ProviderServer
+			protoV6ActionServer
+			protoV6ListResourceServer
+			protoV6StateStoreServer
+		}{wrapped, actions, listResource, stateStores}
+	case hasActions && hasListResource && !hasStateStores:
+		return struct {
+			protoV6ProviderServer
+			protoV6ActionServer
+			protoV6ListResourceServer
+		}{wrapped, actions, listResource}
+	case hasActions && !hasListResource && hasStateStores:
+		return struct {
+			protoV6ProviderServer
+			protoV6ActionServer
+			protoV6StateStoreServer
+		}{wrapped, actions, stateStores}
+	case hasActions && !hasListResource && !hasStateStores:
+		return struct {
+			protoV6ProviderServer
+			protoV6ActionServer
+		}{wrapped, actions}
+	case !hasActions && hasListResource && hasStateStores:
+		return struct {
+			protoV6ProviderServer
+			protoV6ListResourceServer
+			protoV6StateStoreServer
+		}{wrapped, listResource, stateStores}
+	case !hasActions && hasListResource && !hasStateStores:
+		return struct {
+			protoV6ProviderServer
+			protoV6ListResourceServer
+		}{wrapped, listResource}
+	case !hasActions && !hasListResource && hasStateStores:
+		return struct {
+			protoV6ProviderServer
+			protoV6StateStoreServer
+		}{wrapped, stateStores}
+	}
+
+	return wrapped
+}
+
+type protoV6ProviderServer struct {
+	interceptor Interceptor
+	server      tfprotov6.ProviderServer
+}
+
+func (s protoV6ProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ApplyResourceChange", req, s.server.ApplyResourceChange)
+}
+
+func (s protoV6ProviderServer) CallFunction(ctx context.Context, req *tfprotov6.CallFunctionRequest) (*tfprotov6.CallFunctionResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "CallFunction", req, s.server.CallFunction)
+}
+
+func (s protoV6ProviderServer) CloseEphemeralResource(ctx context.Context, req *tfprotov6.CloseEphemeralResourceRequest) (*tfprotov6.CloseEphemeralResourceResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "CloseEphemeralResource", req, s.server.CloseEphemeralResource)
+}
+
+func (s protoV6ProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ConfigureProvider", req, s.server.ConfigureProvider)
+}
+
+func (s protoV6ProviderServer) GenerateResourceConfig(ctx context.Context, req *tfprotov6.GenerateResourceConfigRequest) (*tfprotov6.GenerateResourceConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "GenerateResourceConfig", req, s.server.GenerateResourceConfig)
+}
+
+func (s protoV6ProviderServer) GetFunctions(ctx context.Context, req *tfprotov6.GetFunctionsRequest) (*tfprotov6.GetFunctionsResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "GetFunctions", req, s.server.GetFunctions)
+}
+
+func (s protoV6ProviderServer) GetMetadata(ctx context.Context, req *tfprotov6.GetMetadataRequest) (*tfprotov6.GetMetadataResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "GetMetadata", req, s.server.GetMetadata)
+}
+
+func (s protoV6ProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "GetProviderSchema", req, s.server.GetProviderSchema)
+}
+
+func (s protoV6ProviderServer) GetResourceIdentitySchemas(ctx context.Context, req *tfprotov6.GetResourceIdentitySchemasRequest) (*tfprotov6.GetResourceIdentitySchemasResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "GetResourceIdentitySchemas", req, s.server.GetResourceIdentitySchemas)
+}
+
+func (s protoV6ProviderServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ImportResourceState", req, s.server.ImportResourceState)
+}
+
+func (s protoV6ProviderServer) MoveResourceState(ctx context.Context, req *tfprotov6.MoveResourceStateRequest) (*tfprotov6.MoveResourceStateResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "MoveResourceState", req, s.server.MoveResourceState)
+}
+
+func (s protoV6ProviderServer) OpenEphemeralResource(ctx context.Context, req *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "OpenEphemeralResource", req, s.server.OpenEphemeralResource)
+}
+
+func (s protoV6ProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "PlanResourceChange", req, s.server.PlanResourceChange)
+}
+
+func (s protoV6ProviderServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ReadDataSource", req, s.server.ReadDataSource)
+}
+
+func (s protoV6ProviderServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ReadResource", req, s.server.ReadResource)
+}
+
+func (s protoV6ProviderServer) RenewEphemeralResource(ctx context.Context, req *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "RenewEphemeralResource", req, s.server.RenewEphemeralResource)
+}
+
+func (s protoV6ProviderServer) StopProvider(ctx context.Context, req *tfprotov6.StopProviderRequest) (*tfprotov6.StopProviderResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "StopProvider", req, s.server.StopProvider)
+}
+
+func (s protoV6ProviderServer) UpgradeResourceIdentity(ctx context.Context, req *tfprotov6.UpgradeResourceIdentityRequest) (*tfprotov6.UpgradeResourceIdentityResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "UpgradeResourceIdentity", req, s.server.UpgradeResourceIdentity)
+}
+
+func (s protoV6ProviderServer) UpgradeResourceState(ctx context.Context, req *tfprotov6.UpgradeResourceStateRequest) (*tfprotov6.UpgradeResourceStateResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "UpgradeResourceState", req, s.server.UpgradeResourceState)
+}
+
+func (s protoV6ProviderServer) ValidateDataResourceConfig(ctx context.Context, req *tfprotov6.ValidateDataResourceConfigRequest) (*tfprotov6.ValidateDataResourceConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ValidateDataResourceConfig", req, s.server.ValidateDataResourceConfig)
+}
+
+func (s protoV6ProviderServer) ValidateEphemeralResourceConfig(ctx context.Context, req *tfprotov6.ValidateEphemeralResourceConfigRequest) (*tfprotov6.ValidateEphemeralResourceConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ValidateEphemeralResourceConfig", req, s.server.ValidateEphemeralResourceConfig)
+}
+
+func (s protoV6ProviderServer) ValidateProviderConfig(ctx context.Context, req *tfprotov6.ValidateProviderConfigRequest) (*tfprotov6.ValidateProviderConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ValidateProviderConfig", req, s.server.ValidateProviderConfig)
+}
+
+func (s protoV6ProviderServer) ValidateResourceConfig(ctx context.Context, req *tfprotov6.ValidateResourceConfigRequest) (*tfprotov6.ValidateResourceConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ValidateResourceConfig", req, s.server.ValidateResourceConfig)
+}
+
+// protoV6ActionServer calls the interceptor for the actions RPCs
+// of a provider server which implements them.
+type protoV6ActionServer struct {
+	interceptor Interceptor
+	server      tfprotov6.ProviderServerWithActions
+}
+
+func (s protoV6ActionServer) InvokeAction(ctx context.Context, req *tfprotov6.InvokeActionRequest) (*tfprotov6.InvokeActionServerStream, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "InvokeAction", req, s.server.InvokeAction)
+}
+
+func (s protoV6ActionServer) PlanAction(ctx context.Context, req *tfprotov6.PlanActionRequest) (*tfprotov6.PlanActionResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "PlanAction", req, s.server.PlanAction)
+}
+
+func (s protoV6ActionServer) ValidateActionConfig(ctx context.Context, req *tfprotov6.ValidateActionConfigRequest) (*tfprotov6.ValidateActionConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ValidateActionConfig", req, s.server.ValidateActionConfig)
+}
+
+// protoV6ListResourceServer calls the interceptor for the list resources RPCs
+// of a provider server which implements them.
+type protoV6ListResourceServer struct {
+	interceptor Interceptor
+	server      tfprotov6.ProviderServerWithListResource
+}
+
+func (s protoV6ListResourceServer) ListResource(ctx context.Context, req *tfprotov6.ListResourceRequest) (*tfprotov6.ListResourceServerStream, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ListResource", req, s.server.ListResource)
+}
+
+func (s protoV6ListResourceServer) ValidateListResourceConfig(ctx context.Context, req *tfprotov6.ValidateListResourceConfigRequest) (*tfprotov6.ValidateListResourceConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ValidateListResourceConfig", req, s.server.ValidateListResourceConfig)
+}
+
+// protoV6StateStoreServer calls the interceptor for the state stores RPCs
+// of a provider server which implements them.
+type protoV6StateStoreServer struct {
+	interceptor Interceptor
+	server      tfprotov6.ProviderServerWithStateStores
+}
+
+func (s protoV6StateStoreServer) ConfigureStateStore(ctx context.Context, req *tfprotov6.ConfigureStateStoreRequest) (*tfprotov6.ConfigureStateStoreResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ConfigureStateStore", req, s.server.ConfigureStateStore)
+}
+
+func (s protoV6StateStoreServer) DeleteState(ctx context.Context, req *tfprotov6.DeleteStateRequest) (*tfprotov6.DeleteStateResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "DeleteState", req, s.server.DeleteState)
+}
+
+func (s protoV6StateStoreServer) GetStates(ctx context.Context, req *tfprotov6.GetStatesRequest) (*tfprotov6.GetStatesResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "GetStates", req, s.server.GetStates)
+}
+
+func (s protoV6StateStoreServer) LockState(ctx context.Context, req *tfprotov6.LockStateRequest) (*tfprotov6.LockStateResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "LockState", req, s.server.LockState)
+}
+
+func (s protoV6StateStoreServer) ReadStateBytes(ctx context.Context, req *tfprotov6.ReadStateBytesRequest) (*tfprotov6.ReadStateBytesStream, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ReadStateBytes", req, s.server.ReadStateBytes)
+}
+
+func (s protoV6StateStoreServer) UnlockState(ctx context.Context, req *tfprotov6.UnlockStateRequest) (*tfprotov6.UnlockStateResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "UnlockState", req, s.server.UnlockState)
+}
+
+func (s protoV6StateStoreServer) ValidateStateStoreConfig(ctx context.Context, req *tfprotov6.ValidateStateStoreConfigRequest) (*tfprotov6.ValidateStateStoreConfigResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "ValidateStateStoreConfig", req, s.server.ValidateStateStoreConfig)
+}
+
+func (s protoV6StateStoreServer) WriteStateBytes(ctx context.Context, req *tfprotov6.WriteStateBytesStream) (*tfprotov6.WriteStateBytesResponse, error) {
+	return intercept(ctx, s.interceptor, s.server, 6, "WriteStateBytes", req, s.server.WriteStateBytes)
+}
@@ -17,7 +17,11 @@ type Resource struct {
 	// will be passed to this function if available
 	CreateFunc func(context.Context, resource.CreateRequest, *resource.CreateResponse)
 
-	DeleteResponse      *resource.DeleteResponse
+	DeleteResponse *resource.DeleteResponse
+	// Some tests need more control over logic run during Delete, the struct defined DeleteResponse
+	// will be passed to this function if available
+	DeleteFunc func(context.Context, resource.DeleteRequest, *resource.DeleteResponse)
+
 	ImportStateResponse *resource.ImportStateResponse
 
 	// Planning happens multiple ways during a single TestStep, so statically
@@ -49,6 +53,10 @@ func (r Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *
 	if r.DeleteResponse != nil {
 		resp.Diagnostics = r.DeleteResponse.Diagnostics
 	}
+
+	if r.DeleteFunc != nil {
+		r.DeleteFunc(ctx, req, resp)
+	}
 }
 
 func (r Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -0,0 +1,24 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+// CheckStatus is a string stored in the plan which indicates the result of the custom
+// conditions and check block assertions for an object.
+type CheckStatus string
+
+const (
+	// CheckStatusPass is used to indicate that all conditions for the object passed.
+	CheckStatusPass CheckStatus = "pass"
+
+	// CheckStatusFail is used to indicate that at least one condition for the object failed.
+	CheckStatusFail CheckStatus = "fail"
+
+	// CheckStatusError is used to indicate that at least one condition for the object
+	// could not be evaluated due to an error.
+	CheckStatusError CheckStatus = "error"
+
+	// CheckStatusUnknown is used to indicate that the conditions for the object have not
+	// been evaluated, such as when they depend on values which are not yet known.
+	CheckStatusUnknown CheckStatus = "unknown"
+)
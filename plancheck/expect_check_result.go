@@ -0,0 +1,49 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/checkresult"
+)
+
+var _ PlanCheck = expectCheckResult{}
+
+type expectCheckResult struct {
+	address string
+	status  CheckStatus
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectCheckResult) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	status, instances, ok := checkresult.Find(req.Plan.Checks, e.address)
+
+	if !ok {
+		resp.Error = fmt.Errorf("%s - Check not found in plan Checks", e.address)
+
+		return
+	}
+
+	resp.Error = checkresult.StatusError(e.address, tfjson.CheckStatus(e.status), status, instances)
+}
+
+// ExpectCheckResult returns a plan check that asserts that the custom conditions or check
+// block assertions for the given address have the given status in the plan. The address
+// can be a check block, such as "check.example", a resource or data source, or an output
+// value, such as "output.example". Resource instance addresses, such as
+// "examplecloud_thing.test[0]", check the status of that instance only.
+//
+// Terraform only includes check results for objects which have custom conditions or check
+// block assertions. Check block assertion failures are reported as warnings and do not cause
+// the plan to fail, so this check can be used to verify them with a CheckStatusFail status.
+func ExpectCheckResult(address string, status CheckStatus) PlanCheck {
+	return expectCheckResult{
+		address: address,
+		status:  status,
+	}
+}
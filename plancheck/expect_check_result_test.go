@@ -0,0 +1,171 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"regexp"
+	"testing"
+
+	r "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func Test_ExpectCheckResult_CheckBlockFail(t *testing.T) {
+	t.Parallel()
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0), // check blocks
+		},
+		ExternalProviders: map[string]r.ExternalProvider{
+			"terraform": {Source: "terraform.io/builtin/terraform"},
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "terraform_data" "test" {
+					input = "hello"
+
+					lifecycle {
+						postcondition {
+							condition     = self.input == "hello"
+							error_message = "input must be hello"
+						}
+					}
+				}
+
+				check "example" {
+					assert {
+						condition     = terraform_data.test.input == "world"
+						error_message = "input must be world"
+					}
+				}`,
+				ConfigPlanChecks: r.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectCheckResult("check.example", plancheck.CheckStatusFail),
+					},
+				},
+			},
+		},
+	})
+}
+
+func Test_ExpectCheckResult_Postcondition(t *testing.T) {
+	t.Parallel()
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0), // check blocks
+		},
+		ExternalProviders: map[string]r.ExternalProvider{
+			"terraform": {Source: "terraform.io/builtin/terraform"},
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "terraform_data" "test" {
+					input = "hello"
+
+					lifecycle {
+						postcondition {
+							condition     = self.input == "hello"
+							error_message = "input must be hello"
+						}
+					}
+				}
+
+				check "example" {
+					assert {
+						condition     = terraform_data.test.input == "world"
+						error_message = "input must be world"
+					}
+				}`,
+				ConfigPlanChecks: r.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectCheckResult("terraform_data.test", plancheck.CheckStatusPass),
+					},
+				},
+			},
+		},
+	})
+}
+
+func Test_ExpectCheckResult_StatusMismatch(t *testing.T) {
+	t.Parallel()
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0), // check blocks
+		},
+		ExternalProviders: map[string]r.ExternalProvider{
+			"terraform": {Source: "terraform.io/builtin/terraform"},
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "terraform_data" "test" {
+					input = "hello"
+
+					lifecycle {
+						postcondition {
+							condition     = self.input == "hello"
+							error_message = "input must be hello"
+						}
+					}
+				}
+
+				check "example" {
+					assert {
+						condition     = terraform_data.test.input == "world"
+						error_message = "input must be world"
+					}
+				}`,
+				ConfigPlanChecks: r.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectCheckResult("check.example", plancheck.CheckStatusPass),
+					},
+				},
+				ExpectError: regexp.MustCompile(`check.example - expected check status pass, got: fail\n\ninput must be world`),
+			},
+		},
+	})
+}
+
+func Test_ExpectCheckResult_NotFound(t *testing.T) {
+	t.Parallel()
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0), // check blocks
+		},
+		ExternalProviders: map[string]r.ExternalProvider{
+			"terraform": {Source: "terraform.io/builtin/terraform"},
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "terraform_data" "test" {
+					input = "hello"
+
+					lifecycle {
+						postcondition {
+							condition     = self.input == "hello"
+							error_message = "input must be hello"
+						}
+					}
+				}
+
+				check "example" {
+					assert {
+						condition     = terraform_data.test.input == "world"
+						error_message = "input must be world"
+					}
+				}`,
+				ConfigPlanChecks: r.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectCheckResult("check.missing", plancheck.CheckStatusPass),
+					},
+				},
+				ExpectError: regexp.MustCompile(`check.missing - Check not found in plan Checks`),
+			},
+		},
+	})
+}
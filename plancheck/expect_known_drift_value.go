@@ -0,0 +1,73 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// Resource Plan Check
+var _ PlanCheck = expectKnownDriftValue{}
+
+type expectKnownDriftValue struct {
+	resourceAddress string
+	attributePath   tfjsonpath.Path
+	knownValue      knownvalue.Check
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectKnownDriftValue) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	var rc *tfjson.ResourceChange
+
+	if req.Plan == nil {
+		resp.Error = fmt.Errorf("plan is nil")
+
+		return
+	}
+
+	for _, resourceDrift := range req.Plan.ResourceDrift {
+		if e.resourceAddress == resourceDrift.Address {
+			rc = resourceDrift
+
+			break
+		}
+	}
+
+	if rc == nil {
+		resp.Error = fmt.Errorf("%s - Resource not found in plan ResourceDrift", e.resourceAddress)
+
+		return
+	}
+
+	result, err := tfjsonpath.Traverse(rc.Change.After, e.attributePath)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if err := e.knownValue.CheckValue(result); err != nil {
+		resp.Error = fmt.Errorf("error checking drifted value for attribute at path: %s.%s, err: %s", e.resourceAddress, e.attributePath.String(), err)
+
+		return
+	}
+}
+
+// ExpectKnownDriftValue returns a plan check that asserts that the specified attribute at the given resource
+// has drifted to a known type and value, as detected by Terraform while refreshing and reported in the plan
+// resource_drift entries.
+func ExpectKnownDriftValue(resourceAddress string, attributePath tfjsonpath.Path, knownValue knownvalue.Check) PlanCheck {
+	return expectKnownDriftValue{
+		resourceAddress: resourceAddress,
+		attributePath:   attributePath,
+		knownValue:      knownValue,
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	r "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestExpectKnownDriftValue_CheckPlan_String(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: driftResourceState("original"),
+	}
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": driftResource(readResponse),
+				},
+			}),
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "test_resource" "one" {}`,
+			},
+			{
+				Drift: func(ctx context.Context, req r.DriftRequest) error {
+					readResponse.NewState = driftResourceState("drifted")
+
+					return nil
+				},
+				DriftPlanChecks: r.DriftPlanChecks{
+					PostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectKnownDriftValue(
+							"test_resource.one",
+							tfjsonpath.New("value"),
+							knownvalue.StringExact("drifted"),
+						),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestExpectKnownDriftValue_CheckPlan_String_KnownValueWrongValue(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: driftResourceState("original"),
+	}
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": driftResource(readResponse),
+				},
+			}),
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "test_resource" "one" {}`,
+			},
+			{
+				Drift: func(ctx context.Context, req r.DriftRequest) error {
+					readResponse.NewState = driftResourceState("drifted")
+
+					return nil
+				},
+				DriftPlanChecks: r.DriftPlanChecks{
+					PostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectKnownDriftValue(
+							"test_resource.one",
+							tfjsonpath.New("value"),
+							knownvalue.StringExact("original"),
+						),
+					},
+				},
+				ExpectError: regexp.MustCompile(`error checking drifted value for attribute at path: test_resource.one.value, err: expected value original for StringExact check, got: drifted`),
+			},
+		},
+	})
+}
+
+func TestExpectKnownDriftValue_CheckPlan_ResourceNotFound(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: driftResourceState("original"),
+	}
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": driftResource(readResponse),
+				},
+			}),
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "test_resource" "one" {}`,
+			},
+			{
+				Drift: func(ctx context.Context, req r.DriftRequest) error {
+					readResponse.NewState = driftResourceState("drifted")
+
+					return nil
+				},
+				DriftPlanChecks: r.DriftPlanChecks{
+					PostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectKnownDriftValue(
+							"test_resource.two",
+							tfjsonpath.New("value"),
+							knownvalue.StringExact("drifted"),
+						),
+					},
+				},
+				ExpectError: regexp.MustCompile(`test_resource.two - Resource not found in plan ResourceDrift`),
+			},
+		},
+	})
+}
@@ -0,0 +1,32 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var _ PlanCheck = expectNoResourceDrift{}
+
+type expectNoResourceDrift struct{}
+
+// CheckPlan implements the plan check logic.
+func (e expectNoResourceDrift) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	var result []error
+
+	for _, rc := range req.Plan.ResourceDrift {
+		result = append(result, fmt.Errorf("expected no resource drift, but %s has drifted with action(s): %v", rc.Address, rc.Change.Actions))
+	}
+
+	resp.Error = errors.Join(result...)
+}
+
+// ExpectNoResourceDrift returns a plan check that asserts that Terraform did not detect changes made outside of
+// Terraform to any resource while refreshing. All resource drift found will be aggregated and returned in a plan
+// check error.
+func ExpectNoResourceDrift() PlanCheck {
+	return expectNoResourceDrift{}
+}
@@ -0,0 +1,91 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	r "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func Test_ExpectNoResourceDrift(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: driftResourceState("original"),
+	}
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": driftResource(readResponse),
+				},
+			}),
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "test_resource" "one" {}`,
+			},
+			{
+				RefreshState: true,
+				RefreshPlanChecks: r.RefreshPlanChecks{
+					PostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectNoResourceDrift(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func Test_ExpectNoResourceDrift_Drifted(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: driftResourceState("original"),
+	}
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": driftResource(readResponse),
+				},
+			}),
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "test_resource" "one" {}`,
+			},
+			{
+				Drift: func(ctx context.Context, req r.DriftRequest) error {
+					readResponse.NewState = driftResourceState("drifted")
+
+					return nil
+				},
+				DriftPlanChecks: r.DriftPlanChecks{
+					PostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectNoResourceDrift(),
+					},
+				},
+				ExpectError: regexp.MustCompile(`expected no resource drift, but test_resource.one has drifted with action\(s\): \[update\]`),
+			},
+		},
+	})
+}
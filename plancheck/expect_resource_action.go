@@ -65,6 +65,11 @@ func (e expectResourceAction) CheckPlan(ctx context.Context, req CheckPlanReques
 				resp.Error = fmt.Errorf("%s - expected %s, got action(s): %v", rc.Address, e.actionType, rc.Change.Actions)
 				return
 			}
+		case ResourceActionForget:
+			if !rc.Change.Actions.Forget() {
+				resp.Error = fmt.Errorf("'%s' - expected %s, got action(s): %v", rc.Address, e.actionType, rc.Change.Actions)
+				return
+			}
 		default:
 			resp.Error = fmt.Errorf("%s - unexpected ResourceActionType: %s", rc.Address, e.actionType)
 			return
@@ -9,6 +9,7 @@ import (
 
 	r "github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func Test_ExpectedResourceAction_NoOp(t *testing.T) {
@@ -532,3 +533,59 @@ func Test_ExpectedResourceAction_InvalidResourceActionType(t *testing.T) {
 		},
 	})
 }
+
+func Test_ExpectedResourceAction_Forget(t *testing.T) {
+	t.Parallel()
+
+	r.Test(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0), // removed blocks
+		},
+		ExternalProviders: map[string]r.ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "random_string" "one" {
+					length = 16
+				}`,
+			},
+			{
+				Forget:       true,
+				ResourceName: "random_string.one",
+				ForgetPlanChecks: r.ForgetPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("random_string.one", plancheck.ResourceActionForget),
+					},
+				},
+			},
+		},
+	})
+}
+
+func Test_ExpectedResourceAction_Forget_NoMatch(t *testing.T) {
+	t.Parallel()
+
+	r.Test(t, r.TestCase{
+		ExternalProviders: map[string]r.ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "random_string" "one" {
+					length = 16
+				}`,
+				ConfigPlanChecks: r.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("random_string.one", plancheck.ResourceActionForget),
+					},
+				},
+				ExpectError: regexp.MustCompile(`expected Forget, got action\(s\): \[create\]`),
+			},
+		},
+	})
+}
@@ -0,0 +1,34 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ PlanCheck = expectResourceDrift{}
+
+type expectResourceDrift struct {
+	resourceAddress string
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectResourceDrift) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	for _, rc := range req.Plan.ResourceDrift {
+		if e.resourceAddress == rc.Address {
+			return
+		}
+	}
+
+	resp.Error = fmt.Errorf("%s - Resource not found in plan ResourceDrift", e.resourceAddress)
+}
+
+// ExpectResourceDrift returns a plan check that asserts that Terraform detected changes made outside of
+// Terraform to the given resource while refreshing, which are reported in the plan resource_drift entries.
+func ExpectResourceDrift(resourceAddress string) PlanCheck {
+	return expectResourceDrift{
+		resourceAddress: resourceAddress,
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	r "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testprovider"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/providerserver"
+	"github.com/hashicorp/terraform-plugin-testing/internal/testing/testsdk/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func Test_ExpectResourceDrift(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: driftResourceState("original"),
+	}
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": driftResource(readResponse),
+				},
+			}),
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "test_resource" "one" {}`,
+			},
+			{
+				Drift: func(ctx context.Context, req r.DriftRequest) error {
+					readResponse.NewState = driftResourceState("drifted")
+
+					return nil
+				},
+				DriftPlanChecks: r.DriftPlanChecks{
+					PostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceDrift("test_resource.one"),
+					},
+				},
+			},
+		},
+	})
+}
+
+func Test_ExpectResourceDrift_NotFound(t *testing.T) {
+	t.Parallel()
+
+	readResponse := &resource.ReadResponse{
+		NewState: driftResourceState("original"),
+	}
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // ProtoV6ProviderFactories
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": providerserver.NewProviderServer(testprovider.Provider{
+				Resources: map[string]testprovider.Resource{
+					"test_resource": driftResource(readResponse),
+				},
+			}),
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "test_resource" "one" {}`,
+			},
+			{
+				Drift: func(ctx context.Context, req r.DriftRequest) error {
+					readResponse.NewState = driftResourceState("drifted")
+
+					return nil
+				},
+				DriftPlanChecks: r.DriftPlanChecks{
+					PostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceDrift("test_resource.two"),
+					},
+				},
+				ExpectError: regexp.MustCompile(`test_resource.two - Resource not found in plan ResourceDrift`),
+			},
+		},
+	})
+}
+
+// driftResource returns a resource whose remote object is represented by the
+// given ReadResponse, which a TestStep Drift function can modify.
+func driftResource(readResponse *resource.ReadResponse) testprovider.Resource {
+	return testprovider.Resource{
+		CreateResponse: &resource.CreateResponse{
+			NewState: driftResourceState("original"),
+		},
+		ReadResponse: readResponse,
+		SchemaResponse: &resource.SchemaResponse{
+			Schema: &tfprotov6.Schema{
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "id",
+							Type:     tftypes.String,
+							Computed: true,
+						},
+						{
+							Name:     "value",
+							Type:     tftypes.String,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func driftResourceState(value string) tftypes.Value {
+	return tftypes.NewValue(
+		tftypes.Object{
+			AttributeTypes: map[string]tftypes.Type{
+				"id":    tftypes.String,
+				"value": tftypes.String,
+			},
+		},
+		map[string]tftypes.Value{
+			"id":    tftypes.NewValue(tftypes.String, "test"),
+			"value": tftypes.NewValue(tftypes.String, value),
+		},
+	)
+}
@@ -0,0 +1,101 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ PlanCheck = expectWriteOnlyNull{}
+
+type expectWriteOnlyNull struct {
+	resourceAddress string
+	attributePath   tfjsonpath.Path
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectWriteOnlyNull) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	var rc *tfjson.ResourceChange
+
+	if req.Plan == nil {
+		resp.Error = fmt.Errorf("plan is nil")
+
+		return
+	}
+
+	for _, resourceChange := range req.Plan.ResourceChanges {
+		if e.resourceAddress == resourceChange.Address {
+			rc = resourceChange
+
+			break
+		}
+	}
+
+	if rc == nil {
+		resp.Error = fmt.Errorf("%s - Resource not found in plan", e.resourceAddress)
+
+		return
+	}
+
+	if rc.Change.Before != nil {
+		result, err := tfjsonpath.Traverse(rc.Change.Before, e.attributePath)
+
+		if err != nil {
+			resp.Error = err
+
+			return
+		}
+
+		if result != nil {
+			resp.Error = fmt.Errorf("expected null prior value for write-only attribute at path: %s.%s, got: non-null value", e.resourceAddress, e.attributePath.String())
+
+			return
+		}
+	}
+
+	if rc.Change.After == nil {
+		return
+	}
+
+	result, err := tfjsonpath.Traverse(rc.Change.After, e.attributePath)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if result != nil {
+		resp.Error = fmt.Errorf("expected null planned value for write-only attribute at path: %s.%s, got: non-null value", e.resourceAddress, e.attributePath.String())
+
+		return
+	}
+
+	// Unknown values are represented as null in After, so the path must not
+	// be marked as unknown. AfterUnknown omits known values, so a missing
+	// path is known.
+	if unknown, err := tfjsonpath.Traverse(rc.Change.AfterUnknown, e.attributePath); err == nil && unknown == true {
+		resp.Error = fmt.Errorf("expected null planned value for write-only attribute at path: %s.%s, got: unknown", e.resourceAddress, e.attributePath.String())
+
+		return
+	}
+}
+
+// ExpectWriteOnlyNull returns a plan check that asserts that the specified write-only attribute at the given
+// resource is null in both the prior and planned values. Terraform never persists write-only attribute values,
+// so a provider must always return null for them. The prior value is only checked if the resource exists, and
+// the planned value is only checked if the resource is not planned for destruction.
+//
+// The value is not output on failure. Use rpccheck.ExpectWriteOnlyValue to assert that the configured value reaches the provider.
+func ExpectWriteOnlyNull(resourceAddress string, attributePath tfjsonpath.Path) PlanCheck {
+	return expectWriteOnlyNull{
+		resourceAddress: resourceAddress,
+		attributePath:   attributePath,
+	}
+}
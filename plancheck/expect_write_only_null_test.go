@@ -0,0 +1,134 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpectWriteOnlyNull_CheckPlan(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		change      *tfjson.Change
+		expectedErr error
+	}{
+		"create": {
+			change: &tfjson.Change{
+				After: map[string]any{
+					"password_wo": nil,
+				},
+			},
+		},
+		"update": {
+			change: &tfjson.Change{
+				Before: map[string]any{
+					"password_wo": nil,
+				},
+				After: map[string]any{
+					"password_wo": nil,
+				},
+				AfterUnknown: map[string]any{
+					"id": true,
+				},
+			},
+		},
+		"destroy": {
+			change: &tfjson.Change{
+				Before: map[string]any{
+					"password_wo": nil,
+				},
+			},
+		},
+		"before-not-null": {
+			change: &tfjson.Change{
+				Before: map[string]any{
+					"password_wo": "hunter2",
+				},
+				After: map[string]any{
+					"password_wo": nil,
+				},
+			},
+			expectedErr: fmt.Errorf("expected null prior value for write-only attribute at path: example_resource.test.password_wo, got: non-null value"),
+		},
+		"after-not-null": {
+			change: &tfjson.Change{
+				After: map[string]any{
+					"password_wo": "hunter2",
+				},
+			},
+			expectedErr: fmt.Errorf("expected null planned value for write-only attribute at path: example_resource.test.password_wo, got: non-null value"),
+		},
+		"after-unknown": {
+			change: &tfjson.Change{
+				After: map[string]any{
+					"password_wo": nil,
+				},
+				AfterUnknown: map[string]any{
+					"password_wo": true,
+				},
+			},
+			expectedErr: fmt.Errorf("expected null planned value for write-only attribute at path: example_resource.test.password_wo, got: unknown"),
+		},
+		"path-not-found": {
+			change: &tfjson.Change{
+				After: map[string]any{
+					"password": nil,
+				},
+			},
+			expectedErr: fmt.Errorf("path not found: specified key password_wo not found in map at password_wo"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			e := plancheck.ExpectWriteOnlyNull("example_resource.test", tfjsonpath.New("password_wo"))
+
+			req := plancheck.CheckPlanRequest{
+				Plan: &tfjson.Plan{
+					ResourceChanges: []*tfjson.ResourceChange{
+						{
+							Address: "example_resource.test",
+							Change:  testCase.change,
+						},
+					},
+				},
+			}
+
+			resp := plancheck.CheckPlanResponse{}
+
+			e.CheckPlan(context.Background(), req, &resp)
+
+			if diff := cmp.Diff(resp.Error, testCase.expectedErr, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestExpectWriteOnlyNull_CheckPlan_ResourceNotFound(t *testing.T) {
+	t.Parallel()
+
+	e := plancheck.ExpectWriteOnlyNull("example_resource.other", tfjsonpath.New("password_wo"))
+
+	resp := plancheck.CheckPlanResponse{}
+
+	e.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: &tfjson.Plan{}}, &resp)
+
+	expectedErr := fmt.Errorf("example_resource.other - Resource not found in plan")
+
+	if diff := cmp.Diff(resp.Error, expectedErr, equateErrorMessage); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
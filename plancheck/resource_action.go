@@ -47,4 +47,11 @@ const (
 	// This action matches both ResourceActionDestroyBeforeCreate and ResourceActionCreateBeforeDestroy.
 	//   - Routes to: https://pkg.go.dev/github.com/hashicorp/terraform-json#Actions.Replace
 	ResourceActionReplace ResourceActionType = "Replace"
+
+	// ResourceActionForget occurs when a resource is planned to be removed from state without being destroyed. This is the
+	// behavior of a [removed] block with the destroy lifecycle argument set to false.
+	//   - Routes to: https://pkg.go.dev/github.com/hashicorp/terraform-json#Actions.Forget
+	//
+	// [removed]: https://developer.hashicorp.com/terraform/language/resources/syntax#removing-resources
+	ResourceActionForget ResourceActionType = "Forget"
 )
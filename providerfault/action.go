@@ -0,0 +1,46 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerfault
+
+import (
+	"context"
+)
+
+// Action defines an interface for implementing a fault which is injected into an RPC to an in-process provider
+// server.
+type Action interface {
+	// InjectFault should perform the fault injection.
+	InjectFault(context.Context, InjectFaultRequest, *InjectFaultResponse)
+}
+
+// InjectFaultRequest is a request for an invoke of the InjectFault function.
+type InjectFaultRequest struct {
+	// ProtocolVersion is the protocol version of the provider server, either 5 or 6.
+	ProtocolVersion int
+
+	// RPC is the name of the RPC, such as "ApplyResourceChange".
+	RPC string
+
+	// Request is the RPC request, such as *tfprotov5.ApplyResourceChangeRequest or
+	// *tfprotov6.ApplyResourceChangeRequest.
+	Request any
+
+	// NewResponse returns a new, empty RPC response of the type returned by the RPC, such as
+	// *tfprotov6.ApplyResourceChangeResponse, for actions which respond without calling the provider server.
+	NewResponse func() any
+
+	// CallProvider calls the provider server with the RPC request and returns its response. Actions which do
+	// not call it prevent the provider server from receiving the RPC.
+	CallProvider func(context.Context) (any, error)
+}
+
+// InjectFaultResponse is a response to an invoke of the InjectFault function.
+type InjectFaultResponse struct {
+	// Response is the RPC response returned to Terraform, which must be of the same type as the RPC response or
+	// nil.
+	Response any
+
+	// Error is the RPC error returned to Terraform.
+	Error error
+}
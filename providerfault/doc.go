@@ -0,0 +1,6 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package providerfault contains the fault and action types, and common action implementations, for injecting
+// faults into the RPCs to in-process provider servers during a TestStep.
+package providerfault
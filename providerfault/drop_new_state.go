@@ -0,0 +1,46 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerfault
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+var _ Action = dropNewState{}
+
+type dropNewState struct{}
+
+// InjectFault implements the fault injection logic.
+func (a dropNewState) InjectFault(ctx context.Context, req InjectFaultRequest, resp *InjectFaultResponse) {
+	resp.Response, resp.Error = req.CallProvider(ctx)
+
+	if resp.Response == nil {
+		return
+	}
+
+	value := reflect.ValueOf(resp.Response)
+
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return
+	}
+
+	newState := value.Elem().FieldByName("NewState")
+
+	if !newState.IsValid() {
+		resp.Error = fmt.Errorf("cannot drop new state from %s response", req.RPC)
+
+		return
+	}
+
+	newState.Set(reflect.Zero(newState.Type()))
+}
+
+// DropNewState returns an action which calls the provider server, then removes the new state from the response,
+// such as to simulate a provider which loses track of a remote object it created in ApplyResourceChange or read
+// in ReadResource. The RPC must have a response with new state.
+func DropNewState() Action {
+	return dropNewState{}
+}
@@ -0,0 +1,75 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerfault_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-testing/providerfault"
+)
+
+func TestDropNewState(t *testing.T) {
+	t.Parallel()
+
+	var called bool
+
+	req := providerfault.InjectFaultRequest{
+		ProtocolVersion: 6,
+		RPC:             "ApplyResourceChange",
+		Request:         &tfprotov6.ApplyResourceChangeRequest{TypeName: "test_resource"},
+		NewResponse:     func() any { return new(tfprotov6.ApplyResourceChangeResponse) },
+		CallProvider: func(context.Context) (any, error) {
+			called = true
+
+			return &tfprotov6.ApplyResourceChangeResponse{
+				NewState: &tfprotov6.DynamicValue{JSON: []byte(`{"id":"test"}`)},
+				Private:  []byte("private"),
+			}, nil
+		},
+	}
+
+	resp := providerfault.InjectFaultResponse{}
+	providerfault.DropNewState().InjectFault(context.Background(), req, &resp)
+
+	if !called {
+		t.Error("expected provider server to be called")
+	}
+
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	expected := &tfprotov6.ApplyResourceChangeResponse{
+		Private: []byte("private"),
+	}
+
+	if diff := cmp.Diff(resp.Response, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestDropNewState_NoNewState(t *testing.T) {
+	t.Parallel()
+
+	req := providerfault.InjectFaultRequest{
+		ProtocolVersion: 6,
+		RPC:             "PlanResourceChange",
+		Request:         &tfprotov6.PlanResourceChangeRequest{TypeName: "test_resource"},
+		NewResponse:     func() any { return new(tfprotov6.PlanResourceChangeResponse) },
+		CallProvider: func(context.Context) (any, error) {
+			return &tfprotov6.PlanResourceChangeResponse{}, nil
+		},
+	}
+
+	resp := providerfault.InjectFaultResponse{}
+	providerfault.DropNewState().InjectFault(context.Background(), req, &resp)
+
+	if resp.Error == nil || resp.Error.Error() != "cannot drop new state from PlanResourceChange response" {
+		t.Errorf("expected error, got: %v", resp.Error)
+	}
+}
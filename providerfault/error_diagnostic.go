@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerfault
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+var _ Action = errorDiagnostic{}
+
+type errorDiagnostic struct {
+	summary string
+	detail  string
+}
+
+// InjectFault implements the fault injection logic.
+func (a errorDiagnostic) InjectFault(ctx context.Context, req InjectFaultRequest, resp *InjectFaultResponse) {
+	response := req.NewResponse()
+	diagnostics := reflect.ValueOf(response).Elem().FieldByName("Diagnostics")
+
+	if !diagnostics.IsValid() {
+		resp.Error = fmt.Errorf("cannot inject error diagnostic into %s response", req.RPC)
+
+		return
+	}
+
+	var diagnostic any
+
+	switch req.ProtocolVersion {
+	case 5:
+		diagnostic = &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  a.summary,
+			Detail:   a.detail,
+		}
+	default:
+		diagnostic = &tfprotov6.Diagnostic{
+			Severity: tfprotov6.DiagnosticSeverityError,
+			Summary:  a.summary,
+			Detail:   a.detail,
+		}
+	}
+
+	diagnostics.Set(reflect.Append(diagnostics, reflect.ValueOf(diagnostic)))
+
+	resp.Response = response
+}
+
+// ErrorDiagnostic returns an action which responds to the RPC with an error diagnostic, without calling the
+// provider server, such as to simulate a remote API failure. For ApplyResourceChange, Terraform treats the
+// resource as not created or unchanged, since the response has no new state.
+func ErrorDiagnostic(summary string, detail string) Action {
+	return errorDiagnostic{
+		summary: summary,
+		detail:  detail,
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerfault_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-testing/providerfault"
+)
+
+func unexpectedCallProvider(t *testing.T) func(context.Context) (any, error) {
+	return func(context.Context) (any, error) {
+		t.Error("unexpected call to provider server")
+
+		return nil, nil
+	}
+}
+
+func TestErrorDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		req      providerfault.InjectFaultRequest
+		expected any
+	}{
+		"protov5": {
+			req: providerfault.InjectFaultRequest{
+				ProtocolVersion: 5,
+				RPC:             "ApplyResourceChange",
+				Request:         &tfprotov5.ApplyResourceChangeRequest{TypeName: "test_resource"},
+				NewResponse:     func() any { return new(tfprotov5.ApplyResourceChangeResponse) },
+			},
+			expected: &tfprotov5.ApplyResourceChangeResponse{
+				Diagnostics: []*tfprotov5.Diagnostic{
+					{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  "API error",
+						Detail:   "injected",
+					},
+				},
+			},
+		},
+		"protov6": {
+			req: providerfault.InjectFaultRequest{
+				ProtocolVersion: 6,
+				RPC:             "ReadResource",
+				Request:         &tfprotov6.ReadResourceRequest{TypeName: "test_resource"},
+				NewResponse:     func() any { return new(tfprotov6.ReadResourceResponse) },
+			},
+			expected: &tfprotov6.ReadResourceResponse{
+				Diagnostics: []*tfprotov6.Diagnostic{
+					{
+						Severity: tfprotov6.DiagnosticSeverityError,
+						Summary:  "API error",
+						Detail:   "injected",
+					},
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testCase.req.CallProvider = unexpectedCallProvider(t)
+
+			resp := providerfault.InjectFaultResponse{}
+			providerfault.ErrorDiagnostic("API error", "injected").InjectFault(context.Background(), testCase.req, &resp)
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if diff := cmp.Diff(resp.Response, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
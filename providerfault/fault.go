@@ -0,0 +1,27 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerfault
+
+// Fault injects an Action into matching RPCs from Terraform to the in-process provider servers during a TestStep,
+// such as returning an error diagnostic on the second ApplyResourceChange call for a resource type. Providers in
+// ExternalProviders are not affected.
+type Fault struct {
+	// RPC is the name of the RPC to inject the fault into, such as "ApplyResourceChange". Required.
+	RPC string
+
+	// Provider is the name of the provider to inject the fault into, such as "examplecloud". If empty, the fault
+	// is injected into the RPCs to all in-process providers.
+	Provider string
+
+	// TypeName is the resource, data source, ephemeral resource, list resource, or action type name of the RPC
+	// requests to inject the fault into. If empty, the fault is injected regardless of type name.
+	TypeName string
+
+	// Call is the 1-based number of the matching RPC call within the TestStep to inject the fault into, across
+	// all Terraform commands run by the TestStep. If zero, the fault is injected into every matching call.
+	Call int
+
+	// Action is the fault to inject. Required.
+	Action Action
+}
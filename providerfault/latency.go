@@ -0,0 +1,39 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerfault
+
+import (
+	"context"
+	"time"
+)
+
+var _ Action = latency{}
+
+type latency struct {
+	duration time.Duration
+}
+
+// InjectFault implements the fault injection logic.
+func (a latency) InjectFault(ctx context.Context, req InjectFaultRequest, resp *InjectFaultResponse) {
+	timer := time.NewTimer(a.duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		resp.Error = ctx.Err()
+
+		return
+	case <-timer.C:
+	}
+
+	resp.Response, resp.Error = req.CallProvider(ctx)
+}
+
+// Latency returns an action which waits for the given duration before calling the provider server, such as to
+// simulate a slow remote API.
+func Latency(duration time.Duration) Action {
+	return latency{
+		duration: duration,
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerfault_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-testing/providerfault"
+)
+
+func TestLatency(t *testing.T) {
+	t.Parallel()
+
+	expected := &tfprotov6.ReadResourceResponse{}
+
+	req := providerfault.InjectFaultRequest{
+		ProtocolVersion: 6,
+		RPC:             "ReadResource",
+		CallProvider: func(context.Context) (any, error) {
+			return expected, nil
+		},
+	}
+
+	start := time.Now()
+	resp := providerfault.InjectFaultResponse{}
+	providerfault.Latency(50*time.Millisecond).InjectFault(context.Background(), req, &resp)
+
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected at least 50ms latency, got: %s", elapsed)
+	}
+
+	if resp.Error != nil || resp.Response != expected {
+		t.Errorf("expected provider response, got: %v, %v", resp.Response, resp.Error)
+	}
+}
+
+func TestLatency_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := providerfault.InjectFaultRequest{
+		ProtocolVersion: 6,
+		RPC:             "ReadResource",
+		CallProvider:    unexpectedCallProvider(t),
+	}
+
+	resp := providerfault.InjectFaultResponse{}
+	providerfault.Latency(time.Hour).InjectFault(ctx, req, &resp)
+
+	if !errors.Is(resp.Error, context.Canceled) {
+		t.Errorf("expected context canceled error, got: %v", resp.Error)
+	}
+}
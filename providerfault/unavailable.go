@@ -0,0 +1,33 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerfault
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ Action = unavailable{}
+
+type unavailable struct {
+	message string
+}
+
+// InjectFault implements the fault injection logic.
+func (a unavailable) InjectFault(ctx context.Context, req InjectFaultRequest, resp *InjectFaultResponse) {
+	resp.Error = status.Errorf(codes.Unavailable, "provider unavailable during %s: %s", req.RPC, a.message)
+}
+
+// Unavailable returns an action which responds to the RPC with a gRPC Unavailable error, without calling the
+// provider server. This simulates a provider process which crashed during the RPC, such as from a panic, since
+// Terraform receives the same error when the provider connection is lost. The provider does not actually panic,
+// as a panic in an in-process provider server would also crash the test, and the later RPCs of the TestStep
+// are sent to the provider server as usual.
+func Unavailable(message string) Action {
+	return unavailable{
+		message: message,
+	}
+}
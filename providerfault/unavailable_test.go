@@ -0,0 +1,35 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package providerfault_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/terraform-plugin-testing/providerfault"
+)
+
+func TestUnavailable(t *testing.T) {
+	t.Parallel()
+
+	req := providerfault.InjectFaultRequest{
+		ProtocolVersion: 6,
+		RPC:             "ApplyResourceChange",
+		CallProvider:    unexpectedCallProvider(t),
+	}
+
+	resp := providerfault.InjectFaultResponse{}
+	providerfault.Unavailable("connection lost").InjectFault(context.Background(), req, &resp)
+
+	if status.Code(resp.Error) != codes.Unavailable {
+		t.Fatalf("expected unavailable error, got: %v", resp.Error)
+	}
+
+	if expected := "rpc error: code = Unavailable desc = provider unavailable during ApplyResourceChange: connection lost"; resp.Error.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, resp.Error)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck
+
+import (
+	"reflect"
+)
+
+// Call is a single RPC to an in-process provider server.
+type Call struct {
+	// Provider is the name of the provider, such as "examplecloud".
+	Provider string
+
+	// ProtocolVersion is the protocol version of the provider server, either 5 or 6.
+	ProtocolVersion int
+
+	// RPC is the name of the RPC, such as "ReadResource".
+	RPC string
+
+	// Command is the Terraform command which called the RPC, such as "plan", "apply", "destroy", "refresh", or
+	// "import", or an empty string if it is not known. RPCs of the refresh which Terraform runs as part of plan
+	// and apply are attributed to that command, so "refresh" only matches the RefreshState TestStep command.
+	Command string
+
+	// Request is the RPC request, such as *tfprotov5.ReadResourceRequest or *tfprotov6.ReadResourceRequest.
+	Request any
+
+	// Response is the RPC response, such as *tfprotov5.ReadResourceResponse or
+	// *tfprotov6.ReadResourceResponse, or nil if the RPC returned an error.
+	Response any
+
+	// Error is the error returned by the RPC, if any.
+	Error error
+
+	// ProviderSchema is the provider schema, either *tfprotov5.GetProviderSchemaResponse or
+	// *tfprotov6.GetProviderSchemaResponse, used to decode the dynamic values of the request and response.
+	ProviderSchema any
+}
+
+// TypeName returns the resource, data source, ephemeral resource, list resource, or action type name of the
+// RPC request, or an empty string if the request has no type name.
+func (c Call) TypeName() string {
+	return stringField(c.Request, "TypeName")
+}
+
+// stringField returns the value of a string field of a pointer to a struct, or an empty string if the field
+// does not exist.
+func stringField(v any, name string) string {
+	value := reflect.ValueOf(v)
+
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return ""
+	}
+
+	field := value.Elem().FieldByName(name)
+
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+
+	return field.String()
+}
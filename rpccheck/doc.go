@@ -0,0 +1,5 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package rpccheck contains the RPC check interface, request/response structs, and common RPC check implementations.
+package rpccheck
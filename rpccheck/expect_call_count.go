@@ -0,0 +1,67 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ RPCCheck = expectCallCount{}
+
+type expectCallCount struct {
+	command string
+	rpc     string
+	count   int
+}
+
+// CheckRPCs implements the RPC check logic.
+func (e expectCallCount) CheckRPCs(ctx context.Context, req CheckRPCsRequest, resp *CheckRPCsResponse) {
+	var count int
+
+	for _, call := range req.Calls {
+		if call.RPC != e.rpc {
+			continue
+		}
+
+		if e.command != "" && call.Command != e.command {
+			continue
+		}
+
+		count++
+	}
+
+	if count == e.count {
+		return
+	}
+
+	if e.command != "" {
+		resp.Error = fmt.Errorf("expected %d %s call(s) during %s, got: %d", e.count, e.rpc, e.command, count)
+
+		return
+	}
+
+	resp.Error = fmt.Errorf("expected %d %s call(s), got: %d", e.count, e.rpc, count)
+}
+
+// ExpectCallCount returns an RPC check that asserts that the RPC, such as "ConfigureProvider", was called the
+// given number of times on all in-process provider servers during the TestStep.
+func ExpectCallCount(rpc string, count int) RPCCheck {
+	return expectCallCount{
+		rpc:   rpc,
+		count: count,
+	}
+}
+
+// ExpectCommandCallCount returns an RPC check that asserts that the RPC, such as "ConfigureProvider", was called
+// the given number of times on all in-process provider servers during the given Terraform command of the
+// TestStep, such as "plan" or "apply". The RPCs of the refresh which Terraform runs as part of plan and apply
+// are counted for that command, as described in the Call Command documentation.
+func ExpectCommandCallCount(command string, rpc string, count int) RPCCheck {
+	return expectCallCount{
+		command: command,
+		rpc:     rpc,
+		count:   count,
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-testing/rpccheck"
+)
+
+func TestExpectCallCount(t *testing.T) {
+	t.Parallel()
+
+	calls := []rpccheck.Call{
+		{Provider: "test", ProtocolVersion: 6, RPC: "ConfigureProvider", Command: "plan", Request: &tfprotov6.ConfigureProviderRequest{}},
+		{Provider: "test", ProtocolVersion: 6, RPC: "ReadResource", Command: "plan", Request: &tfprotov6.ReadResourceRequest{TypeName: "test_resource"}},
+		{Provider: "test", ProtocolVersion: 6, RPC: "ReadResource", Command: "apply", Request: &tfprotov6.ReadResourceRequest{TypeName: "test_other"}},
+	}
+
+	testCases := map[string]struct {
+		check       rpccheck.RPCCheck
+		expectedErr *regexp.Regexp
+	}{
+		"match": {
+			check: rpccheck.ExpectCallCount("ReadResource", 2),
+		},
+		"match-zero": {
+			check: rpccheck.ExpectCallCount("UpgradeResourceState", 0),
+		},
+		"mismatch": {
+			check:       rpccheck.ExpectCallCount("ConfigureProvider", 2),
+			expectedErr: regexp.MustCompile(`^expected 2 ConfigureProvider call\(s\), got: 1$`),
+		},
+		"command-match": {
+			check: rpccheck.ExpectCommandCallCount("apply", "ReadResource", 1),
+		},
+		"command-mismatch": {
+			check:       rpccheck.ExpectCommandCallCount("apply", "ConfigureProvider", 1),
+			expectedErr: regexp.MustCompile(`^expected 1 ConfigureProvider call\(s\) during apply, got: 0$`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := rpccheck.CheckRPCsResponse{}
+			testCase.check.CheckRPCs(context.Background(), rpccheck.CheckRPCsRequest{Calls: calls}, &resp)
+
+			assertError(t, resp.Error, testCase.expectedErr)
+		})
+	}
+}
+
+func assertError(t *testing.T, err error, expectedErr *regexp.Regexp) {
+	t.Helper()
+
+	if expectedErr == nil {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		return
+	}
+
+	if err == nil {
+		t.Fatalf("expected error matching %q, got none", expectedErr)
+	}
+
+	if !expectedErr.MatchString(err.Error()) {
+		t.Fatalf("expected error matching %q, got: %s", expectedErr, err)
+	}
+}
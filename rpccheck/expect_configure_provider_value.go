@@ -0,0 +1,74 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ RPCCheck = expectConfigureProviderValue{}
+
+type expectConfigureProviderValue struct {
+	provider      string
+	attributePath tfjsonpath.Path
+	knownValue    knownvalue.Check
+}
+
+// CheckRPCs implements the RPC check logic.
+func (e expectConfigureProviderValue) CheckRPCs(ctx context.Context, req CheckRPCsRequest, resp *CheckRPCsResponse) {
+	var called bool
+	var errs []error
+
+	for _, call := range req.Calls {
+		if call.RPC != "ConfigureProvider" || call.Provider != e.provider {
+			continue
+		}
+
+		called = true
+
+		config, err := configureProviderConfig(call)
+
+		if err != nil {
+			resp.Error = fmt.Errorf("%s - %w", e.provider, err)
+
+			return
+		}
+
+		result, err := tfjsonpath.Traverse(config, e.attributePath)
+
+		if err != nil {
+			resp.Error = err
+
+			return
+		}
+
+		if err := e.knownValue.CheckValue(result); err != nil {
+			errs = append(errs, fmt.Errorf("error checking value for ConfigureProvider of %s at path: %s, err: %s", e.provider, e.attributePath.String(), err))
+		}
+	}
+
+	if !called {
+		resp.Error = fmt.Errorf("%s - ConfigureProvider was not called", e.provider)
+
+		return
+	}
+
+	resp.Error = errors.Join(errs...)
+}
+
+// ExpectConfigureProviderValue returns an RPC check that asserts that every ConfigureProvider call to the
+// named provider during the TestStep received configuration with the specified value at the given path, such
+// as the region. ConfigureProvider must be called at least once.
+func ExpectConfigureProviderValue(provider string, attributePath tfjsonpath.Path, knownValue knownvalue.Check) RPCCheck {
+	return expectConfigureProviderValue{
+		provider:      provider,
+		attributePath: attributePath,
+		knownValue:    knownValue,
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/rpccheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpectConfigureProviderValue(t *testing.T) {
+	t.Parallel()
+
+	providerSchema := &tfprotov5.GetProviderSchemaResponse{
+		Provider: &tfprotov5.Schema{
+			Block: &tfprotov5.SchemaBlock{
+				Attributes: []*tfprotov5.SchemaAttribute{
+					{
+						Name:     "region",
+						Type:     tftypes.String,
+						Optional: true,
+					},
+					{
+						Name:     "retries",
+						Type:     tftypes.Number,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	config, err := tfprotov5.NewDynamicValue(
+		providerSchema.Provider.ValueType(),
+		tftypes.NewValue(providerSchema.Provider.ValueType(), map[string]tftypes.Value{
+			"region":  tftypes.NewValue(tftypes.String, "us-east-1"),
+			"retries": tftypes.NewValue(tftypes.Number, 3),
+		}),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	calls := []rpccheck.Call{
+		{
+			Provider:        "test",
+			ProtocolVersion: 5,
+			RPC:             "ConfigureProvider",
+			Request:         &tfprotov5.ConfigureProviderRequest{Config: &config},
+			Response:        &tfprotov5.ConfigureProviderResponse{},
+			ProviderSchema:  providerSchema,
+		},
+	}
+
+	testCases := map[string]struct {
+		check       rpccheck.RPCCheck
+		expectedErr *regexp.Regexp
+	}{
+		"match": {
+			check: rpccheck.ExpectConfigureProviderValue("test", tfjsonpath.New("region"), knownvalue.StringExact("us-east-1")),
+		},
+		"match-number": {
+			check: rpccheck.ExpectConfigureProviderValue("test", tfjsonpath.New("retries"), knownvalue.Int64Exact(3)),
+		},
+		"mismatch": {
+			check:       rpccheck.ExpectConfigureProviderValue("test", tfjsonpath.New("region"), knownvalue.StringExact("us-west-2")),
+			expectedErr: regexp.MustCompile(`^error checking value for ConfigureProvider of test at path: region, err: expected value us-west-2 for StringExact check, got: us-east-1$`),
+		},
+		"not-called": {
+			check:       rpccheck.ExpectConfigureProviderValue("other", tfjsonpath.New("region"), knownvalue.StringExact("us-east-1")),
+			expectedErr: regexp.MustCompile(`^other - ConfigureProvider was not called$`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := rpccheck.CheckRPCsResponse{}
+			testCase.check.CheckRPCs(context.Background(), rpccheck.CheckRPCsRequest{Calls: calls}, &resp)
+
+			assertError(t, resp.Error, testCase.expectedErr)
+		})
+	}
+}
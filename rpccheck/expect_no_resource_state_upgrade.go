@@ -0,0 +1,54 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+var _ RPCCheck = expectNoResourceStateUpgrade{}
+
+type expectNoResourceStateUpgrade struct {
+	typeName string
+	version  int64
+}
+
+// CheckRPCs implements the RPC check logic.
+func (e expectNoResourceStateUpgrade) CheckRPCs(ctx context.Context, req CheckRPCsRequest, resp *CheckRPCsResponse) {
+	for _, call := range req.Calls {
+		if call.RPC != "UpgradeResourceState" || call.TypeName() != e.typeName {
+			continue
+		}
+
+		var version int64
+
+		switch req := call.Request.(type) {
+		case *tfprotov5.UpgradeResourceStateRequest:
+			version = req.Version
+		case *tfprotov6.UpgradeResourceStateRequest:
+			version = req.Version
+		default:
+			continue
+		}
+
+		if version == e.version {
+			resp.Error = fmt.Errorf("%s - expected no UpgradeResourceState call for schema version %d, got one", e.typeName, e.version)
+
+			return
+		}
+	}
+}
+
+// ExpectNoResourceStateUpgrade returns an RPC check that asserts that UpgradeResourceState was not called for
+// the resource type name with prior state of the given schema version during the TestStep.
+func ExpectNoResourceStateUpgrade(typeName string, version int64) RPCCheck {
+	return expectNoResourceStateUpgrade{
+		typeName: typeName,
+		version:  version,
+	}
+}
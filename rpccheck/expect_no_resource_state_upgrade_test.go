@@ -0,0 +1,49 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-testing/rpccheck"
+)
+
+func TestExpectNoResourceStateUpgrade(t *testing.T) {
+	t.Parallel()
+
+	calls := []rpccheck.Call{
+		{Provider: "test", ProtocolVersion: 6, RPC: "UpgradeResourceState", Request: &tfprotov6.UpgradeResourceStateRequest{TypeName: "test_resource", Version: 1}},
+	}
+
+	testCases := map[string]struct {
+		check       rpccheck.RPCCheck
+		expectedErr *regexp.Regexp
+	}{
+		"other-version": {
+			check: rpccheck.ExpectNoResourceStateUpgrade("test_resource", 2),
+		},
+		"other-type-name": {
+			check: rpccheck.ExpectNoResourceStateUpgrade("test_other", 1),
+		},
+		"upgraded": {
+			check:       rpccheck.ExpectNoResourceStateUpgrade("test_resource", 1),
+			expectedErr: regexp.MustCompile(`^test_resource - expected no UpgradeResourceState call for schema version 1, got one$`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := rpccheck.CheckRPCsResponse{}
+			testCase.check.CheckRPCs(context.Background(), rpccheck.CheckRPCsRequest{Calls: calls}, &resp)
+
+			assertError(t, resp.Error, testCase.expectedErr)
+		})
+	}
+}
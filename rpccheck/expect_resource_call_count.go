@@ -0,0 +1,75 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ RPCCheck = expectResourceCallCount{}
+
+type expectResourceCallCount struct {
+	command  string
+	rpc      string
+	typeName string
+	count    int
+}
+
+// CheckRPCs implements the RPC check logic.
+func (e expectResourceCallCount) CheckRPCs(ctx context.Context, req CheckRPCsRequest, resp *CheckRPCsResponse) {
+	var count int
+
+	for _, call := range req.Calls {
+		if call.RPC != e.rpc || call.TypeName() != e.typeName {
+			continue
+		}
+
+		if e.command != "" && call.Command != e.command {
+			continue
+		}
+
+		count++
+	}
+
+	if count == e.count {
+		return
+	}
+
+	if e.command != "" {
+		resp.Error = fmt.Errorf("%s - expected %d %s call(s) during %s, got: %d", e.typeName, e.count, e.rpc, e.command, count)
+
+		return
+	}
+
+	resp.Error = fmt.Errorf("%s - expected %d %s call(s), got: %d", e.typeName, e.count, e.rpc, count)
+}
+
+// ExpectResourceCallCount returns an RPC check that asserts that the RPC, such as "ReadResource", was called
+// the given number of times with the given resource, data source, ephemeral resource, list resource, or action
+// type name during the TestStep. For example, asserting that ReadResource was called once during a
+// RefreshState TestStep detects unexpected additional reads of the remote object.
+func ExpectResourceCallCount(rpc string, typeName string, count int) RPCCheck {
+	return expectResourceCallCount{
+		rpc:      rpc,
+		typeName: typeName,
+		count:    count,
+	}
+}
+
+// ExpectCommandResourceCallCount returns an RPC check that asserts that the RPC, such as "ReadResource", was
+// called the given number of times with the given resource, data source, ephemeral resource, list resource, or
+// action type name during the given Terraform command of the TestStep, such as "plan" or "apply". The RPCs of
+// the refresh which Terraform runs as part of plan and apply are counted for that command, as described in the
+// Call Command documentation. For example, asserting that ReadResource was called once during "plan" of a
+// Config TestStep detects extra reads during the refresh of the plan, independently of the reads by the other
+// commands which the TestStep runs.
+func ExpectCommandResourceCallCount(command string, rpc string, typeName string, count int) RPCCheck {
+	return expectResourceCallCount{
+		command:  command,
+		rpc:      rpc,
+		typeName: typeName,
+		count:    count,
+	}
+}
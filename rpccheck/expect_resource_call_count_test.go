@@ -0,0 +1,64 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-testing/rpccheck"
+)
+
+func TestExpectResourceCallCount(t *testing.T) {
+	t.Parallel()
+
+	calls := []rpccheck.Call{
+		{Provider: "test", ProtocolVersion: 5, RPC: "ReadResource", Command: "plan", Request: &tfprotov5.ReadResourceRequest{TypeName: "test_resource"}},
+		{Provider: "test", ProtocolVersion: 6, RPC: "ReadResource", Command: "apply", Request: &tfprotov6.ReadResourceRequest{TypeName: "test_resource"}},
+		{Provider: "test", ProtocolVersion: 6, RPC: "ReadResource", Request: &tfprotov6.ReadResourceRequest{TypeName: "test_other"}},
+		{Provider: "test", ProtocolVersion: 6, RPC: "PlanResourceChange", Request: &tfprotov6.PlanResourceChangeRequest{TypeName: "test_resource"}},
+	}
+
+	testCases := map[string]struct {
+		check       rpccheck.RPCCheck
+		expectedErr *regexp.Regexp
+	}{
+		"match": {
+			check: rpccheck.ExpectResourceCallCount("ReadResource", "test_resource", 2),
+		},
+		"match-other": {
+			check: rpccheck.ExpectResourceCallCount("ReadResource", "test_other", 1),
+		},
+		"mismatch": {
+			check:       rpccheck.ExpectResourceCallCount("ReadResource", "test_resource", 1),
+			expectedErr: regexp.MustCompile(`^test_resource - expected 1 ReadResource call\(s\), got: 2$`),
+		},
+		"mismatch-type-name": {
+			check:       rpccheck.ExpectResourceCallCount("PlanResourceChange", "test_other", 1),
+			expectedErr: regexp.MustCompile(`^test_other - expected 1 PlanResourceChange call\(s\), got: 0$`),
+		},
+		"command-match": {
+			check: rpccheck.ExpectCommandResourceCallCount("plan", "ReadResource", "test_resource", 1),
+		},
+		"command-mismatch": {
+			check:       rpccheck.ExpectCommandResourceCallCount("refresh", "ReadResource", "test_resource", 1),
+			expectedErr: regexp.MustCompile(`^test_resource - expected 1 ReadResource call\(s\) during refresh, got: 0$`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := rpccheck.CheckRPCsResponse{}
+			testCase.check.CheckRPCs(context.Background(), rpccheck.CheckRPCsRequest{Calls: calls}, &resp)
+
+			assertError(t, resp.Error, testCase.expectedErr)
+		})
+	}
+}
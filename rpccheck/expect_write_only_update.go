@@ -0,0 +1,112 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ RPCCheck = expectWriteOnlyUpdate{}
+
+type expectWriteOnlyUpdate struct {
+	typeName       string
+	writeOnlyPath  tfjsonpath.Path
+	writeOnlyValue knownvalue.Check
+	versionPath    tfjsonpath.Path
+}
+
+// CheckRPCs implements the RPC check logic.
+func (e expectWriteOnlyUpdate) CheckRPCs(ctx context.Context, req CheckRPCsRequest, resp *CheckRPCsResponse) {
+	var updated bool
+	var errs []error
+
+	for _, call := range req.Calls {
+		if call.RPC != "ApplyResourceChange" || call.TypeName() != e.typeName {
+			continue
+		}
+
+		values, err := applyResourceChange(call)
+
+		if err != nil {
+			resp.Error = fmt.Errorf("%s - %w", e.typeName, err)
+
+			return
+		}
+
+		// Only in-place updates have both a prior and planned state.
+		if values.priorState == nil || values.plannedState == nil {
+			continue
+		}
+
+		priorVersion, err := tfjsonpath.Traverse(values.priorState, e.versionPath)
+
+		if err != nil {
+			resp.Error = err
+
+			return
+		}
+
+		configVersion, err := tfjsonpath.Traverse(values.config, e.versionPath)
+
+		if err != nil {
+			resp.Error = err
+
+			return
+		}
+
+		if reflect.DeepEqual(priorVersion, configVersion) {
+			continue
+		}
+
+		updated = true
+
+		if err := checkWriteOnlyNull(e.typeName, e.writeOnlyPath, values); err != nil {
+			errs = append(errs, err)
+		}
+
+		result, err := tfjsonpath.Traverse(values.config, e.writeOnlyPath)
+
+		if err != nil {
+			resp.Error = err
+
+			return
+		}
+
+		if err := e.writeOnlyValue.CheckValue(result); err != nil {
+			errs = append(errs, fmt.Errorf("error checking value for ApplyResourceChange configuration of %s at path: %s, err: %s", e.typeName, e.writeOnlyPath.String(), err))
+		}
+	}
+
+	if !updated {
+		resp.Error = fmt.Errorf("%s - expected ApplyResourceChange to update the resource in-place with a changed %s, got none", e.typeName, e.versionPath.String())
+
+		return
+	}
+
+	resp.Error = errors.Join(errs...)
+}
+
+// ExpectWriteOnlyUpdate returns an RPC check that asserts that a changed write-only attribute value, paired with
+// a version attribute which triggers its update, is sent to the provider during the TestStep. Terraform never
+// persists write-only attribute values, so providers conventionally detect a changed value by a change of another
+// attribute, such as "password_wo_version" for "password_wo".
+//
+// The check asserts that ApplyResourceChange was called at least once to update a resource with the given type
+// name in-place, with the configured value at versionPath differing from the prior state. For each of those
+// calls, the configured value at writeOnlyPath must match writeOnlyValue, and the planned state and new state
+// must be null at writeOnlyPath. Replacing the resource does not satisfy this check.
+func ExpectWriteOnlyUpdate(typeName string, writeOnlyPath tfjsonpath.Path, writeOnlyValue knownvalue.Check, versionPath tfjsonpath.Path) RPCCheck {
+	return expectWriteOnlyUpdate{
+		typeName:       typeName,
+		writeOnlyPath:  writeOnlyPath,
+		writeOnlyValue: writeOnlyValue,
+		versionPath:    versionPath,
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/rpccheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpectWriteOnlyUpdate(t *testing.T) {
+	t.Parallel()
+
+	prior := writeOnlyResourceValue(map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, "test"),
+		"password_wo_version": tftypes.NewValue(tftypes.Number, 1),
+	})
+	updated := writeOnlyResourceValue(map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, "test"),
+		"password_wo_version": tftypes.NewValue(tftypes.Number, 2),
+	})
+	updatedConfig := writeOnlyResourceValue(map[string]tftypes.Value{
+		"password_wo":         tftypes.NewValue(tftypes.String, "new"),
+		"password_wo_version": tftypes.NewValue(tftypes.Number, 2),
+	})
+	unchangedConfig := writeOnlyResourceValue(map[string]tftypes.Value{
+		"password_wo":         tftypes.NewValue(tftypes.String, "new"),
+		"password_wo_version": tftypes.NewValue(tftypes.Number, 1),
+	})
+	null := writeOnlyResourceValue(nil)
+
+	testCases := map[string]struct {
+		calls       []rpccheck.Call
+		check       rpccheck.RPCCheck
+		expectedErr *regexp.Regexp
+	}{
+		"update": {
+			calls: []rpccheck.Call{
+				applyResourceChangeCall(t, updatedConfig, prior, updated, updated),
+			},
+			check: rpccheck.ExpectWriteOnlyUpdate("test_resource", tfjsonpath.New("password_wo"), knownvalue.StringExact("new"), tfjsonpath.New("password_wo_version")),
+		},
+		"update-value-mismatch": {
+			calls: []rpccheck.Call{
+				applyResourceChangeCall(t, updatedConfig, prior, updated, updated),
+			},
+			check:       rpccheck.ExpectWriteOnlyUpdate("test_resource", tfjsonpath.New("password_wo"), knownvalue.StringExact("old"), tfjsonpath.New("password_wo_version")),
+			expectedErr: regexp.MustCompile(`^error checking value for ApplyResourceChange configuration of test_resource at path: password_wo, err: expected value old for StringExact check, got: new$`),
+		},
+		"version-unchanged": {
+			calls: []rpccheck.Call{
+				applyResourceChangeCall(t, unchangedConfig, prior, prior, prior),
+			},
+			check:       rpccheck.ExpectWriteOnlyUpdate("test_resource", tfjsonpath.New("password_wo"), knownvalue.StringExact("new"), tfjsonpath.New("password_wo_version")),
+			expectedErr: regexp.MustCompile(`^test_resource - expected ApplyResourceChange to update the resource in-place with a changed password_wo_version, got none$`),
+		},
+		"replace": {
+			calls: []rpccheck.Call{
+				applyResourceChangeCall(t, null, prior, null, null),
+				applyResourceChangeCall(t, updatedConfig, null, updated, updated),
+			},
+			check:       rpccheck.ExpectWriteOnlyUpdate("test_resource", tfjsonpath.New("password_wo"), knownvalue.StringExact("new"), tfjsonpath.New("password_wo_version")),
+			expectedErr: regexp.MustCompile(`^test_resource - expected ApplyResourceChange to update the resource in-place with a changed password_wo_version, got none$`),
+		},
+		"invalid-path": {
+			calls: []rpccheck.Call{
+				applyResourceChangeCall(t, updatedConfig, prior, updated, updated),
+			},
+			check:       rpccheck.ExpectWriteOnlyUpdate("test_resource", tfjsonpath.New("password_wo"), knownvalue.StringExact("new"), tfjsonpath.New("version")),
+			expectedErr: regexp.MustCompile(`^path not found: specified key version not found in map at version$`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := rpccheck.CheckRPCsResponse{}
+			testCase.check.CheckRPCs(context.Background(), rpccheck.CheckRPCsRequest{Calls: testCase.calls}, &resp)
+
+			assertError(t, resp.Error, testCase.expectedErr)
+		})
+	}
+}
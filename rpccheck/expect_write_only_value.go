@@ -0,0 +1,122 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ RPCCheck = expectWriteOnlyValue{}
+
+type expectWriteOnlyValue struct {
+	typeName      string
+	attributePath tfjsonpath.Path
+	knownValue    knownvalue.Check
+}
+
+// CheckRPCs implements the RPC check logic.
+func (e expectWriteOnlyValue) CheckRPCs(ctx context.Context, req CheckRPCsRequest, resp *CheckRPCsResponse) {
+	var called bool
+	var errs []error
+
+	for _, call := range req.Calls {
+		if call.RPC != "ApplyResourceChange" || call.TypeName() != e.typeName {
+			continue
+		}
+
+		values, err := applyResourceChange(call)
+
+		if err != nil {
+			resp.Error = fmt.Errorf("%s - %w", e.typeName, err)
+
+			return
+		}
+
+		// Skip destroy, which has no configuration.
+		if values.plannedState == nil {
+			continue
+		}
+
+		called = true
+
+		if err := checkWriteOnlyNull(e.typeName, e.attributePath, values); err != nil {
+			errs = append(errs, err)
+		}
+
+		result, err := tfjsonpath.Traverse(values.config, e.attributePath)
+
+		if err != nil {
+			resp.Error = err
+
+			return
+		}
+
+		if err := e.knownValue.CheckValue(result); err != nil {
+			errs = append(errs, fmt.Errorf("error checking value for ApplyResourceChange configuration of %s at path: %s, err: %s", e.typeName, e.attributePath.String(), err))
+		}
+	}
+
+	if !called {
+		resp.Error = fmt.Errorf("%s - ApplyResourceChange was not called to create or update the resource", e.typeName)
+
+		return
+	}
+
+	resp.Error = errors.Join(errs...)
+}
+
+// checkWriteOnlyNull returns an error if the write-only attribute at the
+// given path is not null in the planned state or new state of an
+// ApplyResourceChange call. The value is not included in the error.
+func checkWriteOnlyNull(typeName string, attributePath tfjsonpath.Path, values applyResourceChangeValues) error {
+	var errs []error
+
+	for _, state := range []struct {
+		name  string
+		value any
+	}{
+		{name: "planned state", value: values.plannedState},
+		{name: "new state", value: values.newState},
+	} {
+		if state.value == nil {
+			continue
+		}
+
+		result, err := tfjsonpath.Traverse(state.value, attributePath)
+
+		if err != nil {
+			return err
+		}
+
+		switch result.(type) {
+		case nil:
+		case unknownValue:
+			errs = append(errs, fmt.Errorf("%s - expected null ApplyResourceChange %s for write-only attribute at path: %s, got: unknown", typeName, state.name, attributePath.String()))
+		default:
+			errs = append(errs, fmt.Errorf("%s - expected null ApplyResourceChange %s for write-only attribute at path: %s, got: non-null value", typeName, state.name, attributePath.String()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ExpectWriteOnlyValue returns an RPC check that asserts that every ApplyResourceChange call which creates or
+// updates a resource with the given type name during the TestStep received configuration with the specified
+// value at the given path of a write-only attribute, and that the planned state and new state of the call are
+// null at that path. ApplyResourceChange must be called at least once to create or update the resource.
+//
+// Use plancheck.ExpectWriteOnlyNull and statecheck.ExpectWriteOnlyNull to assert that the value is null in the
+// plan and state output by Terraform.
+func ExpectWriteOnlyValue(typeName string, attributePath tfjsonpath.Path, knownValue knownvalue.Check) RPCCheck {
+	return expectWriteOnlyValue{
+		typeName:      typeName,
+		attributePath: attributePath,
+		knownValue:    knownValue,
+	}
+}
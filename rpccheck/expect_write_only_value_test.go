@@ -0,0 +1,179 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/rpccheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var writeOnlyProviderSchema = &tfprotov6.GetProviderSchemaResponse{
+	ResourceSchemas: map[string]*tfprotov6.Schema{
+		"test_resource": {
+			Block: &tfprotov6.SchemaBlock{
+				Attributes: []*tfprotov6.SchemaAttribute{
+					{
+						Name:     "id",
+						Type:     tftypes.String,
+						Computed: true,
+					},
+					{
+						Name:      "password_wo",
+						Type:      tftypes.String,
+						Optional:  true,
+						WriteOnly: true,
+					},
+					{
+						Name:     "password_wo_version",
+						Type:     tftypes.Number,
+						Optional: true,
+					},
+				},
+			},
+		},
+	},
+}
+
+// writeOnlyResourceValue returns a test_resource value, or a null value if
+// attributes is nil. Unset attributes are null.
+func writeOnlyResourceValue(attributes map[string]tftypes.Value) tftypes.Value {
+	objectType := writeOnlyProviderSchema.ResourceSchemas["test_resource"].ValueType()
+
+	if attributes == nil {
+		return tftypes.NewValue(objectType, nil)
+	}
+
+	values := map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, nil),
+		"password_wo":         tftypes.NewValue(tftypes.String, nil),
+		"password_wo_version": tftypes.NewValue(tftypes.Number, nil),
+	}
+
+	for name, value := range attributes {
+		values[name] = value
+	}
+
+	return tftypes.NewValue(objectType, values)
+}
+
+// applyResourceChangeCall returns a test_resource ApplyResourceChange call.
+func applyResourceChangeCall(t *testing.T, config, priorState, plannedState, newState tftypes.Value) rpccheck.Call {
+	t.Helper()
+
+	objectType := writeOnlyProviderSchema.ResourceSchemas["test_resource"].ValueType()
+	dynamicValues := make([]*tfprotov6.DynamicValue, 0, 4)
+
+	for _, value := range []tftypes.Value{config, priorState, plannedState, newState} {
+		dynamicValue, err := tfprotov6.NewDynamicValue(objectType, value)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		dynamicValues = append(dynamicValues, &dynamicValue)
+	}
+
+	return rpccheck.Call{
+		Provider:        "test",
+		ProtocolVersion: 6,
+		RPC:             "ApplyResourceChange",
+		Request: &tfprotov6.ApplyResourceChangeRequest{
+			TypeName:     "test_resource",
+			Config:       dynamicValues[0],
+			PriorState:   dynamicValues[1],
+			PlannedState: dynamicValues[2],
+		},
+		Response: &tfprotov6.ApplyResourceChangeResponse{
+			NewState: dynamicValues[3],
+		},
+		ProviderSchema: writeOnlyProviderSchema,
+	}
+}
+
+func TestExpectWriteOnlyValue(t *testing.T) {
+	t.Parallel()
+
+	config := writeOnlyResourceValue(map[string]tftypes.Value{
+		"password_wo": tftypes.NewValue(tftypes.String, "hunter2"),
+	})
+	created := writeOnlyResourceValue(map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, "test"),
+	})
+	planned := writeOnlyResourceValue(map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	leaked := writeOnlyResourceValue(map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "test"),
+		"password_wo": tftypes.NewValue(tftypes.String, "hunter2"),
+	})
+	unknown := writeOnlyResourceValue(map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"password_wo": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	null := writeOnlyResourceValue(nil)
+
+	testCases := map[string]struct {
+		calls       []rpccheck.Call
+		check       rpccheck.RPCCheck
+		expectedErr *regexp.Regexp
+	}{
+		"match": {
+			calls: []rpccheck.Call{
+				applyResourceChangeCall(t, config, null, planned, created),
+			},
+			check: rpccheck.ExpectWriteOnlyValue("test_resource", tfjsonpath.New("password_wo"), knownvalue.StringExact("hunter2")),
+		},
+		"mismatch": {
+			calls: []rpccheck.Call{
+				applyResourceChangeCall(t, config, null, planned, created),
+			},
+			check:       rpccheck.ExpectWriteOnlyValue("test_resource", tfjsonpath.New("password_wo"), knownvalue.StringExact("other")),
+			expectedErr: regexp.MustCompile(`^error checking value for ApplyResourceChange configuration of test_resource at path: password_wo, err: expected value other for StringExact check, got: hunter2$`),
+		},
+		"new-state-not-null": {
+			calls: []rpccheck.Call{
+				applyResourceChangeCall(t, config, null, planned, leaked),
+			},
+			check:       rpccheck.ExpectWriteOnlyValue("test_resource", tfjsonpath.New("password_wo"), knownvalue.StringExact("hunter2")),
+			expectedErr: regexp.MustCompile(`^test_resource - expected null ApplyResourceChange new state for write-only attribute at path: password_wo, got: non-null value$`),
+		},
+		"planned-state-unknown": {
+			calls: []rpccheck.Call{
+				applyResourceChangeCall(t, config, null, unknown, created),
+			},
+			check:       rpccheck.ExpectWriteOnlyValue("test_resource", tfjsonpath.New("password_wo"), knownvalue.StringExact("hunter2")),
+			expectedErr: regexp.MustCompile(`^test_resource - expected null ApplyResourceChange planned state for write-only attribute at path: password_wo, got: unknown$`),
+		},
+		"destroy-only": {
+			calls: []rpccheck.Call{
+				applyResourceChangeCall(t, null, created, null, null),
+			},
+			check:       rpccheck.ExpectWriteOnlyValue("test_resource", tfjsonpath.New("password_wo"), knownvalue.StringExact("hunter2")),
+			expectedErr: regexp.MustCompile(`^test_resource - ApplyResourceChange was not called to create or update the resource$`),
+		},
+		"not-called": {
+			check:       rpccheck.ExpectWriteOnlyValue("test_resource", tfjsonpath.New("password_wo"), knownvalue.StringExact("hunter2")),
+			expectedErr: regexp.MustCompile(`^test_resource - ApplyResourceChange was not called to create or update the resource$`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := rpccheck.CheckRPCsResponse{}
+			testCase.check.CheckRPCs(context.Background(), rpccheck.CheckRPCsRequest{Calls: testCase.calls}, &resp)
+
+			assertError(t, resp.Error, testCase.expectedErr)
+		})
+	}
+}
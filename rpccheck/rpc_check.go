@@ -0,0 +1,30 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck
+
+import (
+	"context"
+)
+
+// RPCCheck defines an interface for implementing test logic that checks the RPCs Terraform called on the
+// in-process provider servers during a TestStep and then returns an error if the calls do not match what is expected.
+type RPCCheck interface {
+	// CheckRPCs should perform the RPC check.
+	CheckRPCs(context.Context, CheckRPCsRequest, *CheckRPCsResponse)
+}
+
+// CheckRPCsRequest is a request for an invoke of the CheckRPCs function.
+type CheckRPCsRequest struct {
+	// Calls are the RPCs called on the in-process provider servers during the TestStep, in the order they
+	// were called, across all Terraform commands run by the TestStep. Each Call Command is the Terraform
+	// command which called it.
+	Calls []Call
+}
+
+// CheckRPCsResponse is a response to an invoke of the CheckRPCs function.
+type CheckRPCsResponse struct {
+	// Error is used to report the failure of an RPC check assertion and is combined with other RPCCheck errors
+	// to be reported as a test failure.
+	Error error
+}
@@ -0,0 +1,244 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package rpccheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// configureProviderConfig returns the provider configuration of a ConfigureProvider call, decoded with the
+// provider schema into the representation used by the knownvalue and tfjsonpath packages.
+func configureProviderConfig(call Call) (any, error) {
+	var config tftypes.Value
+
+	switch req := call.Request.(type) {
+	case *tfprotov5.ConfigureProviderRequest:
+		schema, ok := call.ProviderSchema.(*tfprotov5.GetProviderSchemaResponse)
+
+		if !ok || schema == nil || schema.Provider == nil {
+			return nil, errors.New("provider schema not available")
+		}
+
+		if req.Config == nil {
+			return nil, nil
+		}
+
+		var err error
+
+		config, err = req.Config.Unmarshal(schema.Provider.ValueType())
+
+		if err != nil {
+			return nil, fmt.Errorf("decoding provider configuration: %w", err)
+		}
+	case *tfprotov6.ConfigureProviderRequest:
+		schema, ok := call.ProviderSchema.(*tfprotov6.GetProviderSchemaResponse)
+
+		if !ok || schema == nil || schema.Provider == nil {
+			return nil, errors.New("provider schema not available")
+		}
+
+		if req.Config == nil {
+			return nil, nil
+		}
+
+		var err error
+
+		config, err = req.Config.Unmarshal(schema.Provider.ValueType())
+
+		if err != nil {
+			return nil, fmt.Errorf("decoding provider configuration: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected ConfigureProvider request type %T", call.Request)
+	}
+
+	return jsonValue(config)
+}
+
+// unknownValue represents an unknown value decoded by jsonValueWithUnknown.
+type unknownValue struct{}
+
+// dynamicValue is a *tfprotov5.DynamicValue or *tfprotov6.DynamicValue.
+type dynamicValue interface {
+	Unmarshal(tftypes.Type) (tftypes.Value, error)
+}
+
+// applyResourceChangeValues are the values of an ApplyResourceChange call, in
+// the representation used by the knownvalue and tfjsonpath packages.
+type applyResourceChangeValues struct {
+	config       any
+	priorState   any
+	plannedState any
+	newState     any
+}
+
+// applyResourceChange returns the values of an ApplyResourceChange call,
+// decoded with the resource schema. Unknown values in the planned state are
+// decoded as unknownValue. The new state is nil if the call returned an
+// error.
+func applyResourceChange(call Call) (applyResourceChangeValues, error) {
+	var valueType tftypes.Type
+	var config, priorState, plannedState, newState dynamicValue
+
+	switch req := call.Request.(type) {
+	case *tfprotov5.ApplyResourceChangeRequest:
+		schema, ok := call.ProviderSchema.(*tfprotov5.GetProviderSchemaResponse)
+
+		if !ok || schema == nil || schema.ResourceSchemas[req.TypeName] == nil {
+			return applyResourceChangeValues{}, errors.New("resource schema not available")
+		}
+
+		valueType = schema.ResourceSchemas[req.TypeName].ValueType()
+		config, priorState, plannedState = req.Config, req.PriorState, req.PlannedState
+
+		if resp, ok := call.Response.(*tfprotov5.ApplyResourceChangeResponse); ok && resp != nil && resp.NewState != nil {
+			newState = resp.NewState
+		}
+	case *tfprotov6.ApplyResourceChangeRequest:
+		schema, ok := call.ProviderSchema.(*tfprotov6.GetProviderSchemaResponse)
+
+		if !ok || schema == nil || schema.ResourceSchemas[req.TypeName] == nil {
+			return applyResourceChangeValues{}, errors.New("resource schema not available")
+		}
+
+		valueType = schema.ResourceSchemas[req.TypeName].ValueType()
+		config, priorState, plannedState = req.Config, req.PriorState, req.PlannedState
+
+		if resp, ok := call.Response.(*tfprotov6.ApplyResourceChangeResponse); ok && resp != nil && resp.NewState != nil {
+			newState = resp.NewState
+		}
+	default:
+		return applyResourceChangeValues{}, fmt.Errorf("unexpected ApplyResourceChange request type %T", call.Request)
+	}
+
+	var result applyResourceChangeValues
+
+	for _, field := range []struct {
+		name   string
+		value  dynamicValue
+		result *any
+	}{
+		{name: "configuration", value: config, result: &result.config},
+		{name: "prior state", value: priorState, result: &result.priorState},
+		{name: "planned state", value: plannedState, result: &result.plannedState},
+		{name: "new state", value: newState, result: &result.newState},
+	} {
+		if field.value == nil || reflect.ValueOf(field.value).IsNil() {
+			continue
+		}
+
+		value, err := field.value.Unmarshal(valueType)
+
+		if err != nil {
+			return applyResourceChangeValues{}, fmt.Errorf("decoding %s: %w", field.name, err)
+		}
+
+		*field.result, err = jsonValueWithUnknown(value)
+
+		if err != nil {
+			return applyResourceChangeValues{}, fmt.Errorf("decoding %s: %w", field.name, err)
+		}
+	}
+
+	return result, nil
+}
+
+// jsonValue returns the representation of a value as decoded from JSON, such
+// as string, json.Number, bool, []any, and map[string]any.
+func jsonValue(value tftypes.Value) (any, error) {
+	return decodeValue(value, false)
+}
+
+// jsonValueWithUnknown is jsonValue, except unknown values are decoded as
+// unknownValue.
+func jsonValueWithUnknown(value tftypes.Value) (any, error) {
+	return decodeValue(value, true)
+}
+
+func decodeValue(value tftypes.Value, allowUnknown bool) (any, error) {
+	if !value.IsKnown() {
+		if allowUnknown {
+			return unknownValue{}, nil
+		}
+
+		return nil, errors.New("value is unknown")
+	}
+
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var result string
+
+		err := value.As(&result)
+
+		return result, err
+	case value.Type().Is(tftypes.Number):
+		var result big.Float
+
+		if err := value.As(&result); err != nil {
+			return nil, err
+		}
+
+		return json.Number(result.Text('f', -1)), nil
+	case value.Type().Is(tftypes.Bool):
+		var result bool
+
+		err := value.As(&result)
+
+		return result, err
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Set{}), value.Type().Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+
+		result := make([]any, 0, len(elements))
+
+		for _, element := range elements {
+			v, err := decodeValue(element, allowUnknown)
+
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, v)
+		}
+
+		return result, nil
+	case value.Type().Is(tftypes.Map{}), value.Type().Is(tftypes.Object{}):
+		var attributes map[string]tftypes.Value
+
+		if err := value.As(&attributes); err != nil {
+			return nil, err
+		}
+
+		result := make(map[string]any, len(attributes))
+
+		for name, attribute := range attributes {
+			v, err := decodeValue(attribute, allowUnknown)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			result[name] = v
+		}
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value type %s", value.Type())
+}
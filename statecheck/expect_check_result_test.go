@@ -0,0 +1,163 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck_test
+
+import (
+	"regexp"
+	"testing"
+
+	r "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestExpectCheckResult_CheckBlockFail(t *testing.T) {
+	t.Parallel()
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0), // check blocks
+		},
+		ExternalProviders: map[string]r.ExternalProvider{
+			"terraform": {Source: "terraform.io/builtin/terraform"},
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "terraform_data" "test" {
+					input = "hello"
+
+					lifecycle {
+						postcondition {
+							condition     = self.input == "hello"
+							error_message = "input must be hello"
+						}
+					}
+				}
+
+				check "example" {
+					assert {
+						condition     = terraform_data.test.input == "world"
+						error_message = "input must be world"
+					}
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectCheckResult("check.example", statecheck.CheckStatusFail),
+				},
+			},
+		},
+	})
+}
+
+func TestExpectCheckResult_Postcondition(t *testing.T) {
+	t.Parallel()
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0), // check blocks
+		},
+		ExternalProviders: map[string]r.ExternalProvider{
+			"terraform": {Source: "terraform.io/builtin/terraform"},
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "terraform_data" "test" {
+					input = "hello"
+
+					lifecycle {
+						postcondition {
+							condition     = self.input == "hello"
+							error_message = "input must be hello"
+						}
+					}
+				}
+
+				check "example" {
+					assert {
+						condition     = terraform_data.test.input == "world"
+						error_message = "input must be world"
+					}
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectCheckResult("terraform_data.test", statecheck.CheckStatusPass),
+				},
+			},
+		},
+	})
+}
+
+func TestExpectCheckResult_StatusMismatch(t *testing.T) {
+	t.Parallel()
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0), // check blocks
+		},
+		ExternalProviders: map[string]r.ExternalProvider{
+			"terraform": {Source: "terraform.io/builtin/terraform"},
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "terraform_data" "test" {
+					input = "hello"
+
+					lifecycle {
+						postcondition {
+							condition     = self.input == "hello"
+							error_message = "input must be hello"
+						}
+					}
+				}
+
+				check "example" {
+					assert {
+						condition     = terraform_data.test.input == "world"
+						error_message = "input must be world"
+					}
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectCheckResult("check.example", statecheck.CheckStatusPass),
+				},
+				ExpectError: regexp.MustCompile(`check.example - expected check status pass, got: fail\n\ninput must be world`),
+			},
+		},
+	})
+}
+
+func TestExpectCheckResult_NotFound(t *testing.T) {
+	t.Parallel()
+
+	r.UnitTest(t, r.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0), // check blocks
+		},
+		ExternalProviders: map[string]r.ExternalProvider{
+			"terraform": {Source: "terraform.io/builtin/terraform"},
+		},
+		Steps: []r.TestStep{
+			{
+				Config: `resource "terraform_data" "test" {
+					input = "hello"
+
+					lifecycle {
+						postcondition {
+							condition     = self.input == "hello"
+							error_message = "input must be hello"
+						}
+					}
+				}
+
+				check "example" {
+					assert {
+						condition     = terraform_data.test.input == "world"
+						error_message = "input must be world"
+					}
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectCheckResult("check.missing", statecheck.CheckStatusPass),
+				},
+				ExpectError: regexp.MustCompile(`check.missing - Check not found in state Checks`),
+			},
+		},
+	})
+}
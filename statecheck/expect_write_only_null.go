@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ StateCheck = expectWriteOnlyNull{}
+
+type expectWriteOnlyNull struct {
+	resourceAddress string
+	attributePath   tfjsonpath.Path
+}
+
+// CheckState implements the state check logic.
+func (e expectWriteOnlyNull) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	var resource *tfjson.StateResource
+
+	if req.State == nil {
+		resp.Error = fmt.Errorf("state is nil")
+
+		return
+	}
+
+	if req.State.Values == nil {
+		resp.Error = fmt.Errorf("state does not contain any state values")
+
+		return
+	}
+
+	if req.State.Values.RootModule == nil {
+		resp.Error = fmt.Errorf("state does not contain a root module")
+
+		return
+	}
+
+	for _, r := range req.State.Values.RootModule.Resources {
+		if e.resourceAddress == r.Address {
+			resource = r
+
+			break
+		}
+	}
+
+	if resource == nil {
+		resp.Error = fmt.Errorf("%s - Resource not found in state", e.resourceAddress)
+
+		return
+	}
+
+	result, err := tfjsonpath.Traverse(resource.AttributeValues, e.attributePath)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if result != nil {
+		resp.Error = fmt.Errorf("expected null value for write-only attribute at path: %s.%s, got: non-null value", e.resourceAddress, e.attributePath.String())
+
+		return
+	}
+}
+
+// ExpectWriteOnlyNull returns a state check that asserts that the specified write-only attribute at the given
+// resource is null in state. Terraform never persists write-only attribute values, so a provider must always
+// return null for them.
+//
+// The value is not output on failure. Use the TestCase SecretScanner to assert that the value is not found
+// anywhere in the state file, plan, or logs, such as in another attribute.
+func ExpectWriteOnlyNull(resourceAddress string, attributePath tfjsonpath.Path) StateCheck {
+	return expectWriteOnlyNull{
+		resourceAddress: resourceAddress,
+		attributePath:   attributePath,
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpectWriteOnlyNull_CheckState(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		address         string
+		attributeValues map[string]any
+		expectedErr     error
+	}{
+		"null": {
+			address: "example_resource.test",
+			attributeValues: map[string]any{
+				"id":          "test",
+				"password_wo": nil,
+			},
+		},
+		"not-null": {
+			address: "example_resource.test",
+			attributeValues: map[string]any{
+				"id":          "test",
+				"password_wo": "hunter2",
+			},
+			expectedErr: fmt.Errorf("expected null value for write-only attribute at path: example_resource.test.password_wo, got: non-null value"),
+		},
+		"path-not-found": {
+			address: "example_resource.test",
+			attributeValues: map[string]any{
+				"id": "test",
+			},
+			expectedErr: fmt.Errorf("path not found: specified key password_wo not found in map at password_wo"),
+		},
+		"resource-not-found": {
+			address:     "example_resource.other",
+			expectedErr: fmt.Errorf("example_resource.test - Resource not found in state"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			e := statecheck.ExpectWriteOnlyNull("example_resource.test", tfjsonpath.New("password_wo"))
+
+			req := statecheck.CheckStateRequest{
+				State: &tfjson.State{
+					Values: &tfjson.StateValues{
+						RootModule: &tfjson.StateModule{
+							Resources: []*tfjson.StateResource{
+								{
+									Address:         testCase.address,
+									AttributeValues: testCase.attributeValues,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			resp := statecheck.CheckStateResponse{}
+
+			e.CheckState(context.Background(), req, &resp)
+
+			if diff := cmp.Diff(resp.Error, testCase.expectedErr, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}